@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"k8s-recovery-visualizer/internal/gate"
+	"k8s-recovery-visualizer/internal/model"
+	"k8s-recovery-visualizer/internal/output"
 )
 
 type Direction string
@@ -59,8 +63,17 @@ func main() {
 	maxRisk := flag.String("max-risk", "MODERATE", "Highest allowed risk posture: LOW|MODERATE|HIGH|CRITICAL")
 	maxDrop := flag.Float64("max-drop", 0, "Max allowed score drop vs previous run (points). 0 disables.")
 	maxDropPct := flag.Float64("max-drop-pct", 0, "Max allowed score drop vs previous run (percent). 0 disables.")
+	gateFile := flag.String("gate", "", "Gate YAML (severity maxima, forbidden IDs, domain score minima, backup coverage, no new CRITICAL); evaluated against --scan instead of the risk checks")
+	scanFile := flag.String("scan", "out/recovery-scan.json", "Path to recovery-scan.json (with --gate)")
+	baselineFile := flag.String("baseline", "", "Previous recovery-scan.json for the gate's noNewCritical rule")
+	junitOut := flag.String("junit", "", "Write the gate result as JUnit XML to this path")
+	sarifOut := flag.String("sarif", "", "Write the gate's failed rules as SARIF 2.1.0 to this path")
 	flag.Parse()
 
+	if *gateFile != "" {
+		os.Exit(runGate(*gateFile, *scanFile, *baselineFile, *junitOut, *sarifOut))
+	}
+
 	b, err := os.ReadFile(*in)
 	if err != nil {
 		fmt.Printf("CHECK FAIL: cannot read %s (%v)\n", *in, err)
@@ -112,3 +125,69 @@ func main() {
 
 	fmt.Printf("CHECK PASS: score=%.2f maturity=%s\n", en.Risk.Score, en.Risk.Maturity)
 }
+
+// runGate evaluates the gate file against the scan, writes the optional
+// JUnit and SARIF reports and returns the exit code: 0 pass, 1 fail, 2 when
+// an input cannot be read.
+func runGate(gateFile, scanFile, baselineFile, junitOut, sarifOut string) int {
+	g, err := gate.Load(gateFile)
+	if err != nil {
+		fmt.Printf("CHECK FAIL: %v\n", err)
+		return 2
+	}
+	scan, err := readScan(scanFile)
+	if err != nil {
+		fmt.Printf("CHECK FAIL: %v\n", err)
+		return 2
+	}
+	var baseline *model.Bundle
+	if baselineFile != "" {
+		if baseline, err = readScan(baselineFile); err != nil {
+			fmt.Printf("CHECK FAIL: %v\n", err)
+			return 2
+		}
+	}
+
+	res := gate.Evaluate(g, scan, baseline)
+	for _, r := range res.Rules {
+		switch {
+		case r.Skipped:
+			fmt.Printf("CHECK NOTE: %s skipped (%s)\n", r.ID, r.Message)
+		case r.Passed:
+			fmt.Printf("CHECK OK: %s: %s\n", r.ID, r.Message)
+		default:
+			fmt.Printf("CHECK FAIL: %s: %s\n", r.ID, r.Message)
+		}
+	}
+
+	if junitOut != "" {
+		if err := output.WriteJUnit(junitOut, gate.JUnit(res, scanFile)); err != nil {
+			fmt.Printf("CHECK FAIL: cannot write %s (%v)\n", junitOut, err)
+			return 2
+		}
+	}
+	if sarifOut != "" {
+		if err := output.WriteSARIF(sarifOut, gate.SARIF(res, scanFile)); err != nil {
+			fmt.Printf("CHECK FAIL: cannot write %s (%v)\n", sarifOut, err)
+			return 2
+		}
+	}
+
+	if !res.Passed() {
+		return 1
+	}
+	fmt.Printf("CHECK PASS: gate %s, score=%d maturity=%s\n", gateFile, scan.Score.Overall.Final, scan.Score.Maturity)
+	return 0
+}
+
+func readScan(path string) (*model.Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s (%v)", path, err)
+	}
+	var b model.Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s (%v)", path, err)
+	}
+	return &b, nil
+}
@@ -6,24 +6,33 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"k8s-recovery-visualizer/internal/analyze"
+	"k8s-recovery-visualizer/internal/apps"
 	"k8s-recovery-visualizer/internal/backup"
+	"k8s-recovery-visualizer/internal/capacity"
 	"k8s-recovery-visualizer/internal/collect"
 	"k8s-recovery-visualizer/internal/compare"
 	"k8s-recovery-visualizer/internal/enrich"
 	"k8s-recovery-visualizer/internal/history"
 	"k8s-recovery-visualizer/internal/kube"
+	"k8s-recovery-visualizer/internal/metrics"
 	"k8s-recovery-visualizer/internal/model"
+	"k8s-recovery-visualizer/internal/operator"
 	"k8s-recovery-visualizer/internal/output"
+	"k8s-recovery-visualizer/internal/portability"
 	"k8s-recovery-visualizer/internal/profile"
 	"k8s-recovery-visualizer/internal/remediation"
 	"k8s-recovery-visualizer/internal/restore"
+	"k8s-recovery-visualizer/internal/targets"
+	"k8s-recovery-visualizer/internal/waiver"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
 func main() {
@@ -33,7 +42,9 @@ func main() {
 		dryRun     = flag.Bool("dry-run", false, "Run without Kubernetes")
 		ci         = flag.Bool("ci", false, "CI mode (machine-readable output)")
 		minScore   = flag.Int("min-score", 90, "Minimum acceptable DR score")
-		timeoutSec = flag.Int("timeout", 60, "Timeout in seconds for Kubernetes API calls")
+		timeoutSec = flag.Int("timeout", 60, "Timeout in seconds for each collector's Kubernetes API calls")
+		parallel   = flag.Int("parallel", 4, "Maximum number of collectors to run concurrently")
+		pageSize   = flag.Int64("page-size", 500, "Items per List page for large resources (pods, secrets, configmaps); 0 = no paging")
 		customerID = flag.String("customer", "", "Customer identifier (optional)")
 		site       = flag.String("site", "", "Site/region name (optional)")
 		cluster    = flag.String("cluster", "", "Cluster name (optional)")
@@ -46,52 +57,152 @@ func main() {
 		redactOut   = flag.Bool("redact", false, "Also write redacted JSON and HTML with masked identifiers")
 		profileName = flag.String("profile", "standard", "Scoring profile: standard|enterprise|dev|airgap")
 		runbook     = flag.Bool("runbook", false, "Also write a customer-facing DR runbook HTML")
+		sarifOut    = flag.Bool("sarif", false, "Also write findings as SARIF 2.1.0 (recovery-findings.sarif) for code-scanning dashboards")
+		junitOut    = flag.Bool("junit", false, "Also write DR checks as JUnit XML (recovery-checks.xml) for CI test reports")
 		insecure    = flag.Bool("insecure", false, "Skip TLS certificate verification (use for self-signed certs, e.g. RKE2/k3s)")
+		fromDir     = flag.String("from-dir", "", "Scan offline from a directory of kubectl YAML/JSON dumps instead of a live cluster")
+		rulesFile   = flag.String("rules-file", "", "Rules profile JSON (e.g. profiles/default.json) to enable/disable findings and override severity, penalty and params")
+		waiversFile = flag.String("waivers", "", "Waivers YAML accepting known risks (finding ID + resource/namespace glob, owner, justification, expiry)")
+		targetsFile   = flag.String("targets", "", "Recovery targets YAML with per-namespace RPO/RTO objectives (dr.example/rpo and dr.example/rto annotations take precedence)")
+		providersFile = flag.String("backup-providers", "", "Backup provider spec YAML adding detectors for backup products by namespace, CRD group and pod label")
+		rtoModelFile  = flag.String("rto-model", "", "Restore time assumptions YAML: throughput per provisioner or backup tool, per-PVC/object/image costs, parallel namespaces")
+		targetKubeconfig = flag.String("target-kubeconfig", "", "Kubeconfig of the DR target cluster to check portability against (StorageClasses, CSI drivers, snapshot classes, APIs, IngressClasses, capacity, version)")
+		targetScan       = flag.String("target-scan", "", "recovery-scan.json of the DR target cluster, instead of --target-kubeconfig")
+		drCapacityFile   = flag.String("dr-capacity", "", "DR site node pools YAML (count, cpu, memory, pods) to check the protected workloads fit; defaults to the DR target cluster's nodes")
+		serveMetrics     = flag.String("serve-metrics", "", "Run as a Prometheus exporter: rescan every --metrics-interval and serve /metrics on this address (e.g. :9108)")
+		metricsInterval  = flag.Duration("metrics-interval", 15*time.Minute, "Time between scans with --serve-metrics")
+		operatorMode      = flag.Bool("operator", false, "Run as an in-cluster operator: scan each DRScan resource on its schedule and write results to its status and a ConfigMap")
+		operatorNamespace = flag.String("operator-namespace", "", "Namespace whose DRScan resources --operator reconciles (empty = all namespaces); only DRScans there may scan other namespaces")
+		operatorResync    = flag.Duration("operator-resync", time.Minute, "How often --operator checks DRScan resources for due scans")
+		watchMode         = flag.Bool("watch", false, "With --serve-metrics or --operator: keep built-in resources in watch-fed caches instead of re-listing them each scan; --serve-metrics also rescans when they change")
+		watchQuiet        = flag.Duration("watch-quiet", 10*time.Second, "With --watch: rescan once watched resources have been unchanged this long")
+		watchMaxDelay     = flag.Duration("watch-max-delay", time.Minute, "With --watch: rescan at most this long after the first change, even if changes continue")
 	)
 	flag.Parse()
 
 	if *target != "baremetal" && *target != "vm" {
 		log.Fatalf("--target must be 'baremetal' or 'vm', got %q", *target)
 	}
-
-	start := time.Now().UTC()
-	scanID := model.NewUUID()
+	if *operatorMode && (*fromDir != "" || *dryRun || *serveMetrics != "") {
+		log.Fatal("--operator needs a live cluster and cannot be combined with --from-dir, --dry-run or --serve-metrics")
+	}
+	if *watchMode && (*fromDir != "" || *dryRun || (*serveMetrics == "" && !*operatorMode)) {
+		log.Fatal("--watch needs a live cluster and --serve-metrics or --operator")
+	}
 
 	if err := os.MkdirAll(*outDir, 0755); err != nil {
 		log.Fatalf("mkdir failed: %v", err)
 	}
 
-	bundle := model.NewBundle(scanID, start)
-	bundle.Metadata.CustomerID = *customerID
-	bundle.Metadata.Site = *site
-	bundle.Metadata.ClusterName = *cluster
-	bundle.Metadata.Environment = *env
-	bundle.Target = *target
-	bundle.Profile = string(profile.Normalize(*profileName))
+	var scanNamespaces []string
 	if *namespace != "" {
 		for _, ns := range strings.Split(*namespace, ",") {
 			ns = strings.TrimSpace(ns)
 			if ns != "" {
-				bundle.ScanNamespaces = append(bundle.ScanNamespaces, ns)
+				scanNamespaces = append(scanNamespaces, ns)
 			}
 		}
 	}
 
+	var (
+		evalOpts          analyze.Options
+		rulesProfile      string
+		excludeNamespaces []string
+	)
+	if *rulesFile != "" {
+		rf, err := profile.LoadRules(*rulesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := analyze.ValidateRules(rf); err != nil {
+			log.Fatal(err)
+		}
+		evalOpts.Rules = rf
+		rulesProfile = rf.Name
+		excludeNamespaces = rf.Overrides.ExcludeNamespaces
+	}
+	if *waiversFile != "" {
+		ws, err := waiver.Load(*waiversFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		evalOpts.Waivers = ws
+	}
+	if *providersFile != "" {
+		if err := backup.LoadSpecs(*providersFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	rtoModel := restore.DefaultRTOModel()
+	if *rtoModelFile != "" {
+		m, err := restore.LoadRTOModel(*rtoModelFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rtoModel = m
+	}
+	var drSite *capacity.Site
+	if *drCapacityFile != "" {
+		site, err := capacity.LoadSite(*drCapacityFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		drSite = site
+	}
+	var targetFile *targets.File
+	if *targetsFile != "" {
+		tf, err := targets.Load(*targetsFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		targetFile = tf
+	}
+
+	// newBundle starts an empty scan of namespaces under a scoring profile;
+	// --serve-metrics and --operator start one per run.
+	newBundle := func(namespaces []string, profileName string) model.Bundle {
+		b := model.NewBundle(model.NewUUID(), time.Now().UTC())
+		b.Metadata.CustomerID = *customerID
+		b.Metadata.Site = *site
+		b.Metadata.ClusterName = *cluster
+		b.Metadata.Environment = *env
+		b.Target = *target
+		b.Profile = string(profile.Normalize(profileName))
+		b.ScanNamespaces = namespaces
+		b.RulesProfile = rulesProfile
+		b.ExcludeNamespaces = excludeNamespaces
+		return b
+	}
+	// Progress and summaries go to stdout unless CI or exporter output is wanted.
+	quiet := *ci || *serveMetrics != "" || *operatorMode
+
 	if !*ci {
-		fmt.Printf("Profile: %s\n", bundle.Profile)
+		fmt.Printf("Profile: %s\n", profile.Normalize(*profileName))
+		if rulesProfile != "" {
+			fmt.Printf("Rules:   %s (%s)\n", rulesProfile, *rulesFile)
+		}
 	}
 
 	if *dryRun {
+		bundle := newBundle(scanNamespaces, *profileName)
 		bundle.Inventory.Namespaces = []model.Namespace{
 			{ID: "ns:default", Name: "default"},
 			{ID: "ns:test", Name: "test"},
 		}
-		sim := restore.Simulate(&bundle)
+		sim := restore.SimulateWith(&bundle, rtoModel)
 		bundle.Inventory.Backup.RestoreSim = &sim
-		analyze.Evaluate(&bundle)
+		plan := restore.Plan(&bundle)
+		bundle.Inventory.RestorePlan = &plan
+		drTarget := applyPortability(&bundle, *targetKubeconfig, *targetScan, *insecure, collect.RunOptions{Parallelism: *parallel, Timeout: time.Duration(*timeoutSec) * time.Second})
+		applyCapacity(&bundle, drSite, drTarget)
+		analyze.EvaluateWith(&bundle, evalOpts)
+		bundle.Inventory.Applications = apps.Group(&bundle)
 		bundle.Inventory.RemediationSteps = remediation.Generate(&bundle, *target)
 		applyComparison(&bundle, *compareTo)
-		trendLabel, trendDelta := write(&bundle, *outDir, *ci, *minScore, *csvExport, *summary, *redactOut, *runbook)
+		trendLabel, trendDelta, err := write(&bundle, *outDir, *ci, *minScore, *csvExport, *summary, *redactOut, *runbook, *sarifOut, *junitOut)
+		if err != nil {
+			log.Fatal(err)
+		}
 		if *ci {
 			printCISummary(&bundle, *minScore, trendLabel, trendDelta)
 		}
@@ -99,110 +210,201 @@ func main() {
 		return
 	}
 
-	if *insecure && !*ci {
-		fmt.Println("WARNING: --insecure is set — TLS certificate verification is disabled.")
-	}
-
-	clientset, restCfg, err := kube.NewClient(*kubeconfig, *insecure)
-	if err != nil {
-		log.Fatalf("kube error: %v", err)
-	}
-
-	dc, err := dynamic.NewForConfig(restCfg)
-	if err != nil {
-		log.Fatalf("dynamic client error: %v", err)
-	}
+	var (
+		clientset kubernetes.Interface
+		dc        dynamic.Interface
+		scanMode  string
+		endpoint  string
+	)
+	if *fromDir != "" {
+		// Offline mode: the same collectors read from a kubectl dump on disk.
+		cs, dyn, err := kube.NewOfflineClient(*fromDir)
+		if err != nil {
+			log.Fatalf("offline error: %v", err)
+		}
+		clientset, dc = cs, dyn
+		scanMode = "offline"
+		if !*ci {
+			fmt.Println("Offline scan from:", *fromDir)
+		}
+	} else {
+		if *insecure && !*ci {
+			fmt.Println("WARNING: --insecure is set — TLS certificate verification is disabled.")
+		}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*timeoutSec)*time.Second)
-	defer cancel()
-	bundle.Cluster.APIServer.Endpoint = restCfg.Host
+		cs, restCfg, err := kube.NewClient(*kubeconfig, *insecure)
+		if err != nil {
+			log.Fatalf("kube error: %v", err)
+		}
 
-	// ── Core collectors ────────────────────────────────────────────────────
-	if err := collect.Namespaces(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect namespaces: %v", err)
-	}
-	if err := collect.Nodes(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect nodes: %v", err)
-	}
-	if err := collect.Pods(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect pods: %v", err)
-	}
-	if err := collect.PVCs(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect pvcs: %v", err)
-	}
-	if err := collect.PVs(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect pvs: %v", err)
-	}
-	if err := collect.StatefulSets(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect statefulsets: %v", err)
-	}
-	if err := collect.StorageClasses(ctx, clientset, &bundle); err != nil {
-		log.Fatalf("collect storageclasses: %v", err)
+		dyn, err := dynamic.NewForConfig(restCfg)
+		if err != nil {
+			log.Fatalf("dynamic client error: %v", err)
+		}
+		clientset, dc = cs, dyn
+		endpoint = restCfg.Host
+	}
+
+	timeout := time.Duration(*timeoutSec) * time.Second
+	collect.PageSize = *pageSize
+
+	// ── Watch-fed caches (--watch) ──────────────────────────────────────────
+	// scanClient is what the collectors read: the API server, or with --watch
+	// informer caches kept current from watch events.
+	scanClient := func() kubernetes.Interface { return clientset }
+	var changes <-chan struct{}
+	if *watchMode {
+		c, err := kube.NewCache(context.Background(), clientset, dc, kube.CacheOptions{Quiet: *watchQuiet, MaxDelay: *watchMaxDelay})
+		if err != nil {
+			log.Fatalf("watch: %v", err)
+		}
+		scanClient, changes = c.Clientset, c.Changes()
 	}
 
-	// ── Workload collectors ─────────────────────────────────────────────────
-	tryCollect("Deployments", collect.Deployments(ctx, clientset, &bundle), &bundle)
-	tryCollect("DaemonSets", collect.DaemonSets(ctx, clientset, &bundle), &bundle)
-	tryCollect("Jobs", collect.Jobs(ctx, clientset, &bundle), &bundle)
-	tryCollect("CronJobs", collect.CronJobs(ctx, clientset, &bundle), &bundle)
-
-	// ── Networking collectors ───────────────────────────────────────────────
-	tryCollect("Services", collect.Services(ctx, clientset, &bundle), &bundle)
-	tryCollect("Ingresses", collect.Ingresses(ctx, clientset, &bundle), &bundle)
-	tryCollect("NetworkPolicies", collect.NetworkPolicies(ctx, clientset, &bundle), &bundle)
+	// runScan is one full collect → analyze → write pass over the cluster,
+	// writing its outputs to outDir.
+	runScan := func(namespaces []string, profileName, outDir string) (*model.Bundle, string, int, error) {
+		bundle := newBundle(namespaces, profileName)
+		if scanMode != "" {
+			bundle.Scan.Mode = scanMode
+		}
+		bundle.Cluster.APIServer.Endpoint = endpoint
+		cs := scanClient()
 
-	// ── Config / RBAC collectors ────────────────────────────────────────────
-	tryCollect("ConfigMaps", collect.ConfigMaps(ctx, clientset, &bundle), &bundle)
-	tryCollect("Secrets", collect.Secrets(ctx, clientset, &bundle), &bundle)
-	tryCollect("ClusterRoles", collect.ClusterRoles(ctx, clientset, &bundle), &bundle)
-	tryCollect("ClusterRoleBindings", collect.ClusterRoleBindings(ctx, clientset, &bundle), &bundle)
-	tryCollect("HPAs", collect.HPAs(ctx, clientset, &bundle), &bundle)
-	tryCollect("PodDisruptionBudgets", collect.PodDisruptionBudgets(ctx, clientset, &bundle), &bundle)
-	tryCollect("ResourceQuotas", collect.ResourceQuotas(ctx, clientset, &bundle), &bundle)
-	tryCollect("CRDs", collect.CRDs(ctx, clientset, &bundle), &bundle)
+		// ── Collectors ──────────────────────────────────────────────────────
+		// Independent collectors run concurrently, each under its own deadline.
+		runOpts := collect.RunOptions{Parallelism: *parallel, Timeout: timeout}
+		if !quiet {
+			runOpts.Progress = func(done, total int, run model.CollectorRun) {
+				status := ""
+				if run.Status != "ok" {
+					status = "  " + strings.ToUpper(run.Status)
+				}
+				fmt.Printf("  [%2d/%d] %-22s %6d items %6dms%s\n", done, total, run.Name, run.Items, run.DurationMs, status)
+			}
+		}
+		if err := collect.Run(context.Background(), &bundle, collect.Registry(cs, dc), runOpts); err != nil {
+			return nil, "", 0, err
+		}
 
-	// ── Advanced collectors ─────────────────────────────────────────────────
-	tryCollect("HelmReleases", collect.HelmReleases(ctx, clientset, &bundle), &bundle)
-	tryCollect("Platform", collect.Platform(ctx, clientset, &bundle), &bundle)
-	tryCollect("Certificates", collect.Certificates(ctx, clientset, &bundle), &bundle)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
 
-	// Images is post-collection (derives data from already-collected workloads)
-	tryCollect("Images", collect.Images(ctx, clientset, &bundle), &bundle)
+		// ── Backup detection, restore simulation + restore order ───────────
+		backup.Detect(ctx, cs, dc, &bundle)
+		for _, w := range targets.Apply(&bundle, targetFile) {
+			log.Printf("targets: %s", w)
+		}
+		sim := restore.SimulateWith(&bundle, rtoModel)
+		bundle.Inventory.Backup.RestoreSim = &sim
+		plan := restore.Plan(&bundle)
+		bundle.Inventory.RestorePlan = &plan
 
-	// ── Round 13: VolumeSnapshot collectors (dynamic client) ────────────────
-	tryCollect("VolumeSnapshotClasses", collect.VolumeSnapshotClasses(ctx, dc, &bundle), &bundle)
-	tryCollect("VolumeSnapshots", collect.VolumeSnapshots(ctx, dc, &bundle), &bundle)
+		// ── DR target portability (--target-kubeconfig / --target-scan) ─────
+		drTarget := applyPortability(&bundle, *targetKubeconfig, *targetScan, *insecure, collect.RunOptions{Parallelism: *parallel, Timeout: timeout})
 
-	// ── Round 14: LimitRange + etcd backup collectors ────────────────────────
-	tryCollect("LimitRanges", collect.LimitRanges(ctx, clientset, &bundle), &bundle)
-	tryCollect("EtcdBackup", collect.EtcdBackup(ctx, clientset, &bundle), &bundle)
+		// ── DR site capacity (--dr-capacity, or the DR target's nodes) ──────
+		applyCapacity(&bundle, drSite, drTarget)
 
-	// ── Round 18: ServiceAccount token audit ─────────────────────────────────
-	tryCollect("ServiceAccounts", collect.ServiceAccounts(ctx, clientset, &bundle), &bundle)
+		// ── Scoring, applications + remediation ─────────────────────────────
+		analyze.EvaluateWith(&bundle, evalOpts)
+		bundle.Inventory.Applications = apps.Group(&bundle)
+		bundle.Inventory.RemediationSteps = remediation.Generate(&bundle, *target)
 
-	// ── Backup detection + restore simulation ───────────────────────────────
-	backup.Detect(ctx, clientset, &bundle)
-	sim := restore.Simulate(&bundle)
-	bundle.Inventory.Backup.RestoreSim = &sim
+		// ── Comparison (--compare) ───────────────────────────────────────────
+		applyComparison(&bundle, *compareTo)
 
-	// ── Scoring + remediation ───────────────────────────────────────────────
-	analyze.Evaluate(&bundle)
-	bundle.Inventory.RemediationSteps = remediation.Generate(&bundle, *target)
+		// ── Write outputs ───────────────────────────────────────────────────
+		trendLabel, trendDelta, err := write(&bundle, outDir, quiet, *minScore, *csvExport, *summary, *redactOut, *runbook, *sarifOut, *junitOut)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		return &bundle, trendLabel, trendDelta, nil
+	}
 
-	// ── Comparison (--compare) ───────────────────────────────────────────────
-	applyComparison(&bundle, *compareTo)
+	// ── Exporter mode (--serve-metrics) ─────────────────────────────────────
+	if *serveMetrics != "" {
+		serve(*serveMetrics, *metricsInterval, changes, func() (*model.Bundle, error) {
+			b, _, _, err := runScan(scanNamespaces, *profileName, *outDir)
+			return b, err
+		})
+		return
+	}
 
-	// ── Write outputs ───────────────────────────────────────────────────────
-	trendLabel, trendDelta := write(&bundle, *outDir, *ci, *minScore, *csvExport, *summary, *redactOut, *runbook)
+	// ── Operator mode (--operator) ──────────────────────────────────────────
+	if *operatorMode {
+		ctrl := &operator.Controller{
+			Dynamic:   dc,
+			Kube:      clientset,
+			Namespace: *operatorNamespace,
+			// Each DRScan keeps its outputs and history under
+			// <out>/<namespace>/<name>, e.g. on a mounted PVC.
+			Scan: func(_ context.Context, s *operator.DRScan) (*model.Bundle, error) {
+				namespaces, prof := s.Spec.Namespaces, s.Spec.Profile
+				if len(namespaces) == 0 {
+					namespaces = scanNamespaces
+				}
+				if prof == "" {
+					prof = *profileName
+				}
+				dir := filepath.Join(*outDir, s.Namespace, s.Name)
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return nil, err
+				}
+				b, _, _, err := runScan(namespaces, prof, dir)
+				return b, err
+			},
+		}
+		log.Printf("operator: reconciling %s every %s", operator.GVR.GroupResource(), *operatorResync)
+		if err := ctrl.Run(context.Background(), *operatorResync); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
+	bundle, trendLabel, trendDelta, err := runScan(scanNamespaces, *profileName, *outDir)
+	if err != nil {
+		log.Fatal(err)
+	}
 	if *ci {
-		printCISummary(&bundle, *minScore, trendLabel, trendDelta)
+		printCISummary(bundle, *minScore, trendLabel, trendDelta)
+	}
+	exitWithPolicy(bundle, *minScore, *ci)
+}
+
+// serve runs scan every interval, and whenever changes receives, and serves
+// the latest result as Prometheus metrics on addr until the process is
+// stopped. A failed scan is logged and the last good result kept. changes is
+// nil without --watch.
+func serve(addr string, interval time.Duration, changes <-chan struct{}, scan func() (*model.Bundle, error)) {
+	exp := &metrics.Exporter{}
+	srv := &http.Server{Addr: addr, Handler: exp.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Printf("metrics: serving on %s/metrics, scanning every %s", addr, interval)
+		if err := srv.ListenAndServe(); err != nil {
+			log.Fatalf("metrics: %v", err)
+		}
+	}()
+	for {
+		start := time.Now()
+		if b, err := scan(); err != nil {
+			exp.Fail()
+			log.Printf("metrics: scan failed after %s: %v (serving the last completed scan)", time.Since(start).Round(time.Second), err)
+		} else {
+			exp.Update(b, time.Since(start))
+			log.Printf("metrics: scan %s done in %s, score %d (%s), %d findings",
+				b.Scan.ScanID, time.Since(start).Round(time.Second), b.Score.Overall.Final, b.Score.Maturity, len(b.Inventory.Findings))
+		}
+		select {
+		case <-time.After(time.Until(start.Add(interval))):
+		case <-changes:
+			log.Printf("metrics: watched resources changed, rescanning")
+		}
 	}
-	exitWithPolicy(&bundle, *minScore, *ci)
 }
 
 // write serialises all outputs and returns trend label + delta for CI summary.
-func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExport, summaryOut, redactOut, runbookOut bool) (string, int) {
+func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExport, summaryOut, redactOut, runbookOut, sarifOut, junitOut bool) (string, int, error) {
 	bundle.Scan.EndedAt = time.Now().UTC()
 	bundle.Scan.DurationSeconds = int(bundle.Scan.EndedAt.Sub(bundle.Scan.StartedAt).Seconds())
 	bundle.Checks = analyze.BuildChecks(bundle, minScore)
@@ -211,7 +413,7 @@ func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExp
 	htmlPath := filepath.Join(outDir, "recovery-report.html")
 
 	if err := output.WriteJSON(jsonPath, bundle); err != nil {
-		log.Fatalf("write json: %v", err)
+		return "", 0, fmt.Errorf("write json: %w", err)
 	}
 
 	// Enrich pipeline: trend history, risk, enriched.json, markdown report
@@ -256,13 +458,13 @@ func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExp
 
 	// New tabbed HTML report (overwrites the simple one produced by enrich)
 	if err := output.WriteReport(htmlPath, bundle); err != nil {
-		log.Fatalf("write html report: %v", err)
+		return "", 0, fmt.Errorf("write html report: %w", err)
 	}
 
 	// Optional CSV export
 	if csvExport {
 		if err := output.WriteCSV(outDir, bundle); err != nil {
-			log.Fatalf("write csv: %v", err)
+			return "", 0, fmt.Errorf("write csv: %w", err)
 		}
 		if !quiet {
 			fmt.Println("CSV exports:", filepath.Join(outDir, "csv"))
@@ -273,7 +475,7 @@ func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExp
 	if summaryOut {
 		summaryPath := filepath.Join(outDir, "recovery-summary.html")
 		if err := output.WriteSummary(summaryPath, bundle); err != nil {
-			log.Fatalf("write summary: %v", err)
+			return "", 0, fmt.Errorf("write summary: %w", err)
 		}
 		if !quiet {
 			fmt.Println("Executive Summary:", summaryPath)
@@ -284,20 +486,40 @@ func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExp
 	if runbookOut {
 		runbookPath := filepath.Join(outDir, "recovery-runbook.html")
 		if err := output.WriteRunbook(runbookPath, bundle); err != nil {
-			log.Fatalf("write runbook: %v", err)
+			return "", 0, fmt.Errorf("write runbook: %w", err)
 		}
 		if !quiet {
 			fmt.Println("DR Runbook:", runbookPath)
 		}
 	}
 
+	// Optional CI exports
+	if sarifOut {
+		sarifPath := filepath.Join(outDir, "recovery-findings.sarif")
+		if err := output.WriteFindingsSARIF(sarifPath, bundle); err != nil {
+			return "", 0, fmt.Errorf("write sarif: %w", err)
+		}
+		if !quiet {
+			fmt.Println("SARIF:", sarifPath)
+		}
+	}
+	if junitOut {
+		junitPath := filepath.Join(outDir, "recovery-checks.xml")
+		if err := output.WriteChecksJUnit(junitPath, bundle); err != nil {
+			return "", 0, fmt.Errorf("write junit: %w", err)
+		}
+		if !quiet {
+			fmt.Println("JUnit:", junitPath)
+		}
+	}
+
 	// Optional redacted exports
 	if redactOut {
 		if err := output.WriteRedactedJSON(filepath.Join(outDir, "recovery-scan-redacted.json"), bundle); err != nil {
-			log.Fatalf("write redacted json: %v", err)
+			return "", 0, fmt.Errorf("write redacted json: %w", err)
 		}
 		if err := output.WriteRedactedReport(filepath.Join(outDir, "recovery-report-redacted.html"), bundle); err != nil {
-			log.Fatalf("write redacted html: %v", err)
+			return "", 0, fmt.Errorf("write redacted html: %w", err)
 		}
 		if !quiet {
 			fmt.Println("Redacted exports: recovery-scan-redacted.json, recovery-report-redacted.html")
@@ -311,24 +533,10 @@ func write(bundle *model.Bundle, outDir string, quiet bool, minScore int, csvExp
 		fmt.Println("Enriched:", filepath.Join(outDir, "recovery-enriched.json"))
 	}
 
-	return trendLabel, trendDelta
+	return trendLabel, trendDelta, nil
 }
 
 func printCISummary(b *model.Bundle, minScore int, trendLabel string, trendDelta int) {
-	counts := model.FindingCounts{}
-	for _, f := range b.Inventory.Findings {
-		counts.Total++
-		switch f.Severity {
-		case "CRITICAL":
-			counts.Critical++
-		case "HIGH":
-			counts.High++
-		case "MEDIUM":
-			counts.Medium++
-		default:
-			counts.Low++
-		}
-	}
 	summary := model.ScanSummary{
 		ScanID:       b.Scan.ScanID,
 		TimestampUtc: time.Now().UTC().Format(time.RFC3339),
@@ -340,7 +548,7 @@ func printCISummary(b *model.Bundle, minScore int, trendLabel string, trendDelta
 		Categories:   analyze.BuildCategories(b),
 		Trend:        trendLabel,
 		Delta:        trendDelta,
-		Findings:     counts,
+		Findings:     model.CountFindings(b.Inventory.Findings),
 	}
 	if b.Score.Overall.Final < minScore {
 		summary.Status = "FAILED"
@@ -364,6 +572,64 @@ func applyComparison(bundle *model.Bundle, compareTo string) {
 	bundle.Comparison = &diff
 }
 
+// applyPortability scans the DR target cluster, or loads a scan of it, and
+// attaches the portability report to bundle. It returns the target scan, or
+// nil when there is none.
+func applyPortability(bundle *model.Bundle, kubeconfig, scanFile string, insecure bool, opts collect.RunOptions) *model.Bundle {
+	var (
+		target *model.Bundle
+		name   string
+	)
+	switch {
+	case scanFile != "":
+		b, err := loadBundle(scanFile)
+		if err != nil {
+			log.Printf("portability: failed to load %s: %v (skipping)", scanFile, err)
+			return nil
+		}
+		target, name = b, b.Metadata.ClusterName
+		if name == "" {
+			name = scanFile
+		}
+	case kubeconfig != "":
+		cs, restCfg, err := kube.NewClient(kubeconfig, insecure)
+		if err != nil {
+			log.Printf("portability: %v (skipping)", err)
+			return nil
+		}
+		dyn, err := dynamic.NewForConfig(restCfg)
+		if err != nil {
+			log.Printf("portability: dynamic client error: %v (skipping)", err)
+			return nil
+		}
+		b := model.NewBundle(model.NewUUID(), time.Now().UTC())
+		collectors := collect.Only(collect.Registry(cs, dyn), portability.Collectors...)
+		if err := collect.Run(context.Background(), &b, collectors, opts); err != nil {
+			log.Printf("portability: target scan failed: %v (skipping)", err)
+			return nil
+		}
+		target, name = &b, restCfg.Host
+	default:
+		return nil
+	}
+	report := portability.Check(bundle, target, name)
+	bundle.Portability = &report
+	return target
+}
+
+// applyCapacity checks the protected workloads fit on the DR site: site
+// when --dr-capacity is set, otherwise the Ready nodes of target.
+func applyCapacity(bundle *model.Bundle, site *capacity.Site, target *model.Bundle) {
+	if site == nil {
+		if target == nil || len(target.Inventory.Nodes) == 0 {
+			return
+		}
+		site = capacity.SiteFromBundle(target, bundle.Portability.Target)
+	}
+	report := capacity.Check(bundle, site)
+	bundle.Capacity = &report
+}
+
 // loadBundle reads and decodes a recovery-scan.json file.
 func loadBundle(path string) (*model.Bundle, error) {
 	data, err := os.ReadFile(path)
@@ -377,25 +643,6 @@ func loadBundle(path string) (*model.Bundle, error) {
 	return &b, nil
 }
 
-// tryCollect records a collector skip when err != nil.
-// It logs the error and appends a CollectorSkip to the bundle.
-func tryCollect(name string, err error, bundle *model.Bundle) {
-	if err == nil {
-		return
-	}
-	msg := err.Error()
-	isRBAC := strings.Contains(msg, "forbidden") ||
-		strings.Contains(msg, "Forbidden") ||
-		strings.Contains(msg, "unauthorized") ||
-		strings.Contains(msg, "Unauthorized")
-	bundle.CollectorSkips = append(bundle.CollectorSkips, model.CollectorSkip{
-		Name:   name,
-		Reason: msg,
-		RBAC:   isRBAC,
-	})
-	log.Printf("collect %s: %v (skipping)", name, err)
-}
-
 func exitWithPolicy(b *model.Bundle, minScore int, quiet bool) {
 	score := b.Score.Overall.Final
 	if !quiet {
@@ -0,0 +1,108 @@
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s-recovery-visualizer/internal/profile"
+)
+
+// builtinFindings maps every finding ID raised by Evaluate to its domain.
+// Rules files are validated against it so a typo in an ID fails loudly
+// instead of silently configuring nothing.
+var builtinFindings = map[string]string{
+	"PVC_UNBOUND":               "STORAGE",
+	"PVC_NO_STORAGECLASS":       "STORAGE",
+	"PV_HOSTPATH":               "STORAGE",
+	"PV_DELETE_POLICY":          "STORAGE",
+	"PV_ORPHAN":                 "STORAGE",
+	"SNAPSHOT_NO_CLASS":         "STORAGE",
+	"SNAPSHOT_PVC_UNCOVERED":    "STORAGE",
+	"SC_RECLAIM_DELETE":         "STORAGE",
+	"SC_HOSTPATH_PROVISIONER":   "STORAGE",
+	"SC_ZONE_UNAWARE":           "STORAGE",
+	"STS_NO_PVC":                "WORKLOAD",
+	"POD_NO_REQUESTS":           "WORKLOAD",
+	"POD_NO_LIMITS":             "WORKLOAD",
+	"NODE_NOT_READY":            "WORKLOAD",
+	"SINGLE_AZ_CLUSTER":         "WORKLOAD",
+	"POD_HOSTPATH":              "CONFIG",
+	"RBAC_WILDCARD_VERB":        "CONFIG",
+	"RBAC_ESCALATE_PRIV":        "CONFIG",
+	"RBAC_SECRET_ACCESS":        "CONFIG",
+	"POD_PRIVILEGED":            "CONFIG",
+	"POD_HOST_NAMESPACE":        "CONFIG",
+	"LR_MISSING_NAMESPACE":      "CONFIG",
+	"PSA_MISSING_ENFORCE_LABEL": "CONFIG",
+	"NETPOL_MISSING_NAMESPACE":  "CONFIG",
+	"SA_DEFAULT_OVERPRIV":       "CONFIG",
+	"SA_AUTOMOUNT_TOKEN":        "CONFIG",
+	"BACKUP_NONE":               "BACKUP",
+	"BACKUP_PARTIAL_COVERAGE":   "BACKUP",
+	"BACKUP_NO_POLICIES":        "BACKUP",
+	"BACKUP_NO_OFFSITE":         "BACKUP",
+	"BACKUP_RPO_HIGH":           "BACKUP",
+	"RESTORE_SIM_UNCOVERED":     "BACKUP",
+	"BACKUP_STALE":              "BACKUP",
+	"BACKUP_FAILED":             "BACKUP",
+	"RESTORE_NOT_TESTED":        "BACKUP",
+	"DR_RPO_TARGET_MISSED":      "BACKUP",
+	"DR_RTO_TARGET_MISSED":      "BACKUP",
+	"CRD_NO_BACKUP":             "BACKUP",
+	"CERT_EXPIRING_SOON":        "BACKUP",
+	"IMAGE_EXTERNAL_REGISTRY":   "BACKUP",
+	"HELM_UNTRACKED":            "BACKUP",
+	"ETCD_BACKUP_MISSING":       "BACKUP",
+
+	// DR target portability (--target-kubeconfig / --target-scan)
+	"PORT_STORAGECLASS_MISSING":  "STORAGE",
+	"PORT_CSI_DRIVER_MISSING":    "STORAGE",
+	"PORT_SNAPSHOTCLASS_MISSING": "STORAGE",
+	"PORT_API_NOT_SERVED":        "CONFIG",
+	"PORT_INGRESSCLASS_MISSING":  "CONFIG",
+	"PORT_VERSION_SKEW":          "CONFIG",
+	"PORT_CAPACITY_SHORT":        "WORKLOAD",
+
+	// DR site capacity (--dr-capacity, or the DR target's nodes)
+	"CAPACITY_DR_SHORT":      "WORKLOAD",
+	"CAPACITY_POD_TOO_LARGE": "WORKLOAD",
+}
+
+// ValidateRules reports rules that name an unknown finding ID or put a
+// known one in the wrong domain, and custom rules that fail to compile or
+// reuse a built-in ID.
+func ValidateRules(rf *profile.RuleFile) error {
+	if rf == nil {
+		return nil
+	}
+	if _, err := CompileCustom(rf); err != nil {
+		return err
+	}
+	known := map[string]string{}
+	for id, domain := range builtinFindings {
+		known[id] = domain
+	}
+	var problems []string
+	for _, c := range rf.Custom {
+		if _, ok := builtinFindings[c.ID]; ok {
+			problems = append(problems, fmt.Sprintf("custom rule %s reuses a built-in finding ID", c.ID))
+		}
+		known[c.ID] = c.Domain
+	}
+	for _, r := range rf.Rules {
+		domain, ok := known[r.ID]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown finding ID %s", r.ID))
+			continue
+		}
+		if r.Domain != "" && r.Domain != domain {
+			problems = append(problems, fmt.Sprintf("%s is in domain %s, not %s", r.ID, domain, r.Domain))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("rules file %s: %s", rf.Name, strings.Join(problems, "; "))
+}
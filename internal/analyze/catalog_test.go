@@ -0,0 +1,186 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+	"k8s-recovery-visualizer/internal/profile"
+)
+
+// Every ID raised in rules.go must be in builtinFindings so rules files can
+// configure it.
+func TestBuiltinFindingsCoversEvaluate(t *testing.T) {
+	src, err := os.ReadFile("rules.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range regexp.MustCompile(`raise\("([A-Z_]+)"`).FindAllStringSubmatch(string(src), -1) {
+		if _, ok := builtinFindings[m[1]]; !ok {
+			t.Errorf("%s is raised by Evaluate but missing from builtinFindings", m[1])
+		}
+	}
+}
+
+func TestDefaultRulesFileValidates(t *testing.T) {
+	rf, err := profile.LoadRules(filepath.Join("..", "..", "profiles", "default.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateRules(rf); err != nil {
+		t.Error(err)
+	}
+	if len(rf.Rules) != len(builtinFindings) {
+		t.Errorf("default.json lists %d rules, want all %d built-in findings", len(rf.Rules), len(builtinFindings))
+	}
+	if got := rf.Overrides.ExcludeNamespaces; len(got) != 2 || got[0] != "kube-system" || got[1] != "cattle-system" {
+		t.Errorf("default.json excludes %v, want kube-system and cattle-system", got)
+	}
+}
+
+// Profiles from before --rules-file used "weight" for the penalty; they must
+// still load, with weight read as penalty. Other unknown fields are rejected.
+func TestLoadRulesWeightAlias(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	write := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"name": "old", "defaults": {"weight": 10, "failPenalty": 10, "warnPenalty": 5},
+  "rules": [{"id": "PV_ORPHAN", "enabled": true, "domain": "STORAGE", "weight": 15, "params": {}}]}`)
+	rf, err := profile.LoadRules(path)
+	if err != nil {
+		t.Fatalf("LoadRules = %v, want the old profile to load", err)
+	}
+	if r, _ := rf.Rule("PV_ORPHAN"); r.Penalty == nil || *r.Penalty != 15 {
+		t.Errorf("PV_ORPHAN penalty = %v, want weight 15 read as penalty", r.Penalty)
+	}
+
+	write(`{"name": "both", "rules": [{"id": "PV_ORPHAN", "weight": 15, "penalty": 5}]}`)
+	if _, err := profile.LoadRules(path); err == nil || !strings.Contains(err.Error(), "penalty") {
+		t.Errorf("LoadRules = %v, want an error naming penalty when both are set", err)
+	}
+
+	write(`{"name": "typo", "rules": [{"id": "PV_ORPHAN", "penalti": 15}]}`)
+	if _, err := profile.LoadRules(path); err == nil || !strings.Contains(err.Error(), `"penalti"`) {
+		t.Errorf("LoadRules = %v, want an unknown field error for penalti", err)
+	}
+}
+
+func TestEvaluateWithRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	err := os.WriteFile(path, []byte(`{
+  "name": "test",
+  "rules": [
+    {"id": "pv_orphan", "enabled": false},
+    {"id": "PVC_NO_STORAGECLASS", "severity": "low", "penalty": 2},
+    {"id": "CERT_EXPIRING_SOON", "params": {"days": 60}}
+  ]
+}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rf, err := profile.LoadRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.PVs = []model.PersistentVolume{{Name: "pv-orphan"}, {Name: "pv-a", ClaimRef: "app/data"}}
+	b.Inventory.PVCs = []model.PersistentVolumeClaim{{Namespace: "app", Name: "data"}}
+	b.Inventory.VolumeSnapshotClasses = []model.VolumeSnapshotClass{{Name: "csi"}}
+	b.Inventory.VolumeSnapshots = []model.VolumeSnapshot{{Namespace: "app", PVCName: "data"}}
+	b.Inventory.Certificates = []model.Certificate{{Namespace: "app", Name: "tls", DaysToExpiry: 45}}
+	EvaluateWith(&b, Options{Rules: rf})
+
+	got := map[string]model.Finding{}
+	for _, f := range b.Inventory.Findings {
+		got[f.ID] = f
+	}
+	if _, ok := got["PV_ORPHAN"]; ok {
+		t.Error("PV_ORPHAN raised although disabled")
+	}
+	if f := got["PVC_NO_STORAGECLASS"]; f.Severity != "LOW" {
+		t.Errorf("PVC_NO_STORAGECLASS severity = %q, want LOW", f.Severity)
+	}
+	if b.Score.Storage.Final != 98 {
+		t.Errorf("storage score = %d, want 98 (only the overridden 2-point penalty)", b.Score.Storage.Final)
+	}
+	if _, ok := got["CERT_EXPIRING_SOON"]; !ok {
+		t.Error("CERT_EXPIRING_SOON not raised for a 45-day certificate with days=60")
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	err := os.WriteFile(path, []byte(`{
+  "name": "site",
+  "custom": [
+    {
+      "id": "GOLD_STS_NO_PDB", "domain": "workload", "severity": "high", "penalty": 7,
+      "message": "StatefulSets in tier=gold namespaces must have a PodDisruptionBudget",
+      "expr": "inventory.statefulSets.filter(s, inventory.namespaces.exists(n, n.name == s.namespace && n.labels.?tier.orValue('') == 'gold') && !inventory.podDisruptionBudgets.exists(p, p.namespace == s.namespace))"
+    },
+    {
+      "id": "TOO_FEW_NODES", "domain": "WORKLOAD", "severity": "LOW", "penalty": 1,
+      "message": "Fewer than three nodes", "expr": "size(inventory.nodes) < 3"
+    }
+  ]
+}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rf, err := profile.LoadRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateRules(rf); err != nil {
+		t.Fatal(err)
+	}
+
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Namespaces = []model.Namespace{
+		{Name: "pay", Labels: map[string]string{"tier": "gold"}},
+		{Name: "shop", Labels: map[string]string{"tier": "gold"}},
+		{Name: "dev"},
+	}
+	b.Inventory.StatefulSets = []model.StatefulSet{
+		{Namespace: "pay", Name: "db", HasVolumeClaim: true},
+		{Namespace: "shop", Name: "cache", HasVolumeClaim: true},
+		{Namespace: "dev", Name: "scratch", HasVolumeClaim: true},
+	}
+	b.Inventory.PodDisruptionBudgets = []model.PodDisruptionBudget{{Namespace: "shop", Name: "cache"}}
+	b.Inventory.Nodes = []model.Node{{Name: "a", Ready: true}, {Name: "b", Ready: true}, {Name: "c", Ready: true}}
+	EvaluateWith(&b, Options{Rules: rf})
+
+	var got *model.Finding
+	for i, f := range b.Inventory.Findings {
+		if f.ID == "TOO_FEW_NODES" {
+			t.Errorf("TOO_FEW_NODES raised with 3 nodes")
+		}
+		if f.ID == "GOLD_STS_NO_PDB" {
+			got = &b.Inventory.Findings[i]
+		}
+	}
+	if got == nil {
+		t.Fatal("GOLD_STS_NO_PDB not raised")
+	}
+	if got.ResourceID != "pay/db" || got.Severity != "HIGH" || got.Domain != "WORKLOAD" || !got.Custom {
+		t.Errorf("finding = %+v, want pay/db HIGH WORKLOAD custom", *got)
+	}
+	if b.Score.Workload.Final != 100-7 {
+		t.Errorf("workload score = %d, want 93", b.Score.Workload.Final)
+	}
+
+	bad := &profile.RuleFile{Name: "bad", Custom: []profile.CustomRule{{ID: "X", Domain: "CONFIG", Severity: "LOW", Expr: "inventory.statefulSet"}}}
+	if err := ValidateRules(bad); err == nil {
+		t.Error("expected a compile error for unknown field statefulSet")
+	}
+}
@@ -0,0 +1,129 @@
+package analyze
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+
+	"k8s-recovery-visualizer/internal/model"
+	"k8s-recovery-visualizer/internal/profile"
+)
+
+// customRule is a profile.CustomRule with its expression compiled.
+type customRule struct {
+	profile.CustomRule
+	prg cel.Program
+}
+
+// celEnv exposes the bundle to custom rules. Fields use the same camelCase
+// names as recovery-scan.json, and are type-checked at load time:
+//
+//	bundle     — the whole model.Bundle (bundle.cluster, bundle.score, ...)
+//	inventory  — shorthand for bundle.inventory
+//
+// Optional syntax (x.?field.orValue(default)) and the strings extension are
+// enabled.
+func celEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		ext.Strings(),
+		cel.OptionalTypes(),
+		ext.NativeTypes(reflect.TypeOf(&model.Bundle{}), ext.ParseStructTag("json")),
+		cel.Variable("bundle", cel.ObjectType("model.Bundle")),
+		cel.Variable("inventory", cel.ObjectType("model.Inventory")),
+	)
+}
+
+// CompileCustom type-checks every custom rule in rf.
+//
+// An expression either returns a bool (true raises one cluster-level finding)
+// or a list of violations. List elements may be strings, used as the resource
+// ID, or objects with name/namespace fields such as inventory.statefulSets
+// entries:
+//
+//	inventory.statefulSets.filter(s,
+//	  inventory.namespaces.exists(n, n.name == s.namespace && n.labels.?tier.orValue("") == "gold") &&
+//	  !inventory.podDisruptionBudgets.exists(p, p.namespace == s.namespace))
+func CompileCustom(rf *profile.RuleFile) ([]customRule, error) {
+	if rf == nil || len(rf.Custom) == 0 {
+		return nil, nil
+	}
+	env, err := celEnv()
+	if err != nil {
+		return nil, fmt.Errorf("custom rules: %w", err)
+	}
+	out := make([]customRule, 0, len(rf.Custom))
+	for _, c := range rf.Custom {
+		ast, iss := env.Compile(c.Expr)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("custom rule %s: %w", c.ID, iss.Err())
+		}
+		switch kind := ast.OutputType().Kind(); kind {
+		case types.BoolKind, types.ListKind, types.DynKind:
+		default:
+			return nil, fmt.Errorf("custom rule %s: expr must return bool or list, not %s", c.ID, ast.OutputType())
+		}
+		prg, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %s: %w", c.ID, err)
+		}
+		out = append(out, customRule{CustomRule: c, prg: prg})
+	}
+	return out, nil
+}
+
+// violations runs the rule against b. hit reports whether the rule fired;
+// refs lists the flagged resources (none for a bool rule).
+func (r customRule) violations(b *model.Bundle) (refs []model.ResourceRef, hit bool, err error) {
+	val, _, err := r.prg.Eval(map[string]any{
+		"bundle":    b,
+		"inventory": &b.Inventory,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	switch v := val.(type) {
+	case types.Bool:
+		return nil, bool(v), nil
+	case traits.Lister:
+		it := v.Iterator()
+		for it.HasNext() == types.True {
+			refs = append(refs, resourceRefOf(it.Next()))
+		}
+		sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+		return refs, len(refs) > 0, nil
+	}
+	return nil, false, fmt.Errorf("expr returned %s, want bool or list", val.Type())
+}
+
+// resourceRefOf converts one list element to a ResourceRef. Inventory objects
+// keep their kind (model.StatefulSet → StatefulSet), namespace and name; any
+// other value is used verbatim as the name.
+func resourceRefOf(v ref.Val) model.ResourceRef {
+	if s, ok := v.(types.String); ok {
+		return model.ResourceRef{Name: string(s)}
+	}
+	if obj, ok := v.(traits.Indexer); ok {
+		if name := fieldString(obj, "name"); name != "" {
+			kind := v.Type().TypeName()
+			if i := strings.LastIndex(kind, "."); i >= 0 {
+				kind = kind[i+1:]
+			}
+			return model.ResourceRef{Kind: kind, Namespace: fieldString(obj, "namespace"), Name: name}
+		}
+	}
+	return model.ResourceRef{Name: fmt.Sprint(v.Value())}
+}
+
+func fieldString(obj traits.Indexer, field string) string {
+	if v, ok := obj.Get(types.String(field)).(types.String); ok {
+		return string(v)
+	}
+	return ""
+}
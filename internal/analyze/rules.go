@@ -1,10 +1,16 @@
 package analyze
 
 import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"k8s-recovery-visualizer/internal/model"
 	"k8s-recovery-visualizer/internal/profile"
+	"k8s-recovery-visualizer/internal/waiver"
 )
 
 const (
@@ -28,6 +34,11 @@ const (
 	penBackupNoOffsite     = 15
 	penBackupRPOHigh       = 10 // worst-case RPO > 24 h
 	penRestoreSimUncovered = 20 // namespaces with stateful workloads not covered by any policy
+	penBackupStale         = 15 // covered namespace whose last successful backup is too old
+	penBackupFailed        = 10 // Failed/PartiallyFailed backup objects present
+	penRestoreUntested     = 10 // backups complete but no restore has ever completed
+	penRPOTargetMissed     = 15 // namespaces whose achieved RPO exceeds their declared target
+	penRTOTargetMissed     = 10 // namespaces whose estimated RTO exceeds their declared target
 	penCRDNoBackup         = 10
 	penCertExpiring        = 10
 	penImageExternal       = 5
@@ -71,6 +82,19 @@ const (
 	// Round 18 — ServiceAccount token audit (Config domain)
 	penDefaultSAOverPriv = 15 // default ServiceAccount has explicit ClusterRoleBinding
 	penAutoMountSA       = 10 // pods automount service account token without need
+
+	// DR target portability (--target-kubeconfig / --target-scan)
+	penPortStorageClass = 15 // StorageClasses in use are missing on the target
+	penPortCSIDriver    = 15 // CSI drivers in use are not installed on the target
+	penPortSnapshot     = 8  // no target VolumeSnapshotClass for a snapshot driver
+	penPortAPI          = 15 // custom API groups/versions not served by the target
+	penPortIngressClass = 8  // IngressClasses in use are missing on the target
+	penPortVersion      = 10 // target Kubernetes version is older, or far newer
+	penPortCapacity     = 15 // target has less allocatable CPU or memory
+
+	// DR site capacity (--dr-capacity, or the DR target's nodes)
+	penCapacityShort   = 20 // protected workloads request more than the DR site allocates
+	penCapacityPodSize = 8  // pods request more than any single DR node allocates
 )
 
 // profileGet returns the weight multiplier for key from a profile weight map.
@@ -92,7 +116,115 @@ func penScale(base int, multiplier float64) int {
 	return v
 }
 
+// Options tunes Evaluate beyond the scoring profile.
+type Options struct {
+	// Rules, when set, enables/disables findings and overrides their
+	// severity, penalty and thresholds (--rules-file).
+	Rules *profile.RuleFile
+	// Waivers accept known risks (--waivers). A finding covered by an
+	// unexpired waiver moves to Inventory.WaivedFindings and costs nothing.
+	Waivers []waiver.Waiver
+	// Now is the time waiver expiry is judged against; zero means time.Now().
+	Now time.Time
+}
+
+// Evaluate scores the bundle with the built-in rule defaults.
 func Evaluate(b *model.Bundle) {
+	EvaluateWith(b, Options{})
+}
+
+// EvaluateWith scores the bundle and records findings, applying opts.
+func EvaluateWith(b *model.Bundle, opts Options) {
+	rules := opts.Rules
+	custom, err := CompileCustom(rules)
+	if err != nil {
+		log.Printf("%v (custom rules skipped)", err)
+	}
+	customDomains := map[string]string{}
+	for _, cr := range custom {
+		customDomains[cr.ID] = cr.Domain
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	// raise records a finding unless the rules file disables its ID, applying
+	// any severity/penalty override, and returns the points to deduct. Waived
+	// findings are recorded separately and deduct nothing.
+	raise := func(id, severity string, penalty int, resource, message, recommendation string, refs ...model.ResourceRef) int {
+		if !rules.Enabled(id) {
+			return 0
+		}
+		if r, ok := rules.Rule(id); ok {
+			if r.Severity != "" {
+				severity = r.Severity
+			}
+			if r.Penalty != nil {
+				penalty = *r.Penalty
+			}
+		}
+		f := model.Finding{
+			ID:             id,
+			Severity:       severity,
+			ResourceID:     resource,
+			Message:        message,
+			Recommendation: recommendation,
+			Domain:         builtinFindings[id],
+		}
+		if d, ok := customDomains[id]; ok {
+			f.Domain, f.Custom = d, true
+		}
+		if len(refs) == 0 {
+			if w, ok := waiver.Find(opts.Waivers, f, now); ok {
+				f.Waiver = w.Ref(now)
+				if !f.Waiver.Expired {
+					b.Inventory.WaivedFindings = append(b.Inventory.WaivedFindings, f)
+					return 0
+				}
+			}
+			b.Inventory.Findings = append(b.Inventory.Findings, f)
+			return penalty
+		}
+
+		// Waivers apply per affected resource: waived ones are split off into
+		// their own waived finding, and the penalty is only skipped when
+		// nothing is left.
+		var active, waived []model.ResourceRef
+		var waivedBy *model.WaiverRef
+		for _, r := range refs {
+			w, ok := waiver.FindRef(opts.Waivers, id, r, now)
+			switch {
+			case ok && !w.Expired(now):
+				waived = append(waived, r)
+				if waivedBy == nil {
+					waivedBy = w.Ref(now)
+				}
+			case ok:
+				if f.Waiver == nil {
+					f.Waiver = w.Ref(now)
+				}
+				active = append(active, r)
+			default:
+				active = append(active, r)
+			}
+		}
+		f.Resources = active
+		if len(waived) > 0 {
+			wf := f
+			wf.Waiver = waivedBy
+			wf.Resources = waived
+			wf.ResourceID = summarize(resource, waived)
+			b.Inventory.WaivedFindings = append(b.Inventory.WaivedFindings, wf)
+			if len(active) == 0 {
+				return 0
+			}
+			f.ResourceID = summarize(resource, active)
+		}
+		b.Inventory.Findings = append(b.Inventory.Findings, f)
+		return penalty
+	}
+
 	storage := 100
 	workload := 100
 	config := 100
@@ -118,37 +250,32 @@ func Evaluate(b *model.Bundle) {
 		pv, bound := pvMap[key]
 
 		if !bound {
-			storage -= penPVCUnbound
-			addFinding(b, "PVC_UNBOUND", "CRITICAL", key,
+			storage -= raise("PVC_UNBOUND", "CRITICAL", penPVCUnbound, key,
 				"PVC is not bound to a PV",
-				"Investigate binding failure before DR onboarding")
+				"Investigate binding failure before DR onboarding", resRef("PersistentVolumeClaim", key))
 		}
 		if pvc.StorageClass == "" {
-			storage -= penPVCNoStorageClass
-			addFinding(b, "PVC_NO_STORAGECLASS", "HIGH", key,
+			storage -= raise("PVC_NO_STORAGECLASS", "HIGH", penPVCNoStorageClass, key,
 				"PVC has no storageClass",
-				"Define explicit storageClass for DR predictability")
+				"Define explicit storageClass for DR predictability", resRef("PersistentVolumeClaim", key))
 		}
 		if bound && pv.Backend == "hostPath" {
-			storage -= penScale(penPVHostPath, wImmut)
-			addFinding(b, "PV_HOSTPATH", "CRITICAL", pv.Name,
+			storage -= raise("PV_HOSTPATH", "CRITICAL", penScale(penPVHostPath, wImmut), pv.Name,
 				"PV uses hostPath storage",
-				"Migrate to CSI/network storage before DR onboarding")
+				"Migrate to CSI/network storage before DR onboarding", resRef("PersistentVolume", pv.Name))
 		}
 		if bound && pv.ReclaimPolicy == "Delete" {
-			storage -= penScale(penPVDeletePolicy, wImmut)
-			addFinding(b, "PV_DELETE_POLICY", "HIGH", pv.Name,
+			storage -= raise("PV_DELETE_POLICY", "HIGH", penScale(penPVDeletePolicy, wImmut), pv.Name,
 				"PV reclaimPolicy is Delete",
-				"Consider Retain for DR recoverability")
+				"Consider Retain for DR recoverability", resRef("PersistentVolume", pv.Name))
 		}
 	}
 
 	for _, pv := range b.Inventory.PVs {
 		if pv.ClaimRef == "" {
-			storage -= penPVOrphan
-			addFinding(b, "PV_ORPHAN", "MEDIUM", pv.Name,
+			storage -= raise("PV_ORPHAN", "MEDIUM", penPVOrphan, pv.Name,
 				"PV is not bound to any PVC",
-				"Validate if orphaned storage should be cleaned up")
+				"Validate if orphaned storage should be cleaned up", resRef("PersistentVolume", pv.Name))
 		}
 	}
 
@@ -164,18 +291,17 @@ func Evaluate(b *model.Bundle) {
 			sev = "INFO"
 			rec = "System pod uses hostPath (common for control plane/CNI). Review if acceptable for DR posture."
 		}
-		addFinding(b, "POD_HOSTPATH", sev, pod.Namespace+"/"+pod.Name,
-			"Pod uses hostPath volume", rec)
+		config -= raise("POD_HOSTPATH", sev, 0, pod.Namespace+"/"+pod.Name,
+			"Pod uses hostPath volume", rec, resRef("Pod", pod.Namespace+"/"+pod.Name))
 	}
 
 	// ── Workload domain ─────────────────────────────────────────────────────
 	for _, sts := range b.Inventory.StatefulSets {
 		if !sts.HasVolumeClaim {
-			workload -= penSTSNoPVC
-			addFinding(b, "STS_NO_PVC", "HIGH",
+			workload -= raise("STS_NO_PVC", "HIGH", penSTSNoPVC,
 				sts.Namespace+"/"+sts.Name,
 				"StatefulSet has no volumeClaimTemplate",
-				"Stateful workloads should use persistent storage")
+				"Stateful workloads should use persistent storage", resRef("StatefulSet", sts.Namespace+"/"+sts.Name))
 		}
 	}
 
@@ -193,39 +319,34 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(noRequestPods) > 0 {
-		workload -= penNoRequests
-		addFinding(b, "POD_NO_REQUESTS", "HIGH",
+		workload -= raise("POD_NO_REQUESTS", "HIGH", penNoRequests,
 			"pods:"+joinFirst(noRequestPods, 3),
 			"Pods running without CPU/memory requests — scheduler cannot make placement guarantees",
-			"Set requests on all containers; use LimitRange to enforce namespace defaults")
+			"Set requests on all containers; use LimitRange to enforce namespace defaults", resRefs("Pod", noRequestPods)...)
 	}
 	if len(noLimitPods) > 0 {
-		workload -= penNoLimits
-		addFinding(b, "POD_NO_LIMITS", "MEDIUM",
+		workload -= raise("POD_NO_LIMITS", "MEDIUM", penNoLimits,
 			"pods:"+joinFirst(noLimitPods, 3),
 			"Pods running without CPU/memory limits — risk of noisy-neighbour resource exhaustion",
-			"Set limits on all containers; use LimitRange to enforce namespace defaults")
+			"Set limits on all containers; use LimitRange to enforce namespace defaults", resRefs("Pod", noLimitPods)...)
 	}
 
 	// ── Backup/Recovery domain ──────────────────────────────────────────────
 	inv := b.Inventory.Backup
 	if inv.PrimaryTool == "none" || inv.PrimaryTool == "" {
-		backup -= penBackupNone
-		addFinding(b, "BACKUP_NONE", "CRITICAL", "cluster",
+		backup -= raise("BACKUP_NONE", "CRITICAL", penBackupNone, "cluster",
 			"No backup tool detected in cluster",
 			"Install a backup solution (Kasten K10, Velero, Rubrik, Longhorn) before DR onboarding")
 	} else {
 		// Tool present — check for coverage gaps
 		if len(inv.UncoveredStatefulNS) > 0 {
-			backup -= penBackupPartial
-			addFinding(b, "BACKUP_PARTIAL_COVERAGE", "HIGH",
+			backup -= raise("BACKUP_PARTIAL_COVERAGE", "HIGH", penBackupPartial,
 				"namespaces:"+joinFirst(inv.UncoveredStatefulNS, 3),
 				"StatefulSets found in namespaces not covered by backup policy",
-				"Extend backup policies to cover all stateful namespaces")
+				"Extend backup policies to cover all stateful namespaces", resRefs("Namespace", inv.UncoveredStatefulNS)...)
 		}
 		if len(inv.CoveredNamespaces) == 0 {
-			backup -= penScale(penBackupNoPolicies, wRestore)
-			addFinding(b, "BACKUP_NO_POLICIES", "HIGH", inv.PrimaryTool,
+			backup -= raise("BACKUP_NO_POLICIES", "HIGH", penScale(penBackupNoPolicies, wRestore), inv.ToolList(),
 				"Backup tool detected but no backup policies or schedules found",
 				"Create backup schedules covering all production namespaces")
 		}
@@ -233,51 +354,127 @@ func Evaluate(b *model.Bundle) {
 
 	// Offsite backup check — tool present but no offsite/export policy found.
 	if inv.PrimaryTool != "none" && inv.PrimaryTool != "" && !inv.HasOffsite {
-		backup -= penScale(penBackupNoOffsite, wRepl)
-		addFinding(b, "BACKUP_NO_OFFSITE", "HIGH", inv.PrimaryTool,
+		backup -= raise("BACKUP_NO_OFFSITE", "HIGH", penScale(penBackupNoOffsite, wRepl), inv.ToolList(),
 			"Backup tool detected but no offsite/export location configured",
 			"Configure an offsite or cloud export target to protect against site-level failures")
 	}
 
-	// RPO check — flag when worst-case RPO exceeds 24 hours.
+	// RPO check — flag namespaces whose best RPO across every tool covering
+	// them exceeds 24 hours (param maxHours).
+	rpoMaxHours := rules.IntParam("BACKUP_RPO_HIGH", "maxHours", 24)
+	var slowNS []string
 	worstRPO := 0
-	for _, p := range inv.Policies {
-		if p.RPOHours > worstRPO {
-			worstRPO = p.RPOHours
+	for _, np := range inv.Protection {
+		if np.RPOHours > rpoMaxHours {
+			slowNS = append(slowNS, np.Namespace)
+			if np.RPOHours > worstRPO {
+				worstRPO = np.RPOHours
+			}
 		}
 	}
-	if inv.PrimaryTool != "none" && len(inv.Policies) > 0 && worstRPO > 24 {
-		backup -= penBackupRPOHigh
-		addFinding(b, "BACKUP_RPO_HIGH", "MEDIUM", inv.PrimaryTool,
-			"Backup schedule results in RPO exposure greater than 24 hours",
-			"Increase backup frequency to reduce potential data loss window")
+	if inv.PrimaryTool != "none" && len(slowNS) > 0 {
+		backup -= raise("BACKUP_RPO_HIGH", "MEDIUM", penBackupRPOHigh, "namespaces:"+joinFirst(slowNS, 3),
+			fmt.Sprintf("Backup schedule results in RPO exposure greater than %d hours (worst-case gap between runs: %dh)", rpoMaxHours, worstRPO),
+			"Increase backup frequency to reduce potential data loss window", resRefs("Namespace", slowNS)...)
 	}
 
 	// Restore simulation — penalise when stateful namespaces have no coverage.
 	if sim := b.Inventory.Backup.RestoreSim; sim != nil && len(sim.UncoveredNS) > 0 {
-		backup -= penScale(penRestoreSimUncovered, wRestore)
-		addFinding(b, "RESTORE_SIM_UNCOVERED", "HIGH",
+		backup -= raise("RESTORE_SIM_UNCOVERED", "HIGH", penScale(penRestoreSimUncovered, wRestore),
 			"namespaces:"+joinFirst(sim.UncoveredNS, 3),
 			"Restore simulation: stateful namespaces have no backup policy coverage",
-			"Add backup policies covering all namespaces with PVCs or StatefulSets")
+			"Add backup policies covering all namespaces with PVCs or StatefulSets", resRefs("Namespace", sim.UncoveredNS)...)
+	}
+
+	// Recovery targets — namespaces whose achieved RPO, or the time they are
+	// ready in a full-cluster recovery, exceeds the objective they declare
+	// (annotations or --targets).
+	if sim := inv.RestoreSim; sim != nil {
+		var rpoMissed, rtoMissed []string
+		rpoDetail, rtoDetail := map[string]string{}, map[string]string{}
+		for _, ns := range sim.Namespaces {
+			if ns.RPOStatus == "missed" {
+				rpoMissed = append(rpoMissed, ns.Namespace)
+				achieved := "no backup coverage"
+				if ns.AchievedRPOHours >= 0 {
+					achieved = fmt.Sprintf("%gh", ns.AchievedRPOHours)
+				}
+				rpoDetail[ns.Namespace] = fmt.Sprintf("%s: %s vs target %gh", ns.Namespace, achieved, ns.Target.RPOHours)
+			}
+			if ns.RTOStatus == "missed" {
+				rtoMissed = append(rtoMissed, ns.Namespace)
+				rtoDetail[ns.Namespace] = fmt.Sprintf("%s: ready after %gh vs target %gh", ns.Namespace, ns.ReadyAfterHours, ns.Target.RTOHours)
+			}
+		}
+		sort.Strings(rpoMissed)
+		sort.Strings(rtoMissed)
+		if len(rpoMissed) > 0 {
+			backup -= raise("DR_RPO_TARGET_MISSED", "HIGH", penRPOTargetMissed, "namespaces:"+joinFirst(rpoMissed, 3),
+				fmt.Sprintf("%d namespace(s) exceed their RPO target (%s)", len(rpoMissed), rpoDetail[rpoMissed[0]]),
+				"Back these namespaces up more often than their RPO target, and keep backups succeeding", resRefs("Namespace", rpoMissed)...)
+		}
+		if len(rtoMissed) > 0 {
+			backup -= raise("DR_RTO_TARGET_MISSED", "HIGH", penScale(penRTOTargetMissed, wRestore), "namespaces:"+joinFirst(rtoMissed, 3),
+				fmt.Sprintf("%d namespace(s) would take longer to restore than their RTO target (%s)", len(rtoMissed), rtoDetail[rtoMissed[0]]),
+				"Reduce restore time (smaller volumes, faster storage, pre-staged images), restore more namespaces in parallel, or agree a realistic RTO", resRefs("Namespace", rtoMissed)...)
+		}
+	}
+
+	// Backup evidence — what Backup/Restore objects show actually ran.
+	if ev := inv.Evidence; ev != nil {
+		maxAge := rules.IntParam("BACKUP_STALE", "maxAgeHours", 48)
+		var stale []string
+		if sim := inv.RestoreSim; sim != nil {
+			for _, ns := range sim.Namespaces {
+				// Only tools whose backup objects were read can show a
+				// namespace is stale; the others leave it unmeasured.
+				if np, _ := inv.NamespaceCoverage(ns.Namespace); !evidenceFrom(ev, np.Tools) {
+					continue
+				}
+				if ns.Stateful && ns.HasCoverage && (ns.MeasuredRPOHours < 0 || ns.MeasuredRPOHours > float64(maxAge)) {
+					stale = append(stale, ns.Namespace)
+				}
+			}
+		}
+		sort.Strings(stale)
+		if len(stale) > 0 {
+			backup -= raise("BACKUP_STALE", "HIGH", penBackupStale,
+				"namespaces:"+joinFirst(stale, 3),
+				fmt.Sprintf("Covered stateful namespaces have no successful backup in the last %d hours", maxAge),
+				"Check the schedule and recent Backup objects for errors; the measured RPO is the age of the last Completed backup", resRefs("Namespace", stale)...)
+		}
+		if n := ev.Failed + ev.PartiallyFailed; n > 0 {
+			var names []string
+			for _, r := range ev.FailedBackups {
+				names = append(names, r.String())
+			}
+			backup -= raise("BACKUP_FAILED", "MEDIUM", penBackupFailed,
+				"backups:"+joinFirst(names, 3),
+				fmt.Sprintf("%d backup(s) failed and %d partially failed", ev.Failed, ev.PartiallyFailed),
+				"Inspect the failed backups' logs and fix the cause; partially-failed backups are missing items", ev.FailedBackups...)
+		}
+		if ev.Completed > 0 && ev.RestoresCompleted == 0 {
+			backup -= raise("RESTORE_NOT_TESTED", "MEDIUM", penScale(penRestoreUntested, wRestore), "cluster",
+				"Backups complete but no restore has ever completed — recoverability is unproven",
+				"Run a periodic test restore into a scratch namespace or cluster")
+		}
 	}
 
 	// CRDs present with no backup = extra risk
 	if len(b.Inventory.CRDs) > 0 && (inv.PrimaryTool == "none" || inv.PrimaryTool == "") {
-		backup -= penCRDNoBackup
-		addFinding(b, "CRD_NO_BACKUP", "MEDIUM", "crds",
+		backup -= raise("CRD_NO_BACKUP", "MEDIUM", penCRDNoBackup, "crds",
 			"Custom Resource Definitions present but no backup tool detected",
 			"Ensure backup solution captures CRD definitions and CR data")
 	}
 
-	// Certificates expiring within 30 days
+	// Certificates expiring within 30 days (param days)
+	certDays := rules.IntParam("CERT_EXPIRING_SOON", "days", 30)
 	for _, cert := range b.Inventory.Certificates {
-		if cert.DaysToExpiry >= 0 && cert.DaysToExpiry <= 30 {
-			backup -= penScale(penCertExpiring, wSec)
-			addFinding(b, "CERT_EXPIRING_SOON", "HIGH",
+		if cert.DaysToExpiry >= 0 && cert.DaysToExpiry <= certDays {
+			backup -= raise("CERT_EXPIRING_SOON", "HIGH", penScale(penCertExpiring, wSec),
 				cert.Namespace+"/"+cert.Name,
-				"Certificate expires within 30 days",
-				"Renew certificate before DR event window")
+				fmt.Sprintf("Certificate expires within %d days", certDays),
+				"Renew certificate before DR event window", resRef("Certificate", cert.Namespace+"/"+cert.Name))
 			break // penalise once per scan
 		}
 	}
@@ -290,16 +487,14 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if externalCount > 0 {
-		backup -= penScale(penImageExternal, wAirgap)
-		addFinding(b, "IMAGE_EXTERNAL_REGISTRY", "MEDIUM", "images",
+		backup -= raise("IMAGE_EXTERNAL_REGISTRY", "MEDIUM", penScale(penImageExternal, wAirgap), "images",
 			"Workloads depend on public container registries",
 			"Mirror critical images to a private registry accessible from the DR environment")
 	}
 
 	// Helm releases present (flag for values backup)
 	if len(b.Inventory.HelmReleases) > 0 && (inv.PrimaryTool == "none" || inv.PrimaryTool == "") {
-		backup -= penHelmUntracked
-		addFinding(b, "HELM_UNTRACKED", "LOW", "helm",
+		backup -= raise("HELM_UNTRACKED", "LOW", penHelmUntracked, "helm",
 			"Helm releases detected with no backup tool to capture release values",
 			"Back up Helm values (helm get values <release>) for each release before DR")
 	}
@@ -321,25 +516,22 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(wildRoles) > 0 {
-		config -= penScale(penRBACWildcard, wSec)
-		addFinding(b, "RBAC_WILDCARD_VERB", "CRITICAL",
+		config -= raise("RBAC_WILDCARD_VERB", "CRITICAL", penScale(penRBACWildcard, wSec),
 			"roles:"+joinFirst(wildRoles, 3),
 			"Custom ClusterRole grants wildcard verb permissions",
-			"Scope roles to specific resources and verbs; wildcard permissions are equivalent to cluster-admin")
+			"Scope roles to specific resources and verbs; wildcard permissions are equivalent to cluster-admin", resRefs("ClusterRole", wildRoles)...)
 	}
 	if len(escalateRoles) > 0 {
-		config -= penScale(penRBACEscalate, wSec)
-		addFinding(b, "RBAC_ESCALATE_PRIV", "HIGH",
+		config -= raise("RBAC_ESCALATE_PRIV", "HIGH", penScale(penRBACEscalate, wSec),
 			"roles:"+joinFirst(escalateRoles, 3),
 			"Custom ClusterRole grants escalate, bind, or impersonate verbs",
-			"Remove privilege escalation verbs unless explicitly required by the workload")
+			"Remove privilege escalation verbs unless explicitly required by the workload", resRefs("ClusterRole", escalateRoles)...)
 	}
 	if len(secretRoles) > 0 {
-		config -= penScale(penRBACSecrets, wSec)
-		addFinding(b, "RBAC_SECRET_ACCESS", "HIGH",
+		config -= raise("RBAC_SECRET_ACCESS", "HIGH", penScale(penRBACSecrets, wSec),
 			"roles:"+joinFirst(secretRoles, 3),
 			"Custom ClusterRole grants broad read access to Secrets",
-			"Restrict secret access to specific secrets by name; prefer Role/RoleBinding scoped to a namespace")
+			"Restrict secret access to specific secrets by name; prefer Role/RoleBinding scoped to a namespace", resRefs("ClusterRole", secretRoles)...)
 	}
 
 	// Round 12 — pod security audit (Config domain)
@@ -356,25 +548,22 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(privilegedPods) > 0 {
-		config -= penScale(penPrivileged, wSec)
-		addFinding(b, "POD_PRIVILEGED", "CRITICAL",
+		config -= raise("POD_PRIVILEGED", "CRITICAL", penScale(penPrivileged, wSec),
 			"pods:"+joinFirst(privilegedPods, 3),
 			"Pods run privileged containers — full host kernel access granted",
-			"Remove privileged:true; use specific capabilities (CAP_NET_ADMIN etc.) instead")
+			"Remove privileged:true; use specific capabilities (CAP_NET_ADMIN etc.) instead", resRefs("Pod", privilegedPods)...)
 	}
 	if len(hostNSPods) > 0 {
-		config -= penScale(penHostNetworkPID, wSec)
-		addFinding(b, "POD_HOST_NAMESPACE", "HIGH",
+		config -= raise("POD_HOST_NAMESPACE", "HIGH", penScale(penHostNetworkPID, wSec),
 			"pods:"+joinFirst(hostNSPods, 3),
 			"Pods share host network or PID namespace — increases blast radius on node compromise",
-			"Set hostNetwork:false and hostPID:false unless explicitly required by the workload")
+			"Set hostNetwork:false and hostPID:false unless explicitly required by the workload", resRefs("Pod", hostNSPods)...)
 	}
 
 	// Round 13 — VolumeSnapshot coverage (Storage domain)
 	if len(b.Inventory.VolumeSnapshotClasses) == 0 && len(b.Inventory.PVCs) > 0 {
 		// No snapshot infrastructure at all
-		storage -= penNoSnapshot
-		addFinding(b, "SNAPSHOT_NO_CLASS", "MEDIUM", "cluster",
+		storage -= raise("SNAPSHOT_NO_CLASS", "MEDIUM", penNoSnapshot, "cluster",
 			"No VolumeSnapshotClass found — CSI snapshot capability not configured",
 			"Install a CSI driver that supports snapshots and create a VolumeSnapshotClass")
 	} else if len(b.Inventory.VolumeSnapshotClasses) > 0 {
@@ -391,11 +580,10 @@ func Evaluate(b *model.Bundle) {
 			}
 		}
 		if len(unsnapshottedPVCs) > 0 {
-			storage -= penNoSnapshot
-			addFinding(b, "SNAPSHOT_PVC_UNCOVERED", "MEDIUM",
+			storage -= raise("SNAPSHOT_PVC_UNCOVERED", "MEDIUM", penNoSnapshot,
 				"pvcs:"+joinFirst(unsnapshottedPVCs, 3),
 				"PVCs have no VolumeSnapshot — point-in-time recovery not available for these volumes",
-				"Create VolumeSnapshots (or a schedule via the snapshot-controller) for all production PVCs")
+				"Create VolumeSnapshots (or a schedule via the snapshot-controller) for all production PVCs", resRefs("PersistentVolumeClaim", unsnapshottedPVCs)...)
 		}
 	}
 
@@ -419,12 +607,12 @@ func Evaluate(b *model.Bundle) {
 			lrMissingNS = append(lrMissingNS, ns)
 		}
 	}
+	sort.Strings(lrMissingNS)
 	if len(lrMissingNS) > 0 {
-		config -= penScale(penLRMissing, wSec)
-		addFinding(b, "LR_MISSING_NAMESPACE", "MEDIUM",
+		config -= raise("LR_MISSING_NAMESPACE", "MEDIUM", penScale(penLRMissing, wSec),
 			"namespaces:"+joinFirst(lrMissingNS, 3),
 			"Namespaces have pods but no LimitRange — unbounded resource consumption possible",
-			"Add a LimitRange to each namespace to enforce default CPU/memory requests and limits")
+			"Add a LimitRange to each namespace to enforce default CPU/memory requests and limits", resRefs("Namespace", lrMissingNS)...)
 	}
 
 	// ── Round 14 — PSA label coverage (Config domain) ────────────────────────
@@ -441,17 +629,15 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(psaMissingNS) > 0 {
-		config -= penScale(penPSAMissing, wSec)
-		addFinding(b, "PSA_MISSING_ENFORCE_LABEL", "MEDIUM",
+		config -= raise("PSA_MISSING_ENFORCE_LABEL", "MEDIUM", penScale(penPSAMissing, wSec),
 			"namespaces:"+joinFirst(psaMissingNS, 3),
 			"Namespaces lack pod-security.kubernetes.io/enforce label — PSA admission not enforced",
-			"Set pod-security.kubernetes.io/enforce=baseline (or restricted) on each namespace to enable Pod Security Admission")
+			"Set pod-security.kubernetes.io/enforce=baseline (or restricted) on each namespace to enable Pod Security Admission", resRefs("Namespace", psaMissingNS)...)
 	}
 
 	// ── Round 14 — etcd backup detection (Backup domain) ─────────────────────
 	if eb := b.Inventory.EtcdBackup; eb != nil && !eb.Detected {
-		backup -= penScale(penEtcdNoBackup, wSec)
-		addFinding(b, "ETCD_BACKUP_MISSING", "HIGH",
+		backup -= raise("ETCD_BACKUP_MISSING", "HIGH", penScale(penEtcdNoBackup, wSec),
 			"cluster",
 			"No etcd backup evidence found — complete cluster state loss is unrecoverable without etcd",
 			"Configure periodic etcd snapshots (e.g. etcdctl snapshot save) via a CronJob, or use a managed K8s service that handles this automatically")
@@ -471,12 +657,12 @@ func Evaluate(b *model.Bundle) {
 			npMissingNS = append(npMissingNS, ns)
 		}
 	}
+	sort.Strings(npMissingNS)
 	if len(npMissingNS) > 0 {
-		config -= penScale(penNPMissing, wSec)
-		addFinding(b, "NETPOL_MISSING_NAMESPACE", "MEDIUM",
+		config -= raise("NETPOL_MISSING_NAMESPACE", "MEDIUM", penScale(penNPMissing, wSec),
 			"namespaces:"+joinFirst(npMissingNS, 3),
 			"Namespaces have pods but no NetworkPolicy — unrestricted east-west traffic between all pods",
-			"Add default-deny NetworkPolicies to each namespace and allow only required traffic paths")
+			"Add default-deny NetworkPolicies to each namespace and allow only required traffic paths", resRefs("Namespace", npMissingNS)...)
 	}
 
 	// ── Round 16 — Node health + zone topology (Workload domain) ─────────────
@@ -491,15 +677,13 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(notReadyNodes) > 0 {
-		workload -= penScale(penNodeNotReady, wRepl)
-		addFinding(b, "NODE_NOT_READY", "HIGH",
+		workload -= raise("NODE_NOT_READY", "HIGH", penScale(penNodeNotReady, wRepl),
 			"nodes:"+joinFirst(notReadyNodes, 3),
 			"One or more nodes are NotReady — workload capacity is reduced and DR failover may be impaired",
-			"Investigate node conditions (kubectl describe node) and resolve underlying issues; ensure cluster has sufficient spare capacity")
+			"Investigate node conditions (kubectl describe node) and resolve underlying issues; ensure cluster has sufficient spare capacity", resRefs("Node", notReadyNodes)...)
 	}
 	if len(b.Inventory.Nodes) > 1 && len(zoneSet) == 1 {
-		workload -= penScale(penSingleAZ, wRepl)
-		addFinding(b, "SINGLE_AZ_CLUSTER", "MEDIUM",
+		workload -= raise("SINGLE_AZ_CLUSTER", "MEDIUM", penScale(penSingleAZ, wRepl),
 			"cluster",
 			"All nodes reside in a single availability zone — an AZ outage would take down the entire cluster",
 			"Distribute nodes across at least 3 availability zones; use topology spread constraints on critical workloads")
@@ -528,26 +712,23 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(scDelete) > 0 {
-		storage -= penScale(penSCDeletePolicy, wImmut)
-		addFinding(b, "SC_RECLAIM_DELETE", "MEDIUM",
+		storage -= raise("SC_RECLAIM_DELETE", "MEDIUM", penScale(penSCDeletePolicy, wImmut),
 			"storageclasses:"+joinFirst(scDelete, 3),
 			"StorageClass uses ReclaimPolicy=Delete — PV (and data) is destroyed when the PVC is deleted",
-			"Change reclaimPolicy to Retain on production StorageClasses to prevent accidental data loss")
+			"Change reclaimPolicy to Retain on production StorageClasses to prevent accidental data loss", resRefs("StorageClass", scDelete)...)
 	}
 	if len(scHostPath) > 0 {
-		storage -= penScale(penSCHostPath, wImmut)
-		addFinding(b, "SC_HOSTPATH_PROVISIONER", "HIGH",
+		storage -= raise("SC_HOSTPATH_PROVISIONER", "HIGH", penScale(penSCHostPath, wImmut),
 			"storageclasses:"+joinFirst(scHostPath, 3),
 			"StorageClass uses a hostPath provisioner — volumes are node-local and cannot be recovered after node failure",
-			"Replace hostPath storage with a network-attached CSI driver (e.g. AWS EBS, Azure Disk, Ceph/Rook) for portable, recoverable storage")
+			"Replace hostPath storage with a network-attached CSI driver (e.g. AWS EBS, Azure Disk, Ceph/Rook) for portable, recoverable storage", resRefs("StorageClass", scHostPath)...)
 	}
 	if len(scZoneUnaware) > 0 && len(zoneSet) > 1 {
 		// Only penalise when cluster spans multiple zones — single-AZ clusters get single-AZ finding instead
-		storage -= penScale(penSCZoneUnaware, wImmut)
-		addFinding(b, "SC_ZONE_UNAWARE", "LOW",
+		storage -= raise("SC_ZONE_UNAWARE", "LOW", penScale(penSCZoneUnaware, wImmut),
 			"storageclasses:"+joinFirst(scZoneUnaware, 3),
 			"StorageClass may not be zone-aware in a multi-AZ cluster — PVs could be provisioned in a different AZ than the pod",
-			"Set volumeBindingMode: WaitForFirstConsumer so volumes are provisioned in the same zone as the consuming pod")
+			"Set volumeBindingMode: WaitForFirstConsumer so volumes are provisioned in the same zone as the consuming pod", resRefs("StorageClass", scZoneUnaware)...)
 	}
 
 	// ── Round 18 — ServiceAccount token audit (Config domain) ─────────────────
@@ -574,11 +755,10 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(defaultSAOverPriv) > 0 {
-		config -= penScale(penDefaultSAOverPriv, wSec)
-		addFinding(b, "SA_DEFAULT_OVERPRIV", "HIGH",
+		config -= raise("SA_DEFAULT_OVERPRIV", "HIGH", penScale(penDefaultSAOverPriv, wSec),
 			"serviceaccounts:"+joinFirst(defaultSAOverPriv, 3),
 			"Default ServiceAccount has explicit ClusterRoleBinding — any pod in the namespace inherits elevated cluster permissions",
-			"Remove the ClusterRoleBinding from the default SA; create dedicated ServiceAccounts with minimal required permissions")
+			"Remove the ClusterRoleBinding from the default SA; create dedicated ServiceAccounts with minimal required permissions", resRefs("ServiceAccount", defaultSAOverPriv)...)
 	}
 	var autoMountPods []string
 	for _, pod := range b.Inventory.Pods {
@@ -590,11 +770,132 @@ func Evaluate(b *model.Bundle) {
 		}
 	}
 	if len(autoMountPods) > 0 {
-		config -= penScale(penAutoMountSA, wSec)
-		addFinding(b, "SA_AUTOMOUNT_TOKEN", "MEDIUM",
+		config -= raise("SA_AUTOMOUNT_TOKEN", "MEDIUM", penScale(penAutoMountSA, wSec),
 			"pods:"+joinFirst(autoMountPods, 3),
 			"Pods have automountServiceAccountToken enabled — token is mounted even when the pod does not call the Kubernetes API",
-			"Set automountServiceAccountToken: false on pods (or their ServiceAccount) that do not need API access")
+			"Set automountServiceAccountToken: false on pods (or their ServiceAccount) that do not need API access", resRefs("Pod", autoMountPods)...)
+	}
+
+	// ── DR target portability ────────────────────────────────────────────────
+	if pr := b.Portability; pr != nil {
+		byKind := map[string][]string{}
+		detail := map[string]string{}
+		for _, g := range pr.Gaps {
+			byKind[g.Kind] = append(byKind[g.Kind], g.Name)
+			if _, ok := detail[g.Kind]; !ok {
+				detail[g.Kind] = g.Detail
+			}
+		}
+		where := " on DR target " + pr.Target
+		if names := byKind["StorageClass"]; len(names) > 0 {
+			storage -= raise("PORT_STORAGECLASS_MISSING", "HIGH", penPortStorageClass, "storageclasses:"+joinFirst(names, 3),
+				fmt.Sprintf("%d StorageClass(es) used by PVCs are missing%s (%s)", len(names), where, detail["StorageClass"]),
+				"Create StorageClasses with the same names on the target, or map them at restore time (e.g. Velero change-storage-class config)", resRefs("StorageClass", names)...)
+		}
+		if names := byKind["CSIDriver"]; len(names) > 0 {
+			storage -= raise("PORT_CSI_DRIVER_MISSING", "HIGH", penPortCSIDriver, "csidrivers:"+joinFirst(names, 3),
+				fmt.Sprintf("%d CSI driver(s) are not installed%s (%s)", len(names), where, detail["CSIDriver"]),
+				"Install the CSI drivers on the target, or plan a file-level restore of these volumes onto a driver it has", resRefs("CSIDriver", names)...)
+		}
+		if names := byKind["VolumeSnapshotClass"]; len(names) > 0 {
+			storage -= raise("PORT_SNAPSHOTCLASS_MISSING", "MEDIUM", penPortSnapshot, "volumesnapshotclasses:"+joinFirst(names, 3),
+				fmt.Sprintf("%d snapshot driver(s) have no VolumeSnapshotClass%s (%s)", len(names), where, detail["VolumeSnapshotClass"]),
+				"Create a VolumeSnapshotClass for each driver on the target, labelled for your backup tool where it selects classes by label", resRefs("VolumeSnapshotClass", names)...)
+		}
+		if names := byKind["API"]; len(names) > 0 {
+			config -= raise("PORT_API_NOT_SERVED", "HIGH", penPortAPI, "apis:"+joinFirst(names, 3),
+				fmt.Sprintf("%d custom API group(s) are not fully served%s (%s)", len(names), where, detail["API"]),
+				"Install the same CRD versions and operators on the target before restoring their custom resources", resRefs("APIGroup", names)...)
+		}
+		if names := byKind["IngressClass"]; len(names) > 0 {
+			config -= raise("PORT_INGRESSCLASS_MISSING", "MEDIUM", penPortIngressClass, "ingressclasses:"+joinFirst(names, 3),
+				fmt.Sprintf("%d IngressClass(es) are missing%s (%s)", len(names), where, detail["IngressClass"]),
+				"Install the ingress controller on the target and create the same IngressClasses (and a default class if Ingresses rely on one)", resRefs("IngressClass", names)...)
+		}
+		if len(byKind["Version"]) > 0 {
+			sev := "MEDIUM"
+			if pr.VersionSkew < 0 {
+				sev = "HIGH"
+			}
+			config -= raise("PORT_VERSION_SKEW", sev, penPortVersion, "target",
+				detail["Version"],
+				"Run the DR cluster at the source's Kubernetes minor version, or one newer, and upgrade both together")
+		}
+		// The DR site capacity check below measures the same shortfall
+		// against what the protected workloads request; prefer it.
+		if names := byKind["Capacity"]; len(names) > 0 && b.Capacity == nil {
+			var msgs []string
+			for _, g := range pr.Gaps {
+				if g.Kind == "Capacity" {
+					msgs = append(msgs, g.Detail)
+				}
+			}
+			workload -= raise("PORT_CAPACITY_SHORT", "HIGH", penPortCapacity, "target",
+				fmt.Sprintf("DR target %s has less capacity than the source: %s", pr.Target, strings.Join(msgs, "; ")),
+				"Size the DR node pools to the source's allocatable CPU and memory, or set up autoscaling that can reach it during a failover")
+		}
+	}
+
+	// ── DR site capacity ─────────────────────────────────────────────────────
+	if cr := b.Capacity; cr != nil {
+		if len(cr.Short) > 0 {
+			var short, over []string
+			for _, res := range cr.Short {
+				switch res {
+				case "cpu":
+					short = append(short, fmt.Sprintf("CPU %.1f of %.1f cores", float64(cr.RequiredCPUMillis)/1000, float64(cr.CPUMillis)/1000))
+				case "memory":
+					short = append(short, fmt.Sprintf("memory %.1f of %.1f GiB", float64(cr.RequiredMemoryBytes)/(1<<30), float64(cr.MemoryBytes)/(1<<30)))
+				case "pods":
+					short = append(short, fmt.Sprintf("%d of %d pods", cr.RequiredPods, cr.Pods))
+				}
+			}
+			for _, ns := range cr.Namespaces {
+				if ns.Protected && !ns.Fits {
+					over = append(over, ns.Namespace)
+				}
+			}
+			workload -= raise("CAPACITY_DR_SHORT", "HIGH", penCapacityShort, "site",
+				fmt.Sprintf("DR site %s cannot run the protected workloads after a failover: they request %s; %d namespace(s) would not fit (%s)",
+					cr.Site, strings.Join(short, ", "), len(over), joinFirst(over, 3)),
+				"Add DR nodes or larger node pools (or autoscaling that can reach the required size), or restore fewer namespaces in a failover and mark the rest as not protected", resRefs("Namespace", over)...)
+		}
+		if len(cr.Oversized) > 0 {
+			pods := make([]string, 0, len(cr.Oversized))
+			for _, ref := range cr.Oversized {
+				pods = append(pods, ref.Namespace+"/"+ref.Name)
+			}
+			workload -= raise("CAPACITY_POD_TOO_LARGE", "MEDIUM", penCapacityPodSize, "pods:"+joinFirst(pods, 3),
+				fmt.Sprintf("%d protected pod(s) request more CPU or memory than any single node on DR site %s allocates; they would stay Pending after a failover", len(pods), cr.Site),
+				"Add a DR node pool large enough for these pods, or lower their requests", cr.Oversized...)
+		}
+	}
+
+	// ── Custom rules (--rules-file "custom", CEL) ─────────────────────────────
+	for _, cr := range custom {
+		refs, hit, err := cr.violations(b)
+		if err != nil {
+			log.Printf("custom rule %s: %v (skipped)", cr.ID, err)
+			continue
+		}
+		if !hit {
+			continue
+		}
+		resource := "cluster"
+		if len(refs) > 0 {
+			resource = summarize("", refs)
+		}
+		pen := raise(cr.ID, cr.Severity, cr.Penalty, resource, cr.Message, cr.Recommendation, refs...)
+		switch cr.Domain {
+		case "STORAGE":
+			storage -= pen
+		case "WORKLOAD":
+			workload -= pen
+		case "CONFIG":
+			config -= pen
+		case "BACKUP":
+			backup -= pen
+		}
 	}
 
 	storage = clamp(storage)
@@ -628,16 +929,6 @@ func weightedOverall(storage, workload, config, backup int) int {
 	return (sum + 50) / 100
 }
 
-func addFinding(b *model.Bundle, id, severity, resource, message, recommendation string) {
-	b.Inventory.Findings = append(b.Inventory.Findings, model.Finding{
-		ID:             id,
-		Severity:       severity,
-		ResourceID:     resource,
-		Message:        message,
-		Recommendation: recommendation,
-	})
-}
-
 func clamp(v int) int {
 	if v < 0 {
 		return 0
@@ -648,6 +939,35 @@ func clamp(v int) int {
 	return v
 }
 
+// resRef builds a ResourceRef from a "namespace/name" or cluster-scoped "name" ID.
+func resRef(kind, id string) model.ResourceRef {
+	if i := strings.Index(id, "/"); i > 0 && kind != "Namespace" {
+		return model.ResourceRef{Kind: kind, Namespace: id[:i], Name: id[i+1:]}
+	}
+	return model.ResourceRef{Kind: kind, Name: id}
+}
+
+func resRefs(kind string, ids []string) []model.ResourceRef {
+	out := make([]model.ResourceRef, len(ids))
+	for i, id := range ids {
+		out[i] = resRef(kind, id)
+	}
+	return out
+}
+
+// summaryPrefix matches the "pods:" style kind prefix of an aggregate ResourceID.
+var summaryPrefix = regexp.MustCompile(`^[a-z]+:`)
+
+// summarize rebuilds a ResourceID for a subset of refs, keeping the original
+// kind prefix.
+func summarize(resource string, refs []model.ResourceRef) string {
+	ids := make([]string, len(refs))
+	for i, r := range refs {
+		ids[i] = r.String()
+	}
+	return summaryPrefix.FindString(resource) + joinFirst(ids, 3)
+}
+
 func joinFirst(ss []string, max int) string {
 	if len(ss) <= max {
 		result := ""
@@ -668,3 +988,19 @@ func joinFirst(ss []string, max int) string {
 	}
 	return result + "..."
 }
+
+// evidenceFrom reports whether ev came from at least one of tools. Evidence
+// that does not record its tools (earlier scan files) is taken to cover all.
+func evidenceFrom(ev *model.BackupEvidence, tools []string) bool {
+	if len(ev.Tools) == 0 {
+		return true
+	}
+	for _, t := range tools {
+		for _, et := range ev.Tools {
+			if t == et {
+				return true
+			}
+		}
+	}
+	return false
+}
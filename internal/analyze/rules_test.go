@@ -1,6 +1,13 @@
 package analyze
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+	"k8s-recovery-visualizer/internal/waiver"
+)
 
 func TestWeightedOverall(t *testing.T) {
 	// All domains at 100 → overall 100
@@ -85,3 +92,101 @@ func TestJoinFirst(t *testing.T) {
 		t.Errorf("joinFirst([a,b,c,d], 3) = %q, want %q", got, "a,b,c...")
 	}
 }
+
+func TestEvaluateWaivers(t *testing.T) {
+	newBundle := func() model.Bundle {
+		b := model.NewBundle("test", time.Now())
+		b.Inventory.PVs = []model.PersistentVolume{{Name: "pv-orphan"}}
+		return b
+	}
+	ws := []waiver.Waiver{{ID: "PV_ORPHAN", Resource: "pv-*", Owner: "storage", Justification: "reclaimed manually", Expires: "2026-06-30"}}
+
+	b := newBundle()
+	EvaluateWith(&b, Options{Waivers: ws, Now: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)})
+	orphan := func(fs []model.Finding) *model.Finding {
+		for i := range fs {
+			if fs[i].ID == "PV_ORPHAN" {
+				return &fs[i]
+			}
+		}
+		return nil
+	}
+	if orphan(b.Inventory.Findings) != nil || orphan(b.Inventory.WaivedFindings) == nil {
+		t.Fatalf("findings = %v, waived = %v; want PV_ORPHAN waived", b.Inventory.Findings, b.Inventory.WaivedFindings)
+	}
+	if b.Score.Storage.Final != 100 {
+		t.Errorf("storage score = %d, want 100 with the finding waived", b.Score.Storage.Final)
+	}
+
+	b = newBundle()
+	EvaluateWith(&b, Options{Waivers: ws, Now: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)})
+	if f := orphan(b.Inventory.Findings); f == nil || f.Waiver == nil || !f.Waiver.Expired {
+		t.Fatalf("findings = %+v; want PV_ORPHAN active again with an expired waiver", b.Inventory.Findings)
+	}
+	if b.Score.Storage.Final != 100-penPVOrphan {
+		t.Errorf("storage score = %d, want %d once the waiver expired", b.Score.Storage.Final, 100-penPVOrphan)
+	}
+}
+
+func TestFindingResourcesAndPartialWaiver(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	for _, id := range []string{"app/a", "app/b", "app/c", "app/d", "infra/agent"} {
+		ns, name, _ := strings.Cut(id, "/")
+		b.Inventory.Pods = append(b.Inventory.Pods, model.Pod{Namespace: ns, Name: name, Privileged: true})
+	}
+	ws := []waiver.Waiver{{ID: "POD_PRIVILEGED", Namespace: "infra", Owner: "platform", Justification: "node agent", Expires: "2026-12-31"}}
+	EvaluateWith(&b, Options{Waivers: ws, Now: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)})
+
+	find := func(fs []model.Finding) *model.Finding {
+		for i := range fs {
+			if fs[i].ID == "POD_PRIVILEGED" {
+				return &fs[i]
+			}
+		}
+		return nil
+	}
+	f := find(b.Inventory.Findings)
+	if f == nil {
+		t.Fatalf("POD_PRIVILEGED not raised: %v", b.Inventory.Findings)
+	}
+	if len(f.Resources) != 4 {
+		t.Errorf("resources = %v, want the four app pods", f.Resources)
+	}
+	if f.ResourceID != "pods:app/a,app/b,app/c..." {
+		t.Errorf("ResourceID = %q, want a truncated summary", f.ResourceID)
+	}
+	w := find(b.Inventory.WaivedFindings)
+	if w == nil || len(w.Resources) != 1 || w.Resources[0].String() != "infra/agent" {
+		t.Fatalf("waived = %+v, want only infra/agent", b.Inventory.WaivedFindings)
+	}
+}
+
+func TestBackupStaleOnlyForToolsWithEvidence(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Backup = model.BackupInventory{
+		PrimaryTool: "velero",
+		ActiveTools: []string{"velero", "trilio"},
+		HasOffsite:  true,
+		Protection: []model.NamespaceProtection{
+			{Namespace: "db", Tools: []string{"velero"}, RPOHours: 24},
+			{Namespace: "files", Tools: []string{"trilio"}, RPOHours: 24},
+		},
+		Evidence: &model.BackupEvidence{Tools: []string{"velero"}},
+		RestoreSim: &model.RestoreSimResult{Namespaces: []model.RestoreSimNamespace{
+			{Namespace: "db", Stateful: true, HasCoverage: true, MeasuredRPOHours: -1},
+			{Namespace: "files", Stateful: true, HasCoverage: true, MeasuredRPOHours: -1},
+		}},
+	}
+	Evaluate(&b)
+
+	for _, f := range b.Inventory.Findings {
+		if f.ID != "BACKUP_STALE" {
+			continue
+		}
+		if len(f.Resources) != 1 || f.Resources[0].Name != "db" {
+			t.Errorf("BACKUP_STALE resources = %v, want db only: trilio has no evidence reader", f.Resources)
+		}
+		return
+	}
+	t.Errorf("BACKUP_STALE not raised for db: %v", b.Inventory.Findings)
+}
@@ -0,0 +1,430 @@
+// Package apps groups inventory resources into applications and reports the
+// DR status of each one.
+package apps
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s-recovery-visualizer/internal/kutil"
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// Well-known labels, most specific grouping first.
+const (
+	labelPartOf   = "app.kubernetes.io/part-of"
+	labelInstance = "app.kubernetes.io/instance"
+	labelName     = "app.kubernetes.io/name"
+)
+
+// app is an application being built.
+type app struct {
+	model.Application
+	resources  map[model.ResourceRef]bool
+	namespaces map[string]bool
+}
+
+type grouper struct {
+	apps map[string]*app // by key
+}
+
+// get returns the app for key, creating it with name and source.
+func (g *grouper) get(key, name, source string) *app {
+	a, ok := g.apps[key]
+	if !ok {
+		a = &app{
+			Application: model.Application{Name: name, Source: source},
+			resources:   map[model.ResourceRef]bool{},
+			namespaces:  map[string]bool{},
+		}
+		g.apps[key] = a
+	}
+	return a
+}
+
+func (a *app) add(kind, ns, name string) {
+	a.resources[model.ResourceRef{Kind: kind, Namespace: ns, Name: name}] = true
+	if ns != "" {
+		a.namespaces[ns] = true
+	}
+}
+
+// workload is a Deployment, StatefulSet or DaemonSet.
+type workload struct {
+	kind, ns, name string
+	refs           model.WorkloadRefs
+	sts            *model.StatefulSet
+	key            string
+}
+
+var workloadKinds = map[string]bool{"Deployment": true, "StatefulSet": true, "DaemonSet": true}
+
+// Group groups the resources outside the system namespaces into
+// applications. A workload's application is, in order:
+//
+//   - its app.kubernetes.io/part-of label (which may span namespaces);
+//   - its app.kubernetes.io/instance label or Helm release;
+//   - its app.kubernetes.io/name label;
+//   - the application of the workload, or the custom resource, that owns it;
+//   - otherwise the workload on its own.
+//
+// ConfigMaps, Secrets and PVCs the workload uses join its application, as do
+// Services selecting its pods, Ingresses routing to those Services, and
+// objects in the same Helm release. Call it after analyze.Evaluate so the
+// findings are known.
+func Group(b *model.Bundle) []model.Application {
+	inv := b.Inventory
+	g := &grouper{apps: map[string]*app{}}
+
+	var workloads []*workload
+	for _, d := range inv.Deployments {
+		workloads = append(workloads, &workload{kind: "Deployment", ns: d.Namespace, name: d.Name, refs: d.WorkloadRefs})
+	}
+	for i, s := range inv.StatefulSets {
+		workloads = append(workloads, &workload{kind: "StatefulSet", ns: s.Namespace, name: s.Name, refs: s.WorkloadRefs, sts: &inv.StatefulSets[i]})
+	}
+	for _, d := range inv.DaemonSets {
+		workloads = append(workloads, &workload{kind: "DaemonSet", ns: d.Namespace, name: d.Name, refs: d.WorkloadRefs})
+	}
+	byID := map[string]*workload{}
+	for _, w := range workloads {
+		byID[w.kind+"/"+w.ns+"/"+w.name] = w
+	}
+
+	// Owned workloads join their owner's application, so assign owners
+	// first: one pass per level of ownership is plenty in practice.
+	for pass := 0; pass < 3; pass++ {
+		for _, w := range workloads {
+			if w.key != "" || kutil.SystemNamespace(w.ns) {
+				continue
+			}
+			owner, owned := workloadOwner(w, byID)
+			if owned && owner.key == "" {
+				continue
+			}
+			var a *app
+			if owned {
+				a = g.apps[owner.key]
+				w.key = owner.key
+			} else {
+				key, name, source := appKey(w)
+				a = g.get(key, name, source)
+				w.key = key
+			}
+			a.add(w.kind, w.ns, w.name)
+		}
+	}
+	// Ownership cycles or chains deeper than three: group on their own.
+	for _, w := range workloads {
+		if w.key == "" && !kutil.SystemNamespace(w.ns) {
+			w.key = w.ns + "/" + w.name
+			g.get(w.key, w.name, "workload").add(w.kind, w.ns, w.name)
+		}
+	}
+
+	releaseApp := map[string]string{} // "ns/release" → app key
+	for _, w := range workloads {
+		if w.key == "" {
+			continue
+		}
+		a := g.apps[w.key]
+		for _, o := range w.refs.Owners {
+			if !workloadKinds[o.Kind] && o.Kind != "ReplicaSet" {
+				a.add(o.Kind, w.ns, o.Name)
+			}
+		}
+		for _, cm := range w.refs.ConfigMaps {
+			a.add("ConfigMap", w.ns, cm)
+		}
+		for _, s := range w.refs.Secrets {
+			a.add("Secret", w.ns, s)
+		}
+		for _, p := range w.refs.PVCs {
+			a.add("PersistentVolumeClaim", w.ns, p)
+		}
+		if w.sts != nil {
+			for _, pvc := range inv.PVCs {
+				if pvc.Namespace == w.ns && w.sts.OwnsClaim(pvc.Name) {
+					a.add("PersistentVolumeClaim", w.ns, pvc.Name)
+				}
+			}
+		}
+		if r := w.refs.HelmRelease; r != "" {
+			if _, ok := releaseApp[w.ns+"/"+r]; !ok {
+				releaseApp[w.ns+"/"+r] = w.key
+			}
+		}
+	}
+
+	// Objects that only belong through their Helm release, and Services and
+	// Ingresses through the workloads behind them.
+	inRelease := func(kind, ns, name, release string) bool {
+		if release == "" || kutil.SystemNamespace(ns) {
+			return false
+		}
+		key, ok := releaseApp[ns+"/"+release]
+		if !ok {
+			key = ns + "/" + release
+			releaseApp[key] = key
+		}
+		g.get(key, release, "helm").add(kind, ns, name)
+		return true
+	}
+	for _, cm := range inv.ConfigMaps {
+		inRelease("ConfigMap", cm.Namespace, cm.Name, cm.HelmRelease)
+	}
+	for _, s := range inv.Secrets {
+		inRelease("Secret", s.Namespace, s.Name, s.HelmRelease)
+	}
+	for _, pvc := range inv.PVCs {
+		inRelease("PersistentVolumeClaim", pvc.Namespace, pvc.Name, pvc.HelmRelease)
+	}
+	serviceApp := map[string]string{} // "ns/name" → app key
+	for _, svc := range inv.Services {
+		if inRelease("Service", svc.Namespace, svc.Name, svc.HelmRelease) {
+			serviceApp[svc.Namespace+"/"+svc.Name] = releaseApp[svc.Namespace+"/"+svc.HelmRelease]
+			continue
+		}
+		if len(svc.Selector) == 0 {
+			continue
+		}
+		for _, w := range workloads {
+			if w.key != "" && w.ns == svc.Namespace && kutil.Selects(svc.Selector, w.refs.PodLabels) {
+				g.apps[w.key].add("Service", svc.Namespace, svc.Name)
+				serviceApp[svc.Namespace+"/"+svc.Name] = w.key
+				break
+			}
+		}
+	}
+	for _, ing := range inv.Ingresses {
+		if inRelease("Ingress", ing.Namespace, ing.Name, ing.HelmRelease) {
+			continue
+		}
+		for _, r := range ing.Rules {
+			svc, _, _ := strings.Cut(r.Backend, ":")
+			if key, ok := serviceApp[ing.Namespace+"/"+svc]; ok {
+				g.apps[key].add("Ingress", ing.Namespace, ing.Name)
+				break
+			}
+		}
+	}
+
+	// The workload owning each collected pod, for pod-level findings.
+	podOwners := map[model.ResourceRef]model.ResourceRef{}
+	for _, p := range inv.Pods {
+		if p.OwnerKind != "" {
+			podOwners[model.ResourceRef{Kind: "Pod", Namespace: p.Namespace, Name: p.Name}] =
+				model.ResourceRef{Kind: p.OwnerKind, Namespace: p.Namespace, Name: p.OwnerName}
+		}
+	}
+
+	out := make([]model.Application, 0, len(g.apps))
+	for _, a := range g.apps {
+		a.finish(b, podOwners)
+		out = append(out, a.Application)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return strings.Join(out[i].Namespaces, ",") < strings.Join(out[j].Namespaces, ",")
+	})
+	return out
+}
+
+// appKey picks a workload's application from its labels and Helm release,
+// falling back to a custom resource owner and then the workload itself.
+func appKey(w *workload) (key, name, source string) {
+	label := func(k string) string {
+		if v := w.refs.AppLabels[k]; v != "" {
+			return v
+		}
+		return w.refs.PodLabels[k]
+	}
+	if v := label(labelPartOf); v != "" {
+		return "part-of/" + v, v, "part-of"
+	}
+	if v := label(labelInstance); v != "" {
+		return w.ns + "/" + v, v, "instance"
+	}
+	if v := w.refs.HelmRelease; v != "" {
+		return w.ns + "/" + v, v, "helm"
+	}
+	if v := label(labelName); v != "" {
+		return w.ns + "/" + v, v, "name"
+	}
+	for _, o := range w.refs.Owners {
+		if !workloadKinds[o.Kind] && o.Kind != "ReplicaSet" {
+			return w.ns + "/" + o.Name, o.Name, "owner"
+		}
+	}
+	return w.ns + "/" + w.name, w.name, "workload"
+}
+
+// workloadOwner returns the collected workload that owns w, if any.
+func workloadOwner(w *workload, byID map[string]*workload) (*workload, bool) {
+	for _, o := range w.refs.Owners {
+		if owner, ok := byID[o.Kind+"/"+w.ns+"/"+o.Name]; ok && owner != w {
+			return owner, true
+		}
+	}
+	return nil, false
+}
+
+// finish fills in the resource list and the DR status. podOwners maps pods
+// to the workloads that own them.
+func (a *app) finish(b *model.Bundle, podOwners map[model.ResourceRef]model.ResourceRef) {
+	inv := b.Inventory
+	for r := range a.resources {
+		a.Resources = append(a.Resources, r)
+	}
+	sort.Slice(a.Resources, func(i, j int) bool {
+		x, y := a.Resources[i], a.Resources[j]
+		if x.Kind != y.Kind {
+			return x.Kind < y.Kind
+		}
+		if x.Namespace != y.Namespace {
+			return x.Namespace < y.Namespace
+		}
+		return x.Name < y.Name
+	})
+	for ns := range a.namespaces {
+		a.Namespaces = append(a.Namespaces, ns)
+	}
+	sort.Strings(a.Namespaces)
+
+	protection := map[string]model.NamespaceProtection{}
+	for _, np := range inv.Backup.Protection {
+		protection[np.Namespace] = np
+	}
+	tools := map[string]bool{}
+	for _, pvc := range inv.PVCs {
+		if !a.resources[model.ResourceRef{Kind: "PersistentVolumeClaim", Namespace: pvc.Namespace, Name: pvc.Name}] {
+			continue
+		}
+		a.PVCs++
+		a.PVCSizeGB += kutil.SizeGiB(pvc.RequestedSize)
+		for _, pp := range protection[pvc.Namespace].PVCs {
+			if pp.Name == pvc.Name && len(pp.Tools) > 0 {
+				a.ProtectedPVCs++
+				for _, t := range pp.Tools {
+					tools[t] = true
+				}
+			}
+		}
+	}
+	a.PVCSizeGB = float64(int(a.PVCSizeGB*10+0.5)) / 10
+
+	covered, unknownRPO := 0, false
+	a.RPOHours = -1
+	for _, ns := range a.Namespaces {
+		np := protection[ns]
+		if len(np.Tools) == 0 {
+			unknownRPO = true
+			continue
+		}
+		covered++
+		if a.PVCs == 0 {
+			for _, t := range np.Tools {
+				tools[t] = true
+			}
+		}
+		if np.RPOHours < 0 {
+			unknownRPO = true
+		} else if np.RPOHours > a.RPOHours {
+			a.RPOHours = np.RPOHours
+		}
+	}
+	if unknownRPO {
+		a.RPOHours = -1
+	}
+	have, want := covered, len(a.Namespaces)
+	if a.PVCs > 0 {
+		have, want = a.ProtectedPVCs, a.PVCs
+	}
+	switch {
+	case want > 0 && have == want:
+		a.Coverage = "full"
+	case have > 0:
+		a.Coverage = "partial"
+	default:
+		a.Coverage = "none"
+	}
+	for t := range tools {
+		a.Tools = append(a.Tools, t)
+	}
+	sort.Strings(a.Tools)
+
+	a.matchFindings(inv.Findings, podOwners)
+}
+
+// matchFindings records the active findings that name one of the app's
+// resources, one of its namespaces, or a pod of one of its workloads.
+func (a *app) matchFindings(findings []model.Finding, podOwners map[model.ResourceRef]model.ResourceRef) {
+	seen := map[string]bool{}
+	for _, f := range findings {
+		refs := f.Resources
+		if len(refs) == 0 {
+			if ns, name, ok := strings.Cut(f.ResourceID, "/"); ok && !strings.Contains(ns, ":") {
+				refs = []model.ResourceRef{{Namespace: ns, Name: name}}
+			}
+		}
+		for _, r := range refs {
+			if !a.affectedBy(r, podOwners) {
+				continue
+			}
+			if !seen[f.ID] {
+				seen[f.ID] = true
+				a.Findings = append(a.Findings, f.ID)
+			}
+			if severityRank[f.Severity] > severityRank[a.WorstSeverity] {
+				a.WorstSeverity = f.Severity
+			}
+			break
+		}
+	}
+	sort.Strings(a.Findings)
+}
+
+func (a *app) affectedBy(r model.ResourceRef, podOwners map[model.ResourceRef]model.ResourceRef) bool {
+	switch r.Kind {
+	case "Namespace":
+		return a.namespaces[r.Name]
+	case "Pod":
+		if owner, ok := podOwners[r]; ok {
+			return a.resources[owner]
+		}
+		// A pod that was not collected: match the name its workload
+		// controller generates.
+		for res := range a.resources {
+			if res.Namespace != r.Namespace || !strings.HasPrefix(r.Name, res.Name+"-") {
+				continue
+			}
+			if shape := podSuffix[res.Kind]; shape != nil && shape.MatchString(strings.TrimPrefix(r.Name, res.Name+"-")) {
+				return true
+			}
+		}
+		return false
+	case "":
+		// A bare "namespace/name" resource ID: any kind.
+		for res := range a.resources {
+			if res.Namespace == r.Namespace && res.Name == r.Name {
+				return true
+			}
+		}
+		return false
+	}
+	return a.resources[r]
+}
+
+// podSuffix matches what each workload controller appends to its name when
+// naming pods: "<hash>-<random>" for a Deployment's ReplicaSet, "<random>"
+// for a DaemonSet and "<ordinal>" for a StatefulSet.
+var podSuffix = map[string]*regexp.Regexp{
+	"Deployment":  regexp.MustCompile(`^[a-z0-9]{1,10}-[a-z0-9]{5}$`),
+	"DaemonSet":   regexp.MustCompile(`^[a-z0-9]{5}$`),
+	"StatefulSet": regexp.MustCompile(`^[0-9]+$`),
+}
+
+var severityRank = map[string]int{"INFO": 1, "LOW": 2, "MEDIUM": 3, "HIGH": 4, "CRITICAL": 5}
@@ -0,0 +1,123 @@
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestGroup(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	inv := &b.Inventory
+	inv.Deployments = []model.Deployment{
+		{Namespace: "shop", Name: "web", WorkloadRefs: model.WorkloadRefs{
+			PodLabels:   map[string]string{"app": "web"},
+			AppLabels:   map[string]string{"app.kubernetes.io/part-of": "shop"},
+			ConfigMaps:  []string{"web-config"},
+			HelmRelease: "web",
+		}},
+		{Namespace: "payments", Name: "api", WorkloadRefs: model.WorkloadRefs{
+			PodLabels: map[string]string{"app.kubernetes.io/part-of": "shop"},
+		}},
+		{Namespace: "tools", Name: "debug"},
+		{Namespace: "tools", Name: "debug-proxy"},
+		{Namespace: "kube-system", Name: "coredns"},
+	}
+	inv.StatefulSets = []model.StatefulSet{{
+		Namespace:      "shop",
+		Name:           "db",
+		ClaimTemplates: []string{"data"},
+		WorkloadRefs: model.WorkloadRefs{
+			Secrets: []string{"db-creds"},
+			Owners:  []model.OwnerRef{{APIVersion: "example.com/v1", Kind: "Database", Name: "orders"}},
+		},
+	}}
+	inv.PVCs = []model.PersistentVolumeClaim{
+		{Namespace: "shop", Name: "data-db-0", RequestedSize: "10Gi"},
+		{Namespace: "shop", Name: "data-db-1", RequestedSize: "10Gi"},
+		{Namespace: "shop", Name: "uploads", RequestedSize: "512Mi", HelmRelease: "web"},
+	}
+	inv.Services = []model.Service{{Namespace: "shop", Name: "web", Selector: map[string]string{"app": "web"}}}
+	inv.Ingresses = []model.Ingress{{Namespace: "shop", Name: "web", Rules: []model.IngressRule{{Backend: "web:80"}}}}
+	inv.Backup.Protection = []model.NamespaceProtection{{
+		Namespace: "shop",
+		Tools:     []string{"velero"},
+		RPOHours:  24,
+		PVCs: []model.PVCProtection{
+			{Name: "data-db-0", Tools: []string{"velero"}},
+			{Name: "data-db-1", Tools: []string{"velero"}},
+		},
+	}}
+	inv.Findings = []model.Finding{
+		{ID: "DR_NO_PDB", Severity: "MEDIUM", Resources: []model.ResourceRef{{Kind: "StatefulSet", Namespace: "shop", Name: "db"}}},
+		{ID: "DR_POD_RESTARTS", Severity: "HIGH", Resources: []model.ResourceRef{{Kind: "Pod", Namespace: "tools", Name: "debug-7c9f-abcde"}}},
+		{ID: "DR_NS_UNCOVERED", Severity: "LOW", Resources: []model.ResourceRef{{Kind: "Namespace", Name: "payments"}}},
+		// Pods of debug-proxy, not debug, though their names start with "debug-".
+		{ID: "POD_PRIVILEGED", Severity: "CRITICAL", Resources: []model.ResourceRef{
+			{Kind: "Pod", Namespace: "tools", Name: "debug-proxy-6b8d5-x2x4q"},
+			{Kind: "Pod", Namespace: "tools", Name: "debug-proxy-zx9kq"},
+		}},
+	}
+	inv.Pods = []model.Pod{{Namespace: "tools", Name: "debug-proxy-zx9kq", OwnerKind: "Deployment", OwnerName: "debug-proxy"}}
+
+	got := map[string]model.Application{}
+	for _, a := range Group(&b) {
+		got[a.Name] = a
+	}
+	if len(got) != 4 {
+		t.Fatalf("got %d applications %v; want shop, orders, debug and debug-proxy", len(got), got)
+	}
+
+	shop := got["shop"]
+	if shop.Source != "part-of" || len(shop.Namespaces) != 2 {
+		t.Errorf("shop = %s across %v; want part-of across payments and shop", shop.Source, shop.Namespaces)
+	}
+	has := func(a model.Application, kind, ns, name string) bool {
+		for _, r := range a.Resources {
+			if r == (model.ResourceRef{Kind: kind, Namespace: ns, Name: name}) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, r := range [][3]string{
+		{"Deployment", "shop", "web"},
+		{"Deployment", "payments", "api"},
+		{"ConfigMap", "shop", "web-config"},
+		{"PersistentVolumeClaim", "shop", "uploads"},
+		{"Service", "shop", "web"},
+		{"Ingress", "shop", "web"},
+	} {
+		if !has(shop, r[0], r[1], r[2]) {
+			t.Errorf("shop is missing %s %s/%s", r[0], r[1], r[2])
+		}
+	}
+	// Its only PVC, uploads, is unprotected, and payments has no policy.
+	if shop.Coverage != "none" || shop.PVCs != 1 || shop.RPOHours != -1 {
+		t.Errorf("shop = %s coverage, %d PVCs, RPO %d; want none, 1, -1", shop.Coverage, shop.PVCs, shop.RPOHours)
+	}
+	if len(shop.Findings) != 1 || shop.Findings[0] != "DR_NS_UNCOVERED" {
+		t.Errorf("shop findings = %v; want DR_NS_UNCOVERED", shop.Findings)
+	}
+
+	orders := got["orders"]
+	if orders.Source != "owner" || !has(orders, "Database", "shop", "orders") || !has(orders, "Secret", "shop", "db-creds") {
+		t.Errorf("orders = %+v; want the Database owner and its secret", orders)
+	}
+	if orders.PVCs != 2 || orders.PVCSizeGB != 20 || orders.Coverage != "full" || orders.RPOHours != 24 {
+		t.Errorf("orders = %d PVCs, %.1f GB, %s, RPO %d; want 2, 20, full, 24",
+			orders.PVCs, orders.PVCSizeGB, orders.Coverage, orders.RPOHours)
+	}
+	if orders.WorstSeverity != "MEDIUM" {
+		t.Errorf("orders worst severity = %q; want MEDIUM", orders.WorstSeverity)
+	}
+
+	debug := got["debug"]
+	if debug.Source != "workload" || debug.Coverage != "none" || debug.WorstSeverity != "HIGH" {
+		t.Errorf("debug = %+v; want an uncovered workload app with the pod's HIGH finding", debug)
+	}
+	if proxy := got["debug-proxy"]; proxy.WorstSeverity != "CRITICAL" || len(proxy.Findings) != 1 {
+		t.Errorf("debug-proxy = %+v; want its pods' CRITICAL finding", proxy)
+	}
+}
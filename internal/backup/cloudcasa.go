@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"context"
+
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var cloudcasaSpec = Spec{
+	Name:          "cloudcasa",
+	Namespaces:    []string{"cloudcasa-io"},
+	CRDGroups:     []string{"cloudcasa.io"},
+	PodLabelKey:   "app",
+	PodLabelValue: "cloudcasa",
+}
+
+// cloudcasaProvider reads the Velero Schedules synced to the agent namespace,
+// and the Backups and Restores that Velero ran there.
+type cloudcasaProvider struct{ specProvider }
+
+func (cloudcasaProvider) Policies(ctx context.Context, env Env, tool model.BackupDetectedTool) []model.BackupPolicy {
+	return cloudcasaPolicies(ctx, env.Dynamic, tool.Namespace)
+}
+
+func (cloudcasaProvider) LastBackups(ctx context.Context, env Env, policies []model.BackupPolicy) *model.BackupEvidence {
+	return veleroEvidence(ctx, env.Dynamic, env.Bundle, policies, "cloudcasa", func(ns string) bool {
+		return veleroDistributions[ns] == "cloudcasa"
+	})
+}
+
+// cloudcasaPolicies returns the policies visible in-cluster for CloudCasa.
+// CloudCasa keeps its backup policies in the SaaS control plane; the agent
+// runs backups through the Velero it bundles, so the schedules it has synced
+// to the cluster are the velero.io Schedules in the agent namespace. They are
+// reported under the cloudcasa tool name. When none are synced the tool has
+// no in-cluster policies to read.
+func cloudcasaPolicies(ctx context.Context, dc dynamic.Interface, agentNS string) []model.BackupPolicy {
+	if agentNS == "" {
+		agentNS = "cloudcasa-io"
+	}
+	var policies []model.BackupPolicy
+	for _, p := range veleroSchedules(ctx, dc) {
+		if p.PolicyNamespace != agentNS {
+			continue
+		}
+		p.Tool = "cloudcasa"
+		policies = append(policies, p)
+	}
+	return policies
+}
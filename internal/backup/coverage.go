@@ -0,0 +1,88 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// protection merges the policies of every detected tool per scanned
+// namespace, recording which tools and policies protect it, the best RPO
+// among them, whether any writes offsite, and which tools protect each PVC
+// (as decided by each tool's Provider.Coverage). A volume-level tool only
+// protects a namespace when it protects at least one of its PVCs.
+func protection(b *model.Bundle, policies []model.BackupPolicy) []model.NamespaceProtection {
+	provisioner := map[string]string{}
+	for _, sc := range b.Inventory.StorageClasses {
+		provisioner[sc.Name] = sc.Provisioner
+	}
+	pvcsByNS := map[string][]model.PersistentVolumeClaim{}
+	for _, pvc := range b.Inventory.PVCs {
+		pvcsByNS[pvc.Namespace] = append(pvcsByNS[pvc.Namespace], pvc)
+	}
+
+	out := make([]model.NamespaceProtection, 0, len(b.Inventory.Namespaces))
+	for _, ns := range b.Inventory.Namespaces {
+		np := model.NamespaceProtection{Namespace: ns.Name, RPOHours: -1}
+		pvcTools := make([][]string, len(pvcsByNS[ns.Name]))
+		for _, p := range policies {
+			if !p.Covers(ns.Name) {
+				continue
+			}
+			prov := lookup(p.Tool)
+			protects := false
+			for i, pvc := range pvcsByNS[ns.Name] {
+				if prov == nil || prov.Coverage(p, pvc, provisioner[pvc.StorageClass]) {
+					pvcTools[i] = appendUnique(pvcTools[i], p.Tool)
+					protects = true
+				}
+			}
+			if !protects && volumeLevel(p.Tool) {
+				continue
+			}
+			np.Tools = appendUnique(np.Tools, p.Tool)
+			np.Policies = append(np.Policies, fmt.Sprintf("%s/%s", p.Tool, p.Name))
+			if p.RPOHours >= 0 && (np.RPOHours < 0 || p.RPOHours < np.RPOHours) {
+				np.RPOHours = p.RPOHours
+			}
+			np.HasOffsite = np.HasOffsite || p.HasOffsite
+		}
+		for i, pvc := range pvcsByNS[ns.Name] {
+			np.PVCs = append(np.PVCs, model.PVCProtection{Name: pvc.Name, Tools: pvcTools[i]})
+		}
+		out = append(out, np)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Namespace < out[j].Namespace })
+	return out
+}
+
+// uncoveredStatefulNamespaces lists namespaces with StatefulSets that no
+// tool protects.
+func uncoveredStatefulNamespaces(b *model.Bundle, covered []string) []string {
+	coveredSet := map[string]struct{}{}
+	for _, ns := range covered {
+		coveredSet[ns] = struct{}{}
+	}
+	seen := map[string]struct{}{}
+	var uncovered []string
+	for _, sts := range b.Inventory.StatefulSets {
+		if _, ok := coveredSet[sts.Namespace]; !ok {
+			if _, already := seen[sts.Namespace]; !already {
+				uncovered = append(uncovered, sts.Namespace)
+				seen[sts.Namespace] = struct{}{}
+			}
+		}
+	}
+	sort.Strings(uncovered)
+	return uncovered
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,49 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestProtectionMergesTools(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Namespaces = []model.Namespace{{Name: "db"}, {Name: "web"}}
+	b.Inventory.StorageClasses = []model.StorageClass{
+		{Name: "longhorn", Provisioner: "driver.longhorn.io"},
+		{Name: "gp3", Provisioner: "ebs.csi.aws.com"},
+	}
+	b.Inventory.PVCs = []model.PersistentVolumeClaim{
+		{Namespace: "db", Name: "data-0", StorageClass: "longhorn"},
+		{Namespace: "web", Name: "uploads", StorageClass: "gp3"},
+	}
+	policies := []model.BackupPolicy{
+		{Tool: "longhorn", Name: "weekly-backup", RPOHours: 168, HasOffsite: true},
+		{Tool: "velero", Name: "db-hourly", IncludedNS: []string{"db"}, RPOHours: 1},
+	}
+
+	got := protection(&b, policies)
+	if len(got) != 2 {
+		t.Fatalf("got %d namespaces, want 2", len(got))
+	}
+	db, web := got[0], got[1]
+	if !reflect.DeepEqual(db.Tools, []string{"longhorn", "velero"}) || db.RPOHours != 1 || !db.HasOffsite {
+		t.Errorf("db = %+v, want longhorn+velero, best RPO 1h, offsite via longhorn", db)
+	}
+	if !reflect.DeepEqual(db.PVCs[0].Tools, []string{"longhorn", "velero"}) {
+		t.Errorf("db/data-0 tools = %v, want longhorn and velero", db.PVCs[0].Tools)
+	}
+	if len(web.Tools) != 0 || web.RPOHours != -1 || web.HasOffsite {
+		t.Errorf("web = %+v, want unprotected: longhorn protects none of its PVCs", web)
+	}
+	if len(web.PVCs[0].Tools) != 0 {
+		t.Errorf("web/uploads is on gp3; longhorn cannot protect it, got %v", web.PVCs[0].Tools)
+	}
+
+	b.Inventory.StatefulSets = []model.StatefulSet{{Namespace: "db", Name: "pg"}, {Namespace: "web", Name: "cms"}}
+	if got := uncoveredStatefulNamespaces(&b, []string{"db"}); !reflect.DeepEqual(got, []string{"web"}) {
+		t.Errorf("uncovered stateful namespaces = %v, want [web]", got)
+	}
+}
@@ -0,0 +1,397 @@
+package backup
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // CRON_TZ zones resolve in minimal images without zoneinfo
+)
+
+// ── RPO from schedules ─────────────────────────────────────────────────────
+
+// scheduleRPOHours returns the worst-case RPO of a schedule: the longest gap
+// between two consecutive runs, in hours rounded up. It accepts 5-field cron
+// (6 fields with leading seconds are also accepted), @descriptors, @every
+// durations and a CRON_TZ=/TZ= prefix; anything else is matched against
+// frequency labels ("hourly", "daily", ...). Returns -1 when the schedule
+// cannot be parsed or never runs.
+func scheduleRPOHours(schedule string) int {
+	gap, err := cronMaxGap(schedule)
+	if err != nil {
+		return frequencyLabelHours(schedule)
+	}
+	return int(math.Ceil(gap.Hours()))
+}
+
+// frequencyLabelHours maps free-form frequency labels, such as Rubrik SLA
+// frequencies, to hours. Returns -1 when no label matches.
+func frequencyLabelHours(label string) int {
+	label = strings.ToLower(label)
+	switch {
+	case strings.Contains(label, "hourly"):
+		return 1
+	case strings.Contains(label, "daily"):
+		return 24
+	case strings.Contains(label, "weekly"):
+		return 168
+	case strings.Contains(label, "monthly"):
+		return 31 * 24
+	case strings.Contains(label, "yearly"), strings.Contains(label, "annually"):
+		return 366 * 24
+	}
+	return -1
+}
+
+// cronDescriptors are the predefined schedules shared by cron, Velero and
+// Kasten policy frequencies.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronMaxGap parses schedule and returns the longest interval between two
+// consecutive runs over a rolling window of cronWindowYears, evaluated in the
+// schedule's time zone so DST transitions lengthen or shorten gaps as they
+// would in practice.
+func cronMaxGap(schedule string) (time.Duration, error) {
+	s, err := parseCron(schedule)
+	if err != nil {
+		return 0, err
+	}
+	if s.every > 0 {
+		return s.every, nil
+	}
+	gap, ok := s.maxGap()
+	if !ok {
+		return 0, fmt.Errorf("cron %q: fewer than two runs in %d years", schedule, cronWindowYears)
+	}
+	return gap, nil
+}
+
+// NextRun returns the first run of the cron schedule after after. It accepts
+// the same expressions as scheduleRPOHours except frequency labels; @every
+// runs are counted from after. The operator uses it to schedule DRScans.
+func NextRun(schedule string, after time.Time) (time.Time, error) {
+	s, err := parseCron(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s.every > 0 {
+		return after.Add(s.every), nil
+	}
+	local := after.In(s.loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.loc)
+	for end := day.AddDate(cronWindowYears, 0, 0); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !s.dayMatches(day) {
+			continue
+		}
+		y, m, d := day.Date()
+		for _, t := range s.runsOn(y, m, d) {
+			if t.After(after) {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("cron %q: no run in %d years", schedule, cronWindowYears)
+}
+
+// cronWindowYears spans a leap-day-only schedule ("0 0 29 2 *") twice.
+const cronWindowYears = 5
+
+// cronWindowStart is fixed so results do not depend on when the scan ran.
+var cronWindowStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// cronSchedule is a parsed cron expression. Each field is a bitset of the
+// values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+	loc                           *time.Location
+	every                         time.Duration // @every; the other fields are unused
+}
+
+// cronField describes one field. Values min..max are accepted; "*" and
+// open-ended steps ("a/n") stop at top.
+type cronField struct {
+	name          string
+	min, max, top int
+	names         map[string]int
+}
+
+var (
+	cronSecond = cronField{"second", 0, 59, 59, nil}
+	cronMinute = cronField{"minute", 0, 59, 59, nil}
+	cronHour   = cronField{"hour", 0, 23, 23, nil}
+	cronDOM    = cronField{"day of month", 1, 31, 31, nil}
+	cronMonth  = cronField{"month", 1, 12, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 7 as Sunday; it is folded onto 0 after parsing.
+	cronDOW = cronField{"day of week", 0, 7, 6, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// parseCron parses a cron expression with an optional CRON_TZ=/TZ= prefix.
+func parseCron(spec string) (*cronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	s := &cronSchedule{loc: time.UTC}
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		tz, rest, _ := strings.Cut(spec, " ")
+		_, name, _ := strings.Cut(tz, "=")
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %w", spec, err)
+		}
+		s.loc = loc
+		spec = strings.TrimSpace(rest)
+	}
+	lower := strings.ToLower(spec)
+	if d, ok := strings.CutPrefix(lower, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("cron %q: bad @every duration", spec)
+		}
+		s.every = every
+		return s, nil
+	}
+	if expr, ok := cronDescriptors[lower]; ok {
+		spec = expr
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+	case 6:
+		// Leading seconds field: validate it, but it cannot widen a gap.
+		if _, _, err := parseCronField(fields[0], cronSecond); err != nil {
+			return nil, fmt.Errorf("cron %q: %w", spec, err)
+		}
+		fields = fields[1:]
+	default:
+		return nil, fmt.Errorf("cron %q: want 5 fields, got %d", spec, len(fields))
+	}
+
+	var err error
+	if s.minute, _, err = parseCronField(fields[0], cronMinute); err == nil {
+		if s.hour, _, err = parseCronField(fields[1], cronHour); err == nil {
+			if s.dom, s.domStar, err = parseCronField(fields[2], cronDOM); err == nil {
+				if s.month, _, err = parseCronField(fields[3], cronMonth); err == nil {
+					s.dow, s.dowStar, err = parseCronField(fields[4], cronDOW)
+				}
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cron %q: %w", spec, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// parseCronField parses a comma list of "*", "?", "a", "a-b", each with an
+// optional "/step". star reports whether the field was unrestricted, which
+// decides how day of month and day of week combine.
+func parseCronField(text string, f cronField) (set uint64, star bool, err error) {
+	for _, part := range strings.Split(text, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		lo, hi := f.min, f.top
+		switch rng {
+		case "*", "?":
+			star = !hasStep
+		default:
+			a, b, isRange := strings.Cut(rng, "-")
+			if lo, err = cronValue(a, f); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = cronValue(b, f); err != nil {
+					return 0, false, err
+				}
+			} else if hasStep {
+				hi = f.top // "a/n" means a, a+n, ... to the end of the range
+			}
+			if hi < lo {
+				return 0, false, fmt.Errorf("%s range %q is backwards", f.name, rng)
+			}
+		}
+		step := 1
+		if hasStep {
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("%s step %q", f.name, stepText)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, star, nil
+}
+
+func cronValue(text string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToLower(text)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s value %q", f.name, text)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// dayMatches applies cron's day rule: when both day of month and day of
+// week are restricted, a day matching either runs.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domStar:
+		return dow
+	case s.dowStar:
+		return dom
+	}
+	return dom || dow
+}
+
+// runsOn returns the runs on day (a local midnight). Wall-clock times that
+// do not exist on a DST change are skipped, as Velero's cron library does.
+func (s *cronSchedule) runsOn(y int, m time.Month, d int) []time.Time {
+	var out []time.Time
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for min := 0; min < 60; min++ {
+			if s.minute&(1<<uint(min)) == 0 {
+				continue
+			}
+			t := time.Date(y, m, d, h, min, 0, 0, s.loc)
+			if t.Hour() == h && t.Minute() == min {
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// maxGap walks the window day by day. Within a day without a DST change the
+// gaps between runs are the same every day, so only the first and last run
+// are placed and the intra-day gap is computed once; DST days are expanded
+// run by run. ok is false when the schedule runs fewer than twice.
+func (s *cronSchedule) maxGap() (gap time.Duration, ok bool) {
+	if s.minute == 0 || s.hour == 0 {
+		return 0, false
+	}
+	firstH, lastH := bits.TrailingZeros64(s.hour), 63-bits.LeadingZeros64(s.hour)
+	firstM, lastM := bits.TrailingZeros64(s.minute), 63-bits.LeadingZeros64(s.minute)
+	var intra time.Duration
+	prevMin := -1
+	for h := firstH; h <= lastH; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := firstM; m <= lastM; m++ {
+			if s.minute&(1<<uint(m)) == 0 {
+				continue
+			}
+			cur := h*60 + m
+			if prevMin >= 0 && time.Duration(cur-prevMin)*time.Minute > intra {
+				intra = time.Duration(cur-prevMin) * time.Minute
+			}
+			prevMin = cur
+		}
+	}
+	perDay := bits.OnesCount64(s.hour) * bits.OnesCount64(s.minute)
+
+	start := time.Date(cronWindowStart.Year(), 1, 1, 0, 0, 0, 0, s.loc)
+	end := start.AddDate(cronWindowYears, 0, 0)
+	var last time.Time
+	runs := 0
+	add := func(t time.Time) {
+		if runs > 0 && t.Sub(last) > gap {
+			gap = t.Sub(last)
+		}
+		last = t
+		runs++
+	}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !s.dayMatches(day) {
+			continue
+		}
+		y, m, d := day.Date()
+		_, off0 := day.Zone()
+		_, off1 := day.AddDate(0, 0, 1).Zone()
+		if off0 != off1 {
+			for _, t := range s.runsOn(y, m, d) {
+				add(t)
+			}
+			continue
+		}
+		add(time.Date(y, m, d, firstH, firstM, 0, 0, s.loc))
+		if perDay > 1 {
+			if intra > gap {
+				gap = intra
+			}
+			last = time.Date(y, m, d, lastH, lastM, 0, 0, s.loc)
+			runs += perDay - 1
+		}
+	}
+	return gap, runs >= 2
+}
+
+// kastenCron turns a Kasten policy frequency and its subFrequency into a
+// cron expression: subFrequency pins the minutes, hours, weekdays, days and
+// months within each period (defaults: the start of the period).
+func kastenCron(frequency string, sub kastenSubFrequency) (string, bool) {
+	list := func(vals []int, def string) string {
+		if len(vals) == 0 {
+			return def
+		}
+		parts := make([]string, len(vals))
+		for i, v := range vals {
+			parts[i] = strconv.Itoa(v)
+		}
+		return strings.Join(parts, ",")
+	}
+	minute, hour := list(sub.Minutes, "0"), list(sub.Hours, "0")
+	switch strings.ToLower(strings.TrimSpace(frequency)) {
+	case "@hourly":
+		return minute + " * * * *", true
+	case "@daily":
+		return minute + " " + hour + " * * *", true
+	case "@weekly":
+		return minute + " " + hour + " * * " + list(sub.Weekdays, "0"), true
+	case "@monthly":
+		return minute + " " + hour + " " + list(sub.Days, "1") + " * *", true
+	case "@yearly", "@annually":
+		return minute + " " + hour + " " + list(sub.Days, "1") + " " + list(sub.Months, "1") + " *", true
+	}
+	return "", false
+}
+
+// kastenSubFrequency is config.kio.kasten.io Policy spec.subFrequency.
+type kastenSubFrequency struct {
+	Minutes  []int `json:"minutes"`
+	Hours    []int `json:"hours"`
+	Weekdays []int `json:"weekdays"`
+	Days     []int `json:"days"`
+	Months   []int `json:"months"`
+}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleRPOHours(t *testing.T) {
+	tests := []struct {
+		schedule string
+		want     int
+	}{
+		{"0 2 * * *", 24},
+		{"*/15 * * * *", 1},
+		{"0 */6 * * *", 6},
+		{"0 1-5 * * *", 20},       // 05:00 until 01:00 the next day
+		{"0 2 * * 1-5", 72},       // Friday until Monday
+		{"0 3 * * SUN", 168},      // named weekday
+		{"0 3 * * 7", 168},        // 7 is Sunday
+		{"0 0 1,15 * *", 17 * 24}, // the 15th until the 1st after a 31-day month
+		{"0 0 13 * 5", 7 * 24},    // the 13th OR any Friday
+		{"0 0 1 * *", 31 * 24},    // longest month
+		{"0 0 29 2 *", 1461 * 24}, // leap day only
+		{"0 0 0 * * *", 24},       // leading seconds field
+		{"@daily", 24},
+		{"@every 90m", 2},
+		{"CRON_TZ=America/New_York 30 2 * * *", 47}, // 02:30 does not exist on the spring-forward day; the clock also skips an hour
+		{"TZ=UTC 30 2 * * *", 24},
+		{"Daily", 24}, // frequency label
+		{"0 0 30 2 *", -1},
+		{"61 * * * *", -1},
+		{"not a schedule", -1},
+		{"", -1},
+	}
+	for _, tt := range tests {
+		if got := scheduleRPOHours(tt.schedule); got != tt.want {
+			t.Errorf("scheduleRPOHours(%q) = %d, want %d", tt.schedule, got, tt.want)
+		}
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2026, 3, 7, 10, 20, 0, 0, time.UTC) // a Saturday
+	tests := []struct {
+		schedule string
+		want     time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 3, 7, 10, 30, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 7, 11, 0, 0, 0, time.UTC)},
+		{"0 2 * * 1-5", time.Date(2026, 3, 9, 2, 0, 0, 0, time.UTC)},
+		{"@every 90m", after.Add(90 * time.Minute)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := NextRun(tt.schedule, after)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("NextRun(%q) = %v, %v; want %v", tt.schedule, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"0 0 30 2 *", "hourly"} {
+		if _, err := NextRun(bad, after); err == nil {
+			t.Errorf("NextRun(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestKastenCron(t *testing.T) {
+	cron, ok := kastenCron("@daily", kastenSubFrequency{Hours: []int{0, 12}})
+	if !ok || cron != "0 0,12 * * *" || scheduleRPOHours(cron) != 12 {
+		t.Errorf("kastenCron(@daily, hours 0,12) = %q (%v), want 12h RPO", cron, ok)
+	}
+	if _, ok := kastenCron("@onDemand", kastenSubFrequency{}); ok {
+		t.Error("kastenCron accepted an on-demand policy")
+	}
+}
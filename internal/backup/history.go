@@ -0,0 +1,106 @@
+package backup
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// policyRun is one finished execution of a backup policy or schedule.
+type policyRun struct {
+	policy string
+	state  string // the tool's phase/state, verbatim
+	ok     bool
+	at     time.Time
+}
+
+// applyRunHistory sets LastSuccess, LastRunState and FailureStreak on every
+// policy of tool from its finished runs.
+func applyRunHistory(policies []model.BackupPolicy, tool string, runs []policyRun) {
+	byPolicy := map[string][]policyRun{}
+	for _, r := range runs {
+		if r.policy != "" {
+			byPolicy[r.policy] = append(byPolicy[r.policy], r)
+		}
+	}
+	for i := range policies {
+		p := &policies[i]
+		if p.Tool != tool {
+			continue
+		}
+		rs := byPolicy[p.Name]
+		sort.Slice(rs, func(a, b int) bool { return rs[a].at.After(rs[b].at) })
+		for j, r := range rs {
+			if j == 0 {
+				p.LastRunState = r.state
+			}
+			if r.ok {
+				t := r.at
+				p.LastSuccess = &t
+				break
+			}
+			p.FailureStreak++
+		}
+	}
+}
+
+// mergeEvidence combines evidence from two tools. A namespace backed up by
+// both keeps whichever backup is more recent.
+func mergeEvidence(a, b *model.BackupEvidence) *model.BackupEvidence {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	out := *a
+	out.Tools = append(append([]string(nil), a.Tools...), b.Tools...)
+	out.Backups += b.Backups
+	out.Completed += b.Completed
+	out.Failed += b.Failed
+	out.PartiallyFailed += b.PartiallyFailed
+	out.FailedBackups = append(append([]model.ResourceRef(nil), a.FailedBackups...), b.FailedBackups...)
+	out.LastSuccess = later(a.LastSuccess, b.LastSuccess)
+	out.Restores += b.Restores
+	out.RestoresCompleted += b.RestoresCompleted
+	out.LastRestore = later(a.LastRestore, b.LastRestore)
+	out.RestorePoints = append(append([]model.AppRestorePoints(nil), a.RestorePoints...), b.RestorePoints...)
+
+	byNS := map[string]model.NamespaceBackup{}
+	for _, nb := range append(append([]model.NamespaceBackup(nil), a.Namespaces...), b.Namespaces...) {
+		if cur, ok := byNS[nb.Namespace]; !ok || nb.LastSuccess.After(cur.LastSuccess) {
+			byNS[nb.Namespace] = nb
+		}
+	}
+	out.Namespaces = out.Namespaces[:0:0]
+	for _, nb := range byNS {
+		out.Namespaces = append(out.Namespaces, nb)
+	}
+	sort.Slice(out.Namespaces, func(i, j int) bool { return out.Namespaces[i].Namespace < out.Namespaces[j].Namespace })
+	return &out
+}
+
+func later(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
+
+// namespaceBackups returns the latest successful backup of each namespace,
+// sorted by namespace, with AgeHours measured from the start of the scan.
+func namespaceBackups(b *model.Bundle, last map[string]model.NamespaceBackup) []model.NamespaceBackup {
+	now := b.Scan.StartedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var out []model.NamespaceBackup
+	for _, nb := range last {
+		nb.AgeHours = math.Round(now.Sub(nb.LastSuccess).Hours()*10) / 10
+		out = append(out, nb)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Namespace < out[j].Namespace })
+	return out
+}
@@ -0,0 +1,334 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var (
+	gvrKastenPolicy        = schema.GroupVersionResource{Group: "config.kio.kasten.io", Version: "v1alpha1", Resource: "policies"}
+	gvrKastenRunAction     = schema.GroupVersionResource{Group: "actions.kio.kasten.io", Version: "v1alpha1", Resource: "runactions"}
+	gvrKastenBackupAction  = schema.GroupVersionResource{Group: "actions.kio.kasten.io", Version: "v1alpha1", Resource: "backupactions"}
+	gvrKastenExportAction  = schema.GroupVersionResource{Group: "actions.kio.kasten.io", Version: "v1alpha1", Resource: "exportactions"}
+	gvrKastenRestoreAction = schema.GroupVersionResource{Group: "actions.kio.kasten.io", Version: "v1alpha1", Resource: "restoreactions"}
+	gvrKastenRestorePoint  = schema.GroupVersionResource{Group: "apps.kio.kasten.io", Version: "v1alpha1", Resource: "restorepoints"}
+)
+
+var kastenSpec = Spec{
+	Name:          "kasten",
+	Namespaces:    []string{"kasten-io"},
+	CRDGroups:     []string{"kio.kasten.io", "config.kio.kasten.io"},
+	PodLabelKey:   "app",
+	PodLabelValue: "k10",
+}
+
+// kastenProvider reads Policies, plus actions and RestorePoints as evidence.
+type kastenProvider struct{ specProvider }
+
+func (kastenProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return kastenPolicies(ctx, env.Dynamic)
+}
+
+func (kastenProvider) LastBackups(ctx context.Context, env Env, policies []model.BackupPolicy) *model.BackupEvidence {
+	return kastenEvidence(ctx, env.Dynamic, env.Bundle, policies)
+}
+
+// kastenPolicies reads config.kio.kasten.io/v1alpha1 Policy objects.
+func kastenPolicies(ctx context.Context, dc dynamic.Interface) []model.BackupPolicy {
+	raw, err := listRaw(ctx, dc, gvrKastenPolicy, "")
+	if err != nil {
+		return nil
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Frequency    string             `json:"frequency"`
+				SubFrequency kastenSubFrequency `json:"subFrequency"`
+				Selector     struct {
+					MatchNamespaces []string `json:"matchNamespaces"`
+				} `json:"selector"`
+				Actions []struct {
+					Action string `json:"action"`
+				} `json:"actions"`
+				RetentionDays int `json:"retentionDays"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+
+	var policies []model.BackupPolicy
+	for _, item := range list.Items {
+		hasExport := false
+		for _, a := range item.Spec.Actions {
+			if strings.EqualFold(a.Action, "export") {
+				hasExport = true
+			}
+		}
+		retention := ""
+		if item.Spec.RetentionDays > 0 {
+			retention = strconv.Itoa(item.Spec.RetentionDays) + "d"
+		}
+		p := model.BackupPolicy{
+			Tool:            "kasten",
+			Name:            item.Metadata.Name,
+			PolicyNamespace: item.Metadata.Namespace,
+			IncludedNS:      item.Spec.Selector.MatchNamespaces,
+			Schedule:        item.Spec.Frequency,
+			RetentionTTL:    retention,
+			RPOHours:        scheduleRPOHours(item.Spec.Frequency),
+			HasOffsite:      hasExport,
+		}
+		if cron, ok := kastenCron(item.Spec.Frequency, item.Spec.SubFrequency); ok {
+			p.RPOHours = scheduleRPOHours(cron)
+		}
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// kastenAction holds the fields shared by every actions.kio.kasten.io kind.
+type kastenAction struct {
+	Metadata struct {
+		Name              string            `json:"name"`
+		Namespace         string            `json:"namespace"`
+		Labels            map[string]string `json:"labels"`
+		CreationTimestamp *time.Time        `json:"creationTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		Subject struct {
+			Kind      string `json:"kind"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"subject"`
+		Profile struct {
+			Name string `json:"name"`
+		} `json:"profile"`
+	} `json:"spec"`
+	Status struct {
+		State     string     `json:"state"`
+		StartTime *time.Time `json:"startTime"`
+		EndTime   *time.Time `json:"endTime"`
+	} `json:"status"`
+}
+
+// policy is the K10 policy that created the action, if any.
+func (a kastenAction) policy() string {
+	if p := a.Metadata.Labels["k10.kasten.io/policyName"]; p != "" {
+		return p
+	}
+	if strings.EqualFold(a.Spec.Subject.Kind, "Policy") {
+		return a.Spec.Subject.Name
+	}
+	return ""
+}
+
+// appNamespace is the application namespace a Backup/Export/RestoreAction acted on.
+func (a kastenAction) appNamespace() string {
+	if ns := a.Metadata.Labels["k10.kasten.io/appNamespace"]; ns != "" {
+		return ns
+	}
+	if strings.EqualFold(a.Spec.Subject.Kind, "Namespace") {
+		return a.Spec.Subject.Name
+	}
+	return a.Metadata.Namespace
+}
+
+// at is when the action finished, falling back to when it started or was created.
+func (a kastenAction) at() *time.Time {
+	switch {
+	case a.Status.EndTime != nil:
+		return a.Status.EndTime
+	case a.Status.StartTime != nil:
+		return a.Status.StartTime
+	}
+	return a.Metadata.CreationTimestamp
+}
+
+func listKastenActions(ctx context.Context, dc dynamic.Interface, gvr schema.GroupVersionResource) ([]kastenAction, error) {
+	raw, err := listRaw(ctx, dc, gvr, "")
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Items []kastenAction `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// kastenEvidence reads K10 RunActions, BackupActions, ExportActions,
+// RestoreActions and RestorePoints. RunActions give each policy its run
+// history; BackupActions give the last successful backup per namespace and
+// the failure counts; completed ExportActions record which location profiles
+// really received data, and replace the spec-inferred offsite flag of the
+// policies that have run. Backup and restore actions and RestorePoints only
+// count for scanned namespaces. Returns nil when neither run nor backup
+// actions can be listed.
+func kastenEvidence(ctx context.Context, dc dynamic.Interface, b *model.Bundle, policies []model.BackupPolicy) *model.BackupEvidence {
+	runActions, runErr := listKastenActions(ctx, dc, gvrKastenRunAction)
+	backupActions, backupErr := listKastenActions(ctx, dc, gvrKastenBackupAction)
+	if runErr != nil && backupErr != nil {
+		return nil
+	}
+
+	scanned := map[string]bool{}
+	for _, ns := range b.Inventory.Namespaces {
+		scanned[ns.Name] = true
+	}
+
+	var runs []policyRun
+	for _, a := range runActions {
+		at := a.at()
+		if at == nil {
+			continue
+		}
+		switch a.Status.State {
+		case "Complete", "Failed":
+			runs = append(runs, policyRun{policy: a.policy(), state: a.Status.State, ok: a.Status.State == "Complete", at: *at})
+		}
+	}
+	applyRunHistory(policies, "kasten", runs)
+
+	ev := &model.BackupEvidence{}
+	last := map[string]model.NamespaceBackup{}
+	sort.SliceStable(backupActions, func(i, j int) bool {
+		ti, tj := backupActions[i].at(), backupActions[j].at()
+		return ti != nil && (tj == nil || ti.After(*tj))
+	})
+	for _, a := range backupActions {
+		ns := a.appNamespace()
+		if !scanned[ns] {
+			continue
+		}
+		ev.Backups++
+		at := a.at()
+		switch a.Status.State {
+		case "Complete":
+			ev.Completed++
+			if at == nil {
+				continue
+			}
+			if ev.LastSuccess == nil || at.After(*ev.LastSuccess) {
+				t := *at
+				ev.LastSuccess = &t
+			}
+			if cur, ok := last[ns]; !ok || at.After(cur.LastSuccess) {
+				last[ns] = model.NamespaceBackup{Namespace: ns, Tool: "kasten", Backup: a.Metadata.Name, LastSuccess: *at}
+			}
+		case "Failed":
+			ev.Failed++
+			ev.FailedBackups = append(ev.FailedBackups, model.ResourceRef{Kind: "BackupAction", Namespace: a.Metadata.Namespace, Name: a.Metadata.Name})
+		}
+	}
+	ev.Namespaces = namespaceBackups(b, last)
+
+	if exports, err := listKastenActions(ctx, dc, gvrKastenExportAction); err == nil {
+		profiles := map[string]map[string]bool{}
+		for _, a := range exports {
+			if a.Status.State != "Complete" || a.Spec.Profile.Name == "" {
+				continue
+			}
+			p := a.policy()
+			if profiles[p] == nil {
+				profiles[p] = map[string]bool{}
+			}
+			profiles[p][a.Spec.Profile.Name] = true
+		}
+		for i := range policies {
+			p := &policies[i]
+			if p.Tool != "kasten" || p.LastRunState == "" {
+				continue // a policy that never ran has nothing to export yet
+			}
+			for name := range profiles[p.Name] {
+				p.ExportProfiles = append(p.ExportProfiles, name)
+			}
+			sort.Strings(p.ExportProfiles)
+			p.HasOffsite = len(p.ExportProfiles) > 0
+		}
+	}
+
+	if restores, err := listKastenActions(ctx, dc, gvrKastenRestoreAction); err == nil {
+		for _, a := range restores {
+			if !scanned[a.appNamespace()] {
+				continue
+			}
+			ev.Restores++
+			if a.Status.State != "Complete" {
+				continue
+			}
+			ev.RestoresCompleted++
+			if at := a.at(); at != nil && (ev.LastRestore == nil || at.After(*ev.LastRestore)) {
+				t := *at
+				ev.LastRestore = &t
+			}
+		}
+	}
+
+	ev.RestorePoints = kastenRestorePoints(ctx, dc, scanned)
+	return ev
+}
+
+// kastenRestorePoints counts apps.kio.kasten.io RestorePoints per application
+// in the scanned namespaces.
+func kastenRestorePoints(ctx context.Context, dc dynamic.Interface, scanned map[string]bool) []model.AppRestorePoints {
+	raw, err := listRaw(ctx, dc, gvrKastenRestorePoint, "")
+	if err != nil {
+		return nil
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Namespace         string            `json:"namespace"`
+				Labels            map[string]string `json:"labels"`
+				CreationTimestamp *time.Time        `json:"creationTimestamp"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+	byApp := map[string]*model.AppRestorePoints{}
+	for _, rp := range list.Items {
+		if !scanned[rp.Metadata.Namespace] {
+			continue
+		}
+		app := rp.Metadata.Labels["k10.kasten.io/appName"]
+		if app == "" {
+			app = rp.Metadata.Namespace
+		}
+		a := byApp[app]
+		if a == nil {
+			a = &model.AppRestorePoints{App: app}
+			byApp[app] = a
+		}
+		a.Count++
+		if at := rp.Metadata.CreationTimestamp; at != nil && (a.Latest == nil || at.After(*a.Latest)) {
+			t := *at
+			a.Latest = &t
+		}
+	}
+	out := make([]model.AppRestorePoints, 0, len(byApp))
+	for _, a := range byApp {
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].App < out[j].App })
+	return out
+}
@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestKastenEvidence(t *testing.T) {
+	const actions = "actions.kio.kasten.io/v1alpha1"
+	pol := map[string]interface{}{"k10.kasten.io/policyName": "db-daily"}
+	other := map[string]interface{}{"k10.kasten.io/policyName": "web-hourly"}
+	run := func(name, state, end string) *unstructured.Unstructured {
+		return newObj(actions, "RunAction", "", name, pol, map[string]interface{}{},
+			map[string]interface{}{"state": state, "endTime": end})
+	}
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			gvrKastenRunAction: "RunActionList", gvrKastenBackupAction: "BackupActionList",
+			gvrKastenExportAction: "ExportActionList", gvrKastenRestoreAction: "RestoreActionList",
+			gvrKastenRestorePoint: "RestorePointList",
+		},
+		run("run-1", "Complete", "2026-03-01T01:00:00Z"),
+		run("run-2", "Failed", "2026-03-02T01:00:00Z"),
+		run("run-3", "Failed", "2026-03-03T01:00:00Z"),
+		newObj(actions, "RunAction", "", "run-4", other, map[string]interface{}{},
+			map[string]interface{}{"state": "Complete", "endTime": "2026-03-03T01:00:00Z"}),
+		newObj(actions, "BackupAction", "db", "backup-1", pol, map[string]interface{}{},
+			map[string]interface{}{"state": "Complete", "endTime": "2026-03-01T01:00:00Z"}),
+		newObj(actions, "BackupAction", "db", "backup-2", pol, map[string]interface{}{},
+			map[string]interface{}{"state": "Failed", "endTime": "2026-03-02T01:00:00Z"}),
+		newObj(actions, "ExportAction", "kasten-io", "export-1", pol,
+			map[string]interface{}{"profile": map[string]interface{}{"name": "s3-dr"}},
+			map[string]interface{}{"state": "Complete"}),
+		newObj("apps.kio.kasten.io/v1alpha1", "RestorePoint", "db", "rp-1", map[string]interface{}{}, nil, nil),
+		newObj("apps.kio.kasten.io/v1alpha1", "RestorePoint", "db", "rp-2", map[string]interface{}{}, nil, nil),
+		// Another team's namespace, outside the scan.
+		newObj(actions, "BackupAction", "other", "backup-3", other, map[string]interface{}{},
+			map[string]interface{}{"state": "Failed", "endTime": "2026-03-02T01:00:00Z"}),
+		newObj(actions, "RestoreAction", "other", "restore-1", other, map[string]interface{}{},
+			map[string]interface{}{"state": "Complete", "endTime": "2026-03-02T01:00:00Z"}),
+		newObj("apps.kio.kasten.io/v1alpha1", "RestorePoint", "other", "rp-3", map[string]interface{}{}, nil, nil),
+	)
+
+	b := model.NewBundle("test", time.Date(2026, 3, 3, 13, 0, 0, 0, time.UTC))
+	b.Inventory.Namespaces = []model.Namespace{{Name: "db"}}
+	policies := []model.BackupPolicy{
+		{Tool: "kasten", Name: "db-daily", HasOffsite: false},
+		{Tool: "kasten", Name: "web-daily", HasOffsite: true},
+		{Tool: "kasten", Name: "web-hourly", HasOffsite: true},
+	}
+	ev := kastenEvidence(context.Background(), dc, &b, policies)
+	if ev == nil {
+		t.Fatal("kastenEvidence returned nil")
+	}
+
+	p := policies[0]
+	if p.LastSuccess == nil || p.FailureStreak != 2 || p.LastRunState != "Failed" {
+		t.Errorf("db-daily history = last %v, streak %d, state %q; want run-1, 2, Failed", p.LastSuccess, p.FailureStreak, p.LastRunState)
+	}
+	if !p.HasOffsite || len(p.ExportProfiles) != 1 || p.ExportProfiles[0] != "s3-dr" {
+		t.Errorf("db-daily offsite = %v %v, want exported to s3-dr", p.HasOffsite, p.ExportProfiles)
+	}
+	if !policies[1].HasOffsite {
+		t.Error("web-daily never ran; its spec-inferred offsite flag should be kept")
+	}
+	if policies[2].HasOffsite {
+		t.Error("web-hourly ran but never exported; its spec-inferred offsite flag should be cleared")
+	}
+
+	if ev.Completed != 1 || ev.Failed != 1 || len(ev.Namespaces) != 1 || ev.Namespaces[0].AgeHours != 60 {
+		t.Errorf("evidence = %+v, want 1 completed, 1 failed and db backed up 60h ago", ev)
+	}
+	if len(ev.RestorePoints) != 1 || ev.RestorePoints[0].Count != 2 {
+		t.Errorf("RestorePoints = %+v, want 2 for db", ev.RestorePoints)
+	}
+	if ev.RestoresCompleted != 0 {
+		t.Errorf("RestoresCompleted = %d, want 0: the only restore was outside the scan", ev.RestoresCompleted)
+	}
+}
@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var (
+	gvrLonghornJob = schema.GroupVersionResource{Group: "longhorn.io", Version: "v1beta2", Resource: "recurringjobs"}
+	gvrLonghornSet = schema.GroupVersionResource{Group: "longhorn.io", Version: "v1beta2", Resource: "settings"}
+)
+
+var longhornSpec = Spec{
+	Name:          "longhorn",
+	Namespaces:    []string{"longhorn-system"},
+	CRDGroups:     []string{"longhorn.io"},
+	PodLabelKey:   "app",
+	PodLabelValue: "longhorn-manager",
+}
+
+// longhornProvider reads RecurringJobs. Longhorn backs up its own volumes, so
+// a policy only protects PVCs provisioned by the Longhorn CSI driver.
+type longhornProvider struct{ specProvider }
+
+func (longhornProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return longhornRecurringJobs(ctx, env.Dynamic)
+}
+
+func (longhornProvider) VolumeLevel() bool { return true }
+
+func (longhornProvider) Coverage(_ model.BackupPolicy, _ model.PersistentVolumeClaim, provisioner string) bool {
+	return provisioner == "driver.longhorn.io"
+}
+
+// longhornRecurringJobs reads longhorn.io/v1beta2 RecurringJob objects.
+// It also checks whether a BackupTarget is configured (offsite signal).
+func longhornRecurringJobs(ctx context.Context, dc dynamic.Interface) []model.BackupPolicy {
+	// Check BackupTarget setting — non-empty = offsite configured.
+	hasOffsiteTarget := longhornBackupTargetSet(ctx, dc)
+
+	raw, err := listRaw(ctx, dc, gvrLonghornJob, "longhorn-system")
+	if err != nil {
+		// Try v1beta1
+		v1beta1 := gvrLonghornJob
+		v1beta1.Version = "v1beta1"
+		raw, err = listRaw(ctx, dc, v1beta1, "longhorn-system")
+		if err != nil {
+			return nil
+		}
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				Task   string   `json:"task"` // "backup" or "snapshot"
+				Cron   string   `json:"cron"`
+				Retain int      `json:"retain"`
+				Groups []string `json:"groups"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+
+	var policies []model.BackupPolicy
+	for _, item := range list.Items {
+		if !strings.EqualFold(item.Spec.Task, "backup") {
+			continue // skip snapshot-only jobs
+		}
+		retention := ""
+		if item.Spec.Retain > 0 {
+			retention = strconv.Itoa(item.Spec.Retain) + " snapshots"
+		}
+		p := model.BackupPolicy{
+			Tool:         "longhorn",
+			Name:         item.Metadata.Name,
+			Schedule:     item.Spec.Cron,
+			RetentionTTL: retention,
+			RPOHours:     scheduleRPOHours(item.Spec.Cron),
+			HasOffsite:   hasOffsiteTarget,
+		}
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// longhornBackupTargetSet checks if Longhorn has a non-empty BackupTarget setting.
+func longhornBackupTargetSet(ctx context.Context, dc dynamic.Interface) bool {
+	for _, apiVer := range []string{"v1beta2", "v1beta1"} {
+		gvr := gvrLonghornSet
+		gvr.Version = apiVer
+		setting, err := dc.Resource(gvr).Namespace("longhorn-system").Get(ctx, "backup-target", metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		value, _ := setting.Object["value"].(string)
+		return strings.TrimSpace(value) != ""
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var gvrOADPDataProtectionApp = schema.GroupVersionResource{Group: "oadp.openshift.io", Version: "v1alpha1", Resource: "dataprotectionapplications"}
+
+const oadpNamespace = "openshift-adp"
+
+var oadpSpec = Spec{
+	Name:          "oadp",
+	Namespaces:    []string{oadpNamespace},
+	CRDGroups:     []string{"oadp.openshift.io"},
+	PodLabelKey:   "control-plane",
+	PodLabelValue: "controller-manager",
+}
+
+// oadpProvider is OpenShift API for Data Protection: a Velero managed by a
+// DataProtectionApplication. Policies are the velero.io Schedules in the
+// operator namespace; offsite comes from the BackupStorageLocation each
+// Schedule writes to.
+type oadpProvider struct{ specProvider }
+
+func (oadpProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return oadpPolicies(ctx, env.Dynamic, env.Bundle)
+}
+
+func (oadpProvider) LastBackups(ctx context.Context, env Env, policies []model.BackupPolicy) *model.BackupEvidence {
+	return veleroEvidence(ctx, env.Dynamic, env.Bundle, policies, "oadp", func(ns string) bool {
+		return veleroDistributions[ns] == "oadp"
+	})
+}
+
+// oadpPolicies reports the Schedules in openshift-adp under the oadp tool
+// name. A Schedule without a storageLocation writes to the default location.
+func oadpPolicies(ctx context.Context, dc dynamic.Interface, b *model.Bundle) []model.BackupPolicy {
+	locs, def := oadpLocations(ctx, dc, b)
+	var policies []model.BackupPolicy
+	for _, p := range veleroSchedules(ctx, dc) {
+		if p.PolicyNamespace != oadpNamespace {
+			continue
+		}
+		p.Tool = "oadp"
+		if p.StorageLocation == "" {
+			p.StorageLocation = def
+		}
+		p.HasOffsite = locs[p.StorageLocation]
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// oadpLocation is one backup location, from a BackupStorageLocation or a
+// DataProtectionApplication entry.
+type oadpLocation struct {
+	Provider     string
+	Bucket       string
+	S3URL        string
+	Credential   string
+	Default      bool
+	Unavailable  bool
+	CloudStorage bool // a CloudStorage reference; its creationSecret is required
+}
+
+// offsite reports whether the location is object storage outside the
+// cluster that OADP has credentials for.
+func (l oadpLocation) offsite(b *model.Bundle) bool {
+	if l.Unavailable || l.Bucket == "" || inClusterEndpoint(l.S3URL) {
+		return false
+	}
+	if l.CloudStorage {
+		return true
+	}
+	return oadpCredentialsPresent(b, l.Credential, l.Provider)
+}
+
+// oadpLocations maps each backup location name in openshift-adp to whether it
+// is offsite, and returns the default location's name. BackupStorageLocations
+// are read first; when none can be listed (e.g. an offline dump without them)
+// the locations are derived from the DataProtectionApplications, which name
+// them "<dpa>-<n>".
+func oadpLocations(ctx context.Context, dc dynamic.Interface, b *model.Bundle) (map[string]bool, string) {
+	locs := map[string]oadpLocation{}
+	if raw, err := listRaw(ctx, dc, gvrVeleroBSL, oadpNamespace); err == nil {
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name string `json:"name"`
+				} `json:"metadata"`
+				Spec struct {
+					Provider      string `json:"provider"`
+					Default       bool   `json:"default"`
+					ObjectStorage struct {
+						Bucket string `json:"bucket"`
+					} `json:"objectStorage"`
+					Config     map[string]string `json:"config"`
+					Credential struct {
+						Name string `json:"name"`
+					} `json:"credential"`
+				} `json:"spec"`
+				Status struct {
+					Phase string `json:"phase"`
+				} `json:"status"`
+			} `json:"items"`
+		}
+		if json.Unmarshal(raw, &list) == nil {
+			for _, item := range list.Items {
+				locs[item.Metadata.Name] = oadpLocation{
+					Provider:    item.Spec.Provider,
+					Bucket:      item.Spec.ObjectStorage.Bucket,
+					S3URL:       item.Spec.Config["s3Url"],
+					Credential:  item.Spec.Credential.Name,
+					Default:     item.Spec.Default,
+					Unavailable: item.Status.Phase == "Unavailable",
+				}
+			}
+		}
+	}
+	if len(locs) == 0 {
+		locs = oadpDPALocations(ctx, dc)
+	}
+
+	out := map[string]bool{}
+	def := ""
+	for name, l := range locs {
+		out[name] = l.offsite(b)
+		if l.Default && (def == "" || name < def) {
+			def = name
+		}
+	}
+	return out, def
+}
+
+// oadpDPALocations derives backup locations from DataProtectionApplication
+// spec.backupLocations.
+func oadpDPALocations(ctx context.Context, dc dynamic.Interface) map[string]oadpLocation {
+	raw, err := listRaw(ctx, dc, gvrOADPDataProtectionApp, oadpNamespace)
+	if err != nil {
+		return nil
+	}
+	type credential struct {
+		Name string `json:"name"`
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				BackupLocations []struct {
+					Velero *struct {
+						Provider      string `json:"provider"`
+						Default       bool   `json:"default"`
+						ObjectStorage struct {
+							Bucket string `json:"bucket"`
+						} `json:"objectStorage"`
+						Config     map[string]string `json:"config"`
+						Credential credential        `json:"credential"`
+					} `json:"velero"`
+					Bucket *struct {
+						Name       string            `json:"name"`
+						Default    bool              `json:"default"`
+						Config     map[string]string `json:"config"`
+						Credential credential        `json:"credential"`
+					} `json:"bucket"`
+				} `json:"backupLocations"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+	locs := map[string]oadpLocation{}
+	for _, dpa := range list.Items {
+		for i, bl := range dpa.Spec.BackupLocations {
+			name := dpa.Metadata.Name + "-" + strconv.Itoa(i+1)
+			switch {
+			case bl.Velero != nil:
+				locs[name] = oadpLocation{
+					Provider:   bl.Velero.Provider,
+					Bucket:     bl.Velero.ObjectStorage.Bucket,
+					S3URL:      bl.Velero.Config["s3Url"],
+					Credential: bl.Velero.Credential.Name,
+					Default:    bl.Velero.Default,
+				}
+			case bl.Bucket != nil:
+				locs[name] = oadpLocation{
+					Bucket:       bl.Bucket.Name,
+					S3URL:        bl.Bucket.Config["s3Url"],
+					Credential:   bl.Bucket.Credential.Name,
+					Default:      bl.Bucket.Default,
+					CloudStorage: true,
+				}
+			}
+		}
+	}
+	return locs
+}
+
+// oadpDefaultCredentials are the Secret names OADP uses per provider when a
+// location does not name one.
+var oadpDefaultCredentials = map[string]string{
+	"aws":   "cloud-credentials",
+	"gcp":   "cloud-credentials-gcp",
+	"azure": "cloud-credentials-azure",
+}
+
+// oadpCredentialsPresent reports whether the cloud credentials Secret for a
+// location exists in openshift-adp. When no Secrets were collected there
+// (no RBAC to list them) the credentials are assumed present.
+func oadpCredentialsPresent(b *model.Bundle, name, provider string) bool {
+	if name == "" {
+		name = oadpDefaultCredentials[strings.TrimPrefix(provider, "velero.io/")]
+	}
+	if name == "" {
+		return false
+	}
+	seen := false
+	for _, s := range b.Inventory.Secrets {
+		if s.Namespace != oadpNamespace {
+			continue
+		}
+		seen = true
+		if s.Name == name {
+			return true
+		}
+	}
+	return !seen
+}
+
+// inClusterEndpoint reports whether an object storage URL points at a
+// Service inside the cluster (MinIO, NooBaa/ODF), which is not offsite.
+func inClusterEndpoint(endpoint string) bool {
+	if endpoint == "" {
+		return false
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, ".svc.cluster.local")
+}
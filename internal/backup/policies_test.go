@@ -0,0 +1,192 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestTrilioBackupPlans(t *testing.T) {
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			gvrTrilioBackupPlan: "BackupPlanList", gvrTrilioClusterBackupPlan: "ClusterBackupPlanList", gvrTrilioPolicy: "PolicyList",
+		},
+		newObj("triliovault.trilio.io/v1", "BackupPlan", "shop", "shop-plan", nil, map[string]interface{}{
+			"backupConfig": map[string]interface{}{
+				"target":          map[string]interface{}{"name": "s3-target"},
+				"retentionPolicy": map[string]interface{}{"name": "keep-7"},
+				"schedulePolicy": map[string]interface{}{
+					"fullBackupCron":  map[string]interface{}{"schedule": "0 1 * * 0"},
+					"incrementalCron": map[string]interface{}{"schedule": "0 */4 * * *"},
+				},
+			},
+		}, nil),
+		newObj("triliovault.trilio.io/v1", "Policy", "shop", "nightly", nil, map[string]interface{}{
+			"type":           "Schedule",
+			"scheduleConfig": map[string]interface{}{"schedule": []interface{}{"0 2 * * *"}},
+		}, nil),
+		newObj("triliovault.trilio.io/v1", "ClusterBackupPlan", "", "platform", nil, map[string]interface{}{
+			"backupConfig": map[string]interface{}{
+				"schedulePolicy": map[string]interface{}{"fullBackupPolicy": map[string]interface{}{"name": "nightly", "namespace": "shop"}},
+			},
+			"backupComponents": []interface{}{map[string]interface{}{"namespace": "ingress"}, map[string]interface{}{"namespace": "dns"}},
+		}, nil),
+	)
+
+	ps := trilioBackupPlans(context.Background(), dc)
+	if len(ps) != 2 {
+		t.Fatalf("got %d policies, want 2: %+v", len(ps), ps)
+	}
+	plan := ps[0]
+	if plan.RPOHours != 4 || !plan.HasOffsite || plan.IncludedNS[0] != "shop" || plan.RetentionTTL != "policy keep-7" {
+		t.Errorf("shop-plan = %+v, want 4h RPO from the incremental cron, offsite to s3-target", plan)
+	}
+	cluster := ps[1]
+	if cluster.Schedule != "0 2 * * *" || len(cluster.IncludedNS) != 2 || cluster.HasOffsite {
+		t.Errorf("platform = %+v, want the referenced nightly schedule over ingress and dns, no target", cluster)
+	}
+}
+
+func TestStashBackupConfigurations(t *testing.T) {
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvrStashBackupConfig: "BackupConfigurationList", gvrStashRepository: "RepositoryList"},
+		newObj("stash.appscode.com/v1beta1", "BackupConfiguration", "db", "pg", nil, map[string]interface{}{
+			"schedule":        "*/30 * * * *",
+			"repository":      map[string]interface{}{"name": "gcs-repo"},
+			"retentionPolicy": map[string]interface{}{"keepLast": int64(5), "keepDaily": int64(7)},
+		}, nil),
+		newObj("stash.appscode.com/v1beta1", "BackupConfiguration", "db", "paused", nil, map[string]interface{}{
+			"schedule": "@daily", "paused": true,
+		}, nil),
+		newObj("stash.appscode.com/v1alpha1", "Repository", "db", "gcs-repo", nil, map[string]interface{}{
+			"backend": map[string]interface{}{"gcs": map[string]interface{}{"bucket": "b"}, "storageSecretName": "gcs-secret"},
+		}, nil),
+	)
+
+	ps := stashBackupConfigurations(context.Background(), dc)
+	if len(ps) != 1 {
+		t.Fatalf("got %d policies, want 1 (paused skipped): %+v", len(ps), ps)
+	}
+	if p := ps[0]; !p.HasOffsite || p.RPOHours != 1 || p.RetentionTTL != "5 last, 7 daily" || p.IncludedNS[0] != "db" {
+		t.Errorf("pg = %+v, want offsite gcs backend, hourly RPO, retention 5 last, 7 daily", p)
+	}
+}
+
+func TestOADPPolicies(t *testing.T) {
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			gvrVeleroSchedule: "ScheduleList", gvrVeleroBSL: "BackupStorageLocationList",
+			gvrOADPDataProtectionApp: "DataProtectionApplicationList",
+		},
+		newObj("oadp.openshift.io/v1alpha1", "DataProtectionApplication", oadpNamespace, "dpa", nil, map[string]interface{}{
+			"backupLocations": []interface{}{
+				map[string]interface{}{"velero": map[string]interface{}{
+					"provider": "aws", "default": true,
+					"objectStorage": map[string]interface{}{"bucket": "dr-bucket"},
+				}},
+				map[string]interface{}{"velero": map[string]interface{}{
+					"provider":      "aws",
+					"objectStorage": map[string]interface{}{"bucket": "local"},
+					"config":        map[string]interface{}{"s3Url": "http://s3.openshift-storage.svc"},
+				}},
+			},
+		}, nil),
+		newObj("velero.io/v1", "Schedule", oadpNamespace, "apps-daily", nil, map[string]interface{}{
+			"schedule": "0 2 * * *", "template": map[string]interface{}{"includedNamespaces": []interface{}{"shop"}},
+		}, nil),
+		newObj("velero.io/v1", "Schedule", oadpNamespace, "apps-odf", nil, map[string]interface{}{
+			"schedule": "0 * * * *", "template": map[string]interface{}{"storageLocation": "dpa-2"},
+		}, nil),
+		newObj("velero.io/v1", "Schedule", "velero", "standalone", nil, map[string]interface{}{"schedule": "@daily"}, nil),
+	)
+
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Secrets = []model.Secret{{Namespace: oadpNamespace, Name: "cloud-credentials"}}
+	ps := oadpPolicies(context.Background(), dc, &b)
+	if len(ps) != 2 {
+		t.Fatalf("got %d policies, want the 2 in %s: %+v", len(ps), oadpNamespace, ps)
+	}
+	if p := ps[0]; p.Tool != "oadp" || p.StorageLocation != "dpa-1" || !p.HasOffsite {
+		t.Errorf("apps-daily = %+v, want the default location dpa-1, offsite with cloud-credentials", p)
+	}
+	if ps[1].HasOffsite {
+		t.Errorf("apps-odf writes to an in-cluster NooBaa endpoint; should not be offsite")
+	}
+
+	b.Inventory.Secrets = []model.Secret{{Namespace: oadpNamespace, Name: "other"}}
+	if ps := oadpPolicies(context.Background(), dc, &b); ps[0].HasOffsite {
+		t.Errorf("apps-daily offsite without its cloud credentials Secret")
+	}
+}
+
+func TestStorkBackupSchedules(t *testing.T) {
+	raw := func(apiVersion, kind, ns, name, field string, body map[string]interface{}) *unstructured.Unstructured {
+		meta := map[string]interface{}{"name": name}
+		if ns != "" {
+			meta["namespace"] = ns
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": apiVersion, "kind": kind, "metadata": meta, field: body,
+		}}
+	}
+	const stork = "stork.libopenstorage.org/v1alpha1"
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			gvrStorkBackupSchedule: "ApplicationBackupScheduleList", gvrStorkSchedulePolicy: "SchedulePolicyList",
+			gvrStorkBackupLocation: "BackupLocationList",
+		},
+		raw(stork, "SchedulePolicy", "", "frequent", "policy", map[string]interface{}{
+			"interval": map[string]interface{}{"intervalMinutes": int64(90), "retain": int64(10)},
+			"daily":    map[string]interface{}{"time": "10:14PM", "retain": int64(7)},
+		}),
+		raw(stork, "BackupLocation", "shop", "s3", "location", map[string]interface{}{
+			"type": "s3", "path": "px-bucket",
+			"s3Config": map[string]interface{}{"endpoint": "s3.eu-west-1.amazonaws.com", "accessKeyID": "AKIA"},
+		}),
+		raw(stork, "BackupLocation", "shop", "minio", "location", map[string]interface{}{
+			"type": "s3", "path": "px-bucket", "secretConfig": "minio-creds",
+			"s3Config": map[string]interface{}{"endpoint": "minio.minio.svc:9000"},
+		}),
+		newObj(stork, "ApplicationBackupSchedule", "shop", "shop-backup", nil, map[string]interface{}{
+			"schedulePolicyName": "frequent",
+			"template":           map[string]interface{}{"spec": map[string]interface{}{"namespaces": []interface{}{"shop"}, "backupLocation": "s3"}},
+		}, nil),
+		newObj(stork, "ApplicationBackupSchedule", "shop", "shop-minio", nil, map[string]interface{}{
+			"schedulePolicyName": "missing",
+			"template":           map[string]interface{}{"spec": map[string]interface{}{"namespaces": []interface{}{"shop"}, "backupLocation": "minio"}},
+		}, nil),
+		newObj(stork, "ApplicationBackupSchedule", "shop", "suspended", nil, map[string]interface{}{"suspend": true}, nil),
+	)
+
+	ps := storkBackupSchedules(context.Background(), dc)
+	if len(ps) != 2 {
+		t.Fatalf("got %d policies, want 2 (suspended skipped): %+v", len(ps), ps)
+	}
+	if p := ps[0]; p.RPOHours != 2 || p.RetentionTTL != "10 backups" || p.Schedule != "every 90m, daily 10:14PM" || !p.HasOffsite {
+		t.Errorf("shop-backup = %+v, want 2h RPO from the 90m interval, offsite to S3", p)
+	}
+	if p := ps[1]; p.RPOHours != -1 || p.HasOffsite {
+		t.Errorf("shop-minio = %+v, want unknown RPO and in-cluster MinIO not offsite", p)
+	}
+}
+
+func TestVeleroNotDetectedUnderOADP(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Namespaces = []model.Namespace{{Name: oadpNamespace}}
+	b.Inventory.CRDs = []model.CRD{{Group: "velero.io"}, {Group: "oadp.openshift.io"}}
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvrVeleroSchedule: "ScheduleList", gvrVeleroBSL: "BackupStorageLocationList", gvrOADPDataProtectionApp: "DataProtectionApplicationList",
+		gvrVeleroBackup: "BackupList", gvrVeleroRestore: "RestoreList",
+	})
+	Detect(context.Background(), nil, dc, &b)
+	if inv := b.Inventory.Backup; inv.PrimaryTool != "oadp" || len(inv.ActiveTools) != 1 {
+		t.Errorf("primary %q, active %v; want only oadp", inv.PrimaryTool, inv.ActiveTools)
+	}
+}
@@ -0,0 +1,369 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var (
+	gvrStorkBackupSchedule = schema.GroupVersionResource{Group: "stork.libopenstorage.org", Version: "v1alpha1", Resource: "applicationbackupschedules"}
+	gvrStorkSchedulePolicy = schema.GroupVersionResource{Group: "stork.libopenstorage.org", Version: "v1alpha1", Resource: "schedulepolicies"}
+	gvrStorkBackupLocation = schema.GroupVersionResource{Group: "stork.libopenstorage.org", Version: "v1alpha1", Resource: "backuplocations"}
+	gvrStorkBackup         = schema.GroupVersionResource{Group: "stork.libopenstorage.org", Version: "v1alpha1", Resource: "applicationbackups"}
+	gvrStorkRestore        = schema.GroupVersionResource{Group: "stork.libopenstorage.org", Version: "v1alpha1", Resource: "applicationrestores"}
+)
+
+var portworxSpec = Spec{
+	Name:          "portworx",
+	Namespaces:    []string{"px-backup", "portworx"},
+	CRDGroups:     []string{"stork.libopenstorage.org"},
+	PodLabelKey:   "app.kubernetes.io/name",
+	PodLabelValue: "px-backup",
+}
+
+// portworxProvider reads Stork ApplicationBackupSchedules. PX-Backup drives
+// backups through Stork on each cluster, so its schedules appear there too;
+// the PX-Backup catalogue itself lives in its own datastore and is not read.
+type portworxProvider struct{ specProvider }
+
+func (portworxProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return storkBackupSchedules(ctx, env.Dynamic)
+}
+
+func (portworxProvider) LastBackups(ctx context.Context, env Env, policies []model.BackupPolicy) *model.BackupEvidence {
+	return storkEvidence(ctx, env.Dynamic, env.Bundle, policies)
+}
+
+// storkScheduleList is the part of an ApplicationBackupSchedule list we read.
+// Status.Items holds the backups the schedule created, per policy type.
+type storkScheduleList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			SchedulePolicyName string `json:"schedulePolicyName"`
+			Suspend            *bool  `json:"suspend"`
+			Template           struct {
+				Spec struct {
+					Namespaces     []string `json:"namespaces"`
+					BackupLocation string   `json:"backupLocation"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+		Status struct {
+			Items map[string][]struct {
+				Name              string     `json:"name"`
+				CreationTimestamp *time.Time `json:"creationTimestamp"`
+				FinishTimestamp   *time.Time `json:"finishTimestamp"`
+				Status            string     `json:"status"`
+			} `json:"items"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// storkBackupSchedules reads stork.libopenstorage.org/v1alpha1
+// ApplicationBackupSchedules; suspended ones are skipped. The schedule and
+// retention come from the referenced SchedulePolicy, offsite from the
+// BackupLocation the template writes to.
+func storkBackupSchedules(ctx context.Context, dc dynamic.Interface) []model.BackupPolicy {
+	raw, err := listRaw(ctx, dc, gvrStorkBackupSchedule, "")
+	if err != nil {
+		return nil
+	}
+	var list storkScheduleList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+	schedules := storkSchedulePolicies(ctx, dc)
+	locations := storkBackupLocations(ctx, dc)
+
+	var policies []model.BackupPolicy
+	for _, item := range list.Items {
+		if item.Spec.Suspend != nil && *item.Spec.Suspend {
+			continue
+		}
+		tmpl := item.Spec.Template.Spec
+		sp, ok := schedules[item.Spec.SchedulePolicyName]
+		if !ok {
+			sp = storkSchedule{rpo: -1}
+		}
+		p := model.BackupPolicy{
+			Tool:            "portworx",
+			Name:            item.Metadata.Name,
+			PolicyNamespace: item.Metadata.Namespace,
+			IncludedNS:      tmpl.Namespaces,
+			Schedule:        sp.schedule,
+			RetentionTTL:    sp.retention,
+			RPOHours:        sp.rpo,
+			StorageLocation: tmpl.BackupLocation,
+			HasOffsite:      locations[item.Metadata.Namespace+"/"+tmpl.BackupLocation],
+		}
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+// storkSchedule is a SchedulePolicy reduced to its most frequent trigger.
+type storkSchedule struct {
+	schedule  string
+	retention string
+	rpo       int
+}
+
+// storkSchedulePolicies reads cluster-scoped SchedulePolicies. A policy may
+// set several triggers (interval, daily, weekly, monthly); the RPO and
+// retention are those of the most frequent one.
+func storkSchedulePolicies(ctx context.Context, dc dynamic.Interface) map[string]storkSchedule {
+	out := map[string]storkSchedule{}
+	raw, err := listRaw(ctx, dc, gvrStorkSchedulePolicy, "")
+	if err != nil {
+		return out
+	}
+	type trigger struct {
+		IntervalMinutes int    `json:"intervalMinutes"`
+		Day             string `json:"day"`
+		Date            int    `json:"date"`
+		Time            string `json:"time"`
+		Retain          int    `json:"retain"`
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Policy struct {
+				Interval *trigger `json:"interval"`
+				Daily    *trigger `json:"daily"`
+				Weekly   *trigger `json:"weekly"`
+				Monthly  *trigger `json:"monthly"`
+			} `json:"policy"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return out
+	}
+	for _, item := range list.Items {
+		s := storkSchedule{rpo: -1}
+		var parts []string
+		use := func(t *trigger, hours int, desc string) {
+			parts = append(parts, desc)
+			if s.rpo == -1 || hours < s.rpo {
+				s.rpo = hours
+				s.retention = ""
+				if t.Retain > 0 {
+					s.retention = strconv.Itoa(t.Retain) + " backups"
+				}
+			}
+		}
+		pol := item.Policy
+		if t := pol.Interval; t != nil && t.IntervalMinutes > 0 {
+			use(t, int(math.Ceil(float64(t.IntervalMinutes)/60)), "every "+strconv.Itoa(t.IntervalMinutes)+"m")
+		}
+		if t := pol.Daily; t != nil {
+			use(t, 24, strings.TrimSpace("daily "+t.Time))
+		}
+		if t := pol.Weekly; t != nil {
+			use(t, 168, strings.TrimSpace("weekly "+t.Day+" "+t.Time))
+		}
+		if t := pol.Monthly; t != nil {
+			use(t, 720, strings.TrimSpace(fmt.Sprintf("monthly %d %s", t.Date, t.Time)))
+		}
+		s.schedule = strings.Join(parts, ", ")
+		out[item.Metadata.Name] = s
+	}
+	return out
+}
+
+// storkObjectStores are BackupLocation types that store data outside the
+// cluster. nfs is not counted as offsite.
+var storkObjectStores = []string{"s3", "azure", "google"}
+
+// storkBackupLocations maps "namespace/name" of each BackupLocation to whether
+// it is offsite: an object store with a bucket, an endpoint outside the
+// cluster, and cloud credentials (inline or via a Secret).
+func storkBackupLocations(ctx context.Context, dc dynamic.Interface) map[string]bool {
+	out := map[string]bool{}
+	raw, err := listRaw(ctx, dc, gvrStorkBackupLocation, "")
+	if err != nil {
+		return out
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Location struct {
+				Type         string `json:"type"`
+				Path         string `json:"path"`
+				SecretConfig string `json:"secretConfig"`
+				S3Config     *struct {
+					Endpoint    string `json:"endpoint"`
+					AccessKeyID string `json:"accessKeyID"`
+				} `json:"s3Config"`
+				AzureConfig *struct {
+					StorageAccountKey string `json:"storageAccountKey"`
+				} `json:"azureConfig"`
+				GoogleConfig *struct {
+					AccountKey string `json:"accountKey"`
+				} `json:"googleConfig"`
+			} `json:"location"`
+			Cluster struct {
+				SecretConfig string `json:"secretConfig"`
+			} `json:"cluster"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return out
+	}
+	for _, item := range list.Items {
+		loc := item.Location
+		if !contains(storkObjectStores, strings.ToLower(loc.Type)) || loc.Path == "" {
+			continue
+		}
+		creds := loc.SecretConfig != "" || item.Cluster.SecretConfig != ""
+		if c := loc.S3Config; c != nil {
+			if inClusterEndpoint(c.Endpoint) {
+				continue
+			}
+			creds = creds || c.AccessKeyID != ""
+		}
+		if c := loc.AzureConfig; c != nil {
+			creds = creds || c.StorageAccountKey != ""
+		}
+		if c := loc.GoogleConfig; c != nil {
+			creds = creds || c.AccountKey != ""
+		}
+		out[item.Metadata.Namespace+"/"+item.Metadata.Name] = creds
+	}
+	return out
+}
+
+// storkEvidence reads ApplicationBackups and ApplicationRestores: the last
+// Successful backup per namespace, Failed/PartialSuccess counts and restore
+// tests. Schedule status gives each policy its run history. Returns nil when
+// ApplicationBackups cannot be listed.
+func storkEvidence(ctx context.Context, dc dynamic.Interface, b *model.Bundle, policies []model.BackupPolicy) *model.BackupEvidence {
+	raw, err := listRaw(ctx, dc, gvrStorkBackup, "")
+	if err != nil {
+		return nil
+	}
+	var backups struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Namespaces []string `json:"namespaces"`
+			} `json:"spec"`
+			Status struct {
+				Status           string     `json:"status"`
+				TriggerTimestamp *time.Time `json:"triggerTimestamp"`
+				FinishTimestamp  *time.Time `json:"finishTimestamp"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &backups); err != nil {
+		return nil
+	}
+
+	var nsNames []string
+	for _, ns := range b.Inventory.Namespaces {
+		nsNames = append(nsNames, ns.Name)
+	}
+
+	ev := &model.BackupEvidence{}
+	last := map[string]model.NamespaceBackup{}
+	for _, item := range backups.Items {
+		ev.Backups++
+		at := item.Status.FinishTimestamp
+		if at == nil {
+			at = item.Status.TriggerTimestamp
+		}
+		switch item.Status.Status {
+		case "Successful":
+			ev.Completed++
+			if at == nil {
+				continue
+			}
+			if ev.LastSuccess == nil || at.After(*ev.LastSuccess) {
+				t := *at
+				ev.LastSuccess = &t
+			}
+			for _, ns := range veleroBackupNamespaces(item.Spec.Namespaces, nil, nsNames) {
+				if cur, ok := last[ns]; !ok || at.After(cur.LastSuccess) {
+					last[ns] = model.NamespaceBackup{Namespace: ns, Tool: "portworx", Backup: item.Metadata.Name, LastSuccess: *at}
+				}
+			}
+		case "Failed", "PartialSuccess":
+			if item.Status.Status == "Failed" {
+				ev.Failed++
+			} else {
+				ev.PartiallyFailed++
+			}
+			ev.FailedBackups = append(ev.FailedBackups, model.ResourceRef{Kind: "ApplicationBackup", Namespace: item.Metadata.Namespace, Name: item.Metadata.Name})
+		}
+	}
+	ev.Namespaces = namespaceBackups(b, last)
+
+	if raw, err := listRaw(ctx, dc, gvrStorkBackupSchedule, ""); err == nil {
+		var list storkScheduleList
+		if json.Unmarshal(raw, &list) == nil {
+			var runs []policyRun
+			for _, s := range list.Items {
+				for _, items := range s.Status.Items {
+					for _, r := range items {
+						at := r.FinishTimestamp
+						if at == nil {
+							at = r.CreationTimestamp
+						}
+						switch {
+						case at == nil:
+						case r.Status == "Successful", r.Status == "Failed", r.Status == "PartialSuccess":
+							runs = append(runs, policyRun{policy: s.Metadata.Name, state: r.Status, ok: r.Status == "Successful", at: *at})
+						}
+					}
+				}
+			}
+			applyRunHistory(policies, "portworx", runs)
+		}
+	}
+
+	if raw, err := listRaw(ctx, dc, gvrStorkRestore, ""); err == nil {
+		var restores struct {
+			Items []struct {
+				Status struct {
+					Status          string     `json:"status"`
+					FinishTimestamp *time.Time `json:"finishTimestamp"`
+				} `json:"status"`
+			} `json:"items"`
+		}
+		if json.Unmarshal(raw, &restores) == nil {
+			for _, r := range restores.Items {
+				ev.Restores++
+				if r.Status.Status != "Successful" {
+					continue
+				}
+				ev.RestoresCompleted++
+				if at := r.Status.FinishTimestamp; at != nil && (ev.LastRestore == nil || at.After(*ev.LastRestore)) {
+					t := *at
+					ev.LastRestore = &t
+				}
+			}
+		}
+	}
+	return ev
+}
@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// Env is what a Provider reads the cluster through. Bundle already holds the
+// collected inventory (namespaces, CRDs, PVCs, ...).
+type Env struct {
+	Client  kubernetes.Interface
+	Dynamic dynamic.Interface
+	Bundle  *model.Bundle
+}
+
+// Provider is one backup product. Built-in providers live one per file
+// (velero.go, kasten.go, ...). A new product implements Provider in its own
+// file and calls Register from init. Products that only need to be recognised
+// can be described in a spec file instead (see LoadSpecs).
+type Provider interface {
+	// Name is the tool name used in BackupPolicy.Tool and the report.
+	Name() string
+	// Detect reports whether the product is installed, with its namespace,
+	// version and CRDs.
+	Detect(ctx context.Context, env Env) model.BackupDetectedTool
+	// Policies returns the product's backup policies or schedules.
+	Policies(ctx context.Context, env Env, tool model.BackupDetectedTool) []model.BackupPolicy
+	// Coverage reports whether policy p, which already selects the PVC's
+	// namespace, protects the PVC's data. provisioner is the PVC's
+	// StorageClass provisioner.
+	Coverage(p model.BackupPolicy, pvc model.PersistentVolumeClaim, provisioner string) bool
+	// LastBackups reads the backups the product actually ran, filling in run
+	// history on its policies. Nil when there is nothing to read.
+	LastBackups(ctx context.Context, env Env, policies []model.BackupPolicy) *model.BackupEvidence
+}
+
+// VolumeProvider is implemented by providers that back up volumes rather
+// than a namespace's objects. Such a tool only counts as protecting a
+// namespace when its Coverage accepts at least one of the namespace's PVCs.
+type VolumeProvider interface {
+	Provider
+	VolumeLevel() bool
+}
+
+// volumeLevel reports whether the provider called name is a VolumeProvider.
+func volumeLevel(name string) bool {
+	vp, ok := lookup(name).(VolumeProvider)
+	return ok && vp.VolumeLevel()
+}
+
+// registry holds the providers in detection order. The first detected one
+// becomes BackupInventory.PrimaryTool.
+var registry = []Provider{
+	kastenProvider{specProvider{kastenSpec}},
+	oadpProvider{specProvider{oadpSpec}},
+	veleroProvider{specProvider{veleroSpec}},
+	rubrikProvider{specProvider{rubrikSpec}},
+	longhornProvider{specProvider{longhornSpec}},
+	trilioProvider{specProvider{trilioSpec}},
+	stashProvider{specProvider{stashSpec}},
+	cloudcasaProvider{specProvider{cloudcasaSpec}},
+	portworxProvider{specProvider{portworxSpec}},
+}
+
+// Register adds p after the built-in providers. A provider with the same
+// name as a registered one replaces it. Register is not safe to call while a
+// scan is running.
+func Register(p Provider) {
+	for i, q := range registry {
+		if q.Name() == p.Name() {
+			registry[i] = p
+			return
+		}
+	}
+	registry = append(registry, p)
+}
+
+// Providers returns the registered providers in detection order.
+func Providers() []Provider {
+	return append([]Provider(nil), registry...)
+}
+
+// lookup returns the registered provider called name, or nil.
+func lookup(name string) Provider {
+	for _, p := range registry {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Spec describes how to recognise a backup product from cluster state: any
+// of its namespaces or CRD groups marks it detected, and a pod matching the
+// label in that namespace supplies the version.
+type Spec struct {
+	Name          string   `json:"name"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	CRDGroups     []string `json:"crdGroups,omitempty"` // substrings matched against CRD group names
+	PodLabelKey   string   `json:"podLabelKey,omitempty"`
+	PodLabelValue string   `json:"podLabelValue,omitempty"`
+}
+
+// specProvider detects a product from its Spec and reads nothing else: no
+// policies, every PVC in a covered namespace is protected, no backup
+// history. Built-in providers embed it and override what they can read.
+type specProvider struct {
+	spec Spec
+}
+
+func (s specProvider) Name() string { return s.spec.Name }
+
+func (s specProvider) Detect(ctx context.Context, env Env) model.BackupDetectedTool {
+	b := env.Bundle
+	tool := model.BackupDetectedTool{Name: s.spec.Name}
+
+	// Check namespace presence
+	foundNS := ""
+	for _, want := range s.spec.Namespaces {
+		for _, ns := range b.Inventory.Namespaces {
+			if ns.Name == want {
+				foundNS = want
+				break
+			}
+		}
+		if foundNS != "" {
+			tool.Detected = true
+			tool.Namespace = foundNS
+			break
+		}
+	}
+
+	// Check CRD presence
+	seen := map[string]bool{}
+	for _, crd := range b.Inventory.CRDs {
+		if seen[crd.Group] {
+			continue
+		}
+		for _, part := range s.spec.CRDGroups {
+			if strings.Contains(crd.Group, part) {
+				tool.Detected = true
+				tool.CRDsFound = append(tool.CRDsFound, crd.Group)
+				seen[crd.Group] = true
+				break
+			}
+		}
+	}
+
+	// If namespace found, check for pods to confirm and get version
+	if foundNS != "" && s.spec.PodLabelKey != "" && env.Client != nil {
+		selector := s.spec.PodLabelKey + "=" + s.spec.PodLabelValue
+		pods, err := env.Client.CoreV1().Pods(foundNS).List(ctx, metav1.ListOptions{
+			LabelSelector: selector,
+			Limit:         1,
+		})
+		if err == nil && len(pods.Items) > 0 {
+			pod := pods.Items[0]
+			if v := pod.Labels["app.kubernetes.io/version"]; v != "" {
+				tool.Version = v
+			} else if v := pod.Labels["helm.sh/chart"]; v != "" {
+				tool.Version = v
+			}
+		}
+	}
+	return tool
+}
+
+func (specProvider) Policies(context.Context, Env, model.BackupDetectedTool) []model.BackupPolicy {
+	return nil
+}
+
+func (specProvider) Coverage(model.BackupPolicy, model.PersistentVolumeClaim, string) bool {
+	return true
+}
+
+func (specProvider) LastBackups(context.Context, Env, []model.BackupPolicy) *model.BackupEvidence {
+	return nil
+}
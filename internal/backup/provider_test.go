@@ -0,0 +1,67 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestLoadSpecsRegistersDetector(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+
+	path := filepath.Join(t.TempDir(), "providers.yaml")
+	spec := `providers:
+  - name: Commvault
+    namespaces: [commvault]
+  - name: astra
+    crdGroups: [astra.netapp.io]
+`
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadSpecs(path); err != nil {
+		t.Fatalf("LoadSpecs: %v", err)
+	}
+	if lookup("commvault") == nil || lookup("astra") == nil {
+		t.Fatalf("spec providers not registered: %d providers", len(registry))
+	}
+
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Namespaces = []model.Namespace{{Name: "commvault"}}
+	b.Inventory.CRDs = []model.CRD{{Group: "astra.netapp.io"}}
+	Detect(context.Background(), nil, nil, &b)
+
+	inv := b.Inventory.Backup
+	if inv.PrimaryTool != "commvault" || len(inv.ActiveTools) != 2 || inv.ActiveTools[1] != "astra" {
+		t.Errorf("primary %q, active %v; want commvault then astra", inv.PrimaryTool, inv.ActiveTools)
+	}
+	if len(inv.Policies) != 0 || len(inv.CoveredNamespaces) != 0 {
+		t.Errorf("spec-only providers should add no policies or coverage, got %d policies, %v covered", len(inv.Policies), inv.CoveredNamespaces)
+	}
+}
+
+func TestRegisterReplacesByName(t *testing.T) {
+	saved := registry
+	defer func() { registry = saved }()
+	registry = append([]Provider(nil), saved...)
+
+	n := len(registry)
+	Register(specProvider{Spec{Name: "velero", Namespaces: []string{"backup"}}})
+	if len(registry) != n {
+		t.Fatalf("Register added a second velero provider")
+	}
+	if _, ok := lookup("velero").(specProvider); !ok {
+		t.Errorf("velero provider not replaced")
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.yaml")
+	os.WriteFile(path, []byte("providers:\n  - name: x\n"), 0o644)
+	if err := LoadSpecs(path); err == nil {
+		t.Error("LoadSpecs accepted a provider without namespaces or crdGroups")
+	}
+}
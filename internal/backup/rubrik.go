@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var rubrikSpec = Spec{
+	Name:          "rubrik",
+	Namespaces:    []string{"rubrik", "rbs"},
+	CRDGroups:     []string{"rubrik.com"},
+	PodLabelKey:   "app",
+	PodLabelValue: "rubrik-backup-service",
+}
+
+// rubrikProvider reads SLA / policy custom resources.
+type rubrikProvider struct{ specProvider }
+
+func (rubrikProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return rubrikPolicies(ctx, env.Dynamic, env.Bundle)
+}
+
+// rubrikPolicies reads Rubrik SLA / policy custom resources. Rubrik does not
+// publish its in-cluster CRD schema and it differs between agent releases, so
+// every rubrik.com CRD whose resource name mentions an SLA, policy or schedule
+// is listed and the commonly used spec fields are mapped:
+//
+//	spec.frequency | spec.schedule                  → Schedule
+//	spec.namespaces | spec.includedNamespaces       → IncludedNS (namespaced objects default to their own namespace)
+//	spec.retention                                  → RetentionTTL
+//	spec.archivalLocation | spec.replicationTarget  → HasOffsite
+func rubrikPolicies(ctx context.Context, dc dynamic.Interface, b *model.Bundle) []model.BackupPolicy {
+	var policies []model.BackupPolicy
+	for _, crd := range b.Inventory.CRDs {
+		if !strings.HasSuffix(crd.Group, "rubrik.com") || len(crd.Versions) == 0 {
+			continue
+		}
+		plural := strings.TrimSuffix(crd.Name, "."+crd.Group)
+		if !strings.Contains(plural, "sla") && !strings.Contains(plural, "polic") && !strings.Contains(plural, "schedule") {
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: crd.Group, Version: crd.Versions[0], Resource: plural}
+		raw, err := listRaw(ctx, dc, gvr, "")
+		if err != nil {
+			continue
+		}
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+				Spec struct {
+					Frequency          string          `json:"frequency"`
+					Schedule           string          `json:"schedule"`
+					Namespaces         []string        `json:"namespaces"`
+					IncludedNamespaces []string        `json:"includedNamespaces"`
+					Retention          json.RawMessage `json:"retention"`
+					ArchivalLocation   string          `json:"archivalLocation"`
+					ReplicationTarget  string          `json:"replicationTarget"`
+				} `json:"spec"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(raw, &list); err != nil {
+			continue
+		}
+		for _, item := range list.Items {
+			sched := item.Spec.Schedule
+			if sched == "" {
+				sched = item.Spec.Frequency
+			}
+			included := item.Spec.IncludedNamespaces
+			if len(included) == 0 {
+				included = item.Spec.Namespaces
+			}
+			if len(included) == 0 && item.Metadata.Namespace != "" {
+				included = []string{item.Metadata.Namespace}
+			}
+			location := item.Spec.ArchivalLocation
+			if location == "" {
+				location = item.Spec.ReplicationTarget
+			}
+			policies = append(policies, model.BackupPolicy{
+				Tool:            "rubrik",
+				Name:            item.Metadata.Name,
+				PolicyNamespace: item.Metadata.Namespace,
+				IncludedNS:      included,
+				Schedule:        sched,
+				RetentionTTL:    strings.Trim(string(item.Spec.Retention), `"`),
+				RPOHours:        scheduleRPOHours(sched),
+				HasOffsite:      location != "",
+				StorageLocation: location,
+			})
+		}
+	}
+	return policies
+}
@@ -0,0 +1,51 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// SpecFile is the top-level --backup-providers document. Each entry is
+// detected like a built-in tool but has no policy reader.
+//
+//	providers:
+//	  - name: commvault
+//	    namespaces: [commvault]
+//	    crdGroups: [commvault.com]
+//	    podLabelKey: app
+//	    podLabelValue: commvault
+type SpecFile struct {
+	Providers []Spec `json:"providers"`
+}
+
+// LoadSpecs reads a provider spec YAML (or JSON) file and registers one
+// detector per entry. An entry named like a built-in provider replaces it.
+func LoadSpecs(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("backup providers: %w", err)
+	}
+	var f SpecFile
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return fmt.Errorf("backup providers %s: %w", path, err)
+	}
+	for i, s := range f.Providers {
+		s.Name = strings.ToLower(strings.TrimSpace(s.Name))
+		switch {
+		case s.Name == "":
+			return fmt.Errorf("backup providers %s: provider %d has no name", path, i)
+		case len(s.Namespaces) == 0 && len(s.CRDGroups) == 0:
+			return fmt.Errorf("backup providers %s: provider %s needs namespaces or crdGroups", path, s.Name)
+		case (s.PodLabelKey == "") != (s.PodLabelValue == ""):
+			return fmt.Errorf("backup providers %s: provider %s: podLabelKey and podLabelValue go together", path, s.Name)
+		}
+		f.Providers[i] = s
+	}
+	for _, s := range f.Providers {
+		Register(specProvider{spec: s})
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var (
+	gvrStashBackupConfig = schema.GroupVersionResource{Group: "stash.appscode.com", Version: "v1beta1", Resource: "backupconfigurations"}
+	gvrStashRepository   = schema.GroupVersionResource{Group: "stash.appscode.com", Version: "v1alpha1", Resource: "repositories"}
+)
+
+var stashSpec = Spec{
+	Name:          "stash",
+	Namespaces:    []string{"stash"},
+	CRDGroups:     []string{"stash.appscode.com"},
+	PodLabelKey:   "app",
+	PodLabelValue: "stash",
+}
+
+// stashProvider reads BackupConfigurations.
+type stashProvider struct{ specProvider }
+
+func (stashProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return stashBackupConfigurations(ctx, env.Dynamic)
+}
+
+// stashRemoteBackends are Repository backends that store data outside the
+// cluster. "local" (a PVC, hostPath or NFS volume) is not counted as offsite.
+var stashRemoteBackends = []string{"s3", "gcs", "azure", "swift", "b2", "rest"}
+
+// stashBackupConfigurations reads stash.appscode.com/v1beta1
+// BackupConfigurations. Each protects a target in its own namespace; paused
+// configurations are skipped. Offsite comes from the backend of the
+// referenced Repository.
+func stashBackupConfigurations(ctx context.Context, dc dynamic.Interface) []model.BackupPolicy {
+	raw, err := listRaw(ctx, dc, gvrStashBackupConfig, "")
+	if err != nil {
+		return nil
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Schedule   string `json:"schedule"`
+				Paused     bool   `json:"paused"`
+				Repository struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"repository"`
+				RetentionPolicy struct {
+					KeepLast    int `json:"keepLast"`
+					KeepHourly  int `json:"keepHourly"`
+					KeepDaily   int `json:"keepDaily"`
+					KeepWeekly  int `json:"keepWeekly"`
+					KeepMonthly int `json:"keepMonthly"`
+				} `json:"retentionPolicy"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+
+	backends := stashRepositoryBackends(ctx, dc)
+	var policies []model.BackupPolicy
+	for _, item := range list.Items {
+		if item.Spec.Paused {
+			continue
+		}
+		repoNS := item.Spec.Repository.Namespace
+		if repoNS == "" {
+			repoNS = item.Metadata.Namespace
+		}
+		backend := backends[repoNS+"/"+item.Spec.Repository.Name]
+
+		var keep []string
+		rp := item.Spec.RetentionPolicy
+		for _, k := range []struct {
+			n     int
+			label string
+		}{{rp.KeepLast, "last"}, {rp.KeepHourly, "hourly"}, {rp.KeepDaily, "daily"}, {rp.KeepWeekly, "weekly"}, {rp.KeepMonthly, "monthly"}} {
+			if k.n > 0 {
+				keep = append(keep, fmt.Sprintf("%d %s", k.n, k.label))
+			}
+		}
+
+		policies = append(policies, model.BackupPolicy{
+			Tool:            "stash",
+			Name:            item.Metadata.Name,
+			PolicyNamespace: item.Metadata.Namespace,
+			IncludedNS:      []string{item.Metadata.Namespace},
+			Schedule:        item.Spec.Schedule,
+			RetentionTTL:    strings.Join(keep, ", "),
+			RPOHours:        scheduleRPOHours(item.Spec.Schedule),
+			HasOffsite:      contains(stashRemoteBackends, backend),
+			StorageLocation: item.Spec.Repository.Name,
+		})
+	}
+	return policies
+}
+
+// stashRepositoryBackends maps "namespace/name" of each Repository to its
+// backend type (s3, gcs, local, ...).
+func stashRepositoryBackends(ctx context.Context, dc dynamic.Interface) map[string]string {
+	out := map[string]string{}
+	raw, err := listRaw(ctx, dc, gvrStashRepository, "")
+	if err != nil {
+		return out
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Backend map[string]json.RawMessage `json:"backend"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if json.Unmarshal(raw, &list) != nil {
+		return out
+	}
+	for _, item := range list.Items {
+		for kind := range item.Spec.Backend {
+			if kind != "storageSecretName" {
+				out[item.Metadata.Namespace+"/"+item.Metadata.Name] = kind
+			}
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
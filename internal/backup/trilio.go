@@ -0,0 +1,168 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var (
+	gvrTrilioBackupPlan        = schema.GroupVersionResource{Group: "triliovault.trilio.io", Version: "v1", Resource: "backupplans"}
+	gvrTrilioClusterBackupPlan = schema.GroupVersionResource{Group: "triliovault.trilio.io", Version: "v1", Resource: "clusterbackupplans"}
+	gvrTrilioPolicy            = schema.GroupVersionResource{Group: "triliovault.trilio.io", Version: "v1", Resource: "policies"}
+)
+
+var trilioSpec = Spec{
+	Name:          "trilio",
+	Namespaces:    []string{"trilio-system"},
+	CRDGroups:     []string{"triliovault.trilio.io"},
+	PodLabelKey:   "app",
+	PodLabelValue: "trilio",
+}
+
+// trilioProvider reads BackupPlans and ClusterBackupPlans.
+type trilioProvider struct{ specProvider }
+
+func (trilioProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	return trilioBackupPlans(ctx, env.Dynamic)
+}
+
+type trilioRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type trilioBackupConfig struct {
+	Target          trilioRef `json:"target"`
+	RetentionPolicy trilioRef `json:"retentionPolicy"`
+	SchedulePolicy  struct {
+		// Inline crons (TVK 3.x).
+		FullBackupCron struct {
+			Schedule string `json:"schedule"`
+		} `json:"fullBackupCron"`
+		IncrementalCron struct {
+			Schedule string `json:"schedule"`
+		} `json:"incrementalCron"`
+		// References to Policy objects of type Schedule (earlier releases).
+		FullBackupPolicy  trilioRef `json:"fullBackupPolicy"`
+		IncrementalPolicy trilioRef `json:"incrementalPolicy"`
+	} `json:"schedulePolicy"`
+}
+
+// trilioBackupPlans reads triliovault.trilio.io/v1 BackupPlans (which protect
+// their own namespace) and ClusterBackupPlans (which list namespaces in
+// backupComponents). Every plan writes to a Target — an object store or NFS
+// share outside the cluster — so a plan with a target counts as offsite.
+func trilioBackupPlans(ctx context.Context, dc dynamic.Interface) []model.BackupPolicy {
+	schedules := trilioSchedulePolicies(ctx, dc)
+	cronOf := func(inline string, ref trilioRef, planNS string) string {
+		if inline != "" || ref.Name == "" {
+			return inline
+		}
+		if ref.Namespace == "" {
+			ref.Namespace = planNS
+		}
+		return schedules[ref.Namespace+"/"+ref.Name]
+	}
+	toPolicy := func(name, ns string, cfg trilioBackupConfig, included []string) model.BackupPolicy {
+		sp := cfg.SchedulePolicy
+		full := cronOf(sp.FullBackupCron.Schedule, sp.FullBackupPolicy, ns)
+		incr := cronOf(sp.IncrementalCron.Schedule, sp.IncrementalPolicy, ns)
+		p := model.BackupPolicy{
+			Tool:            "trilio",
+			Name:            name,
+			PolicyNamespace: ns,
+			IncludedNS:      included,
+			Schedule:        incr,
+			RPOHours:        scheduleRPOHours(incr),
+			HasOffsite:      cfg.Target.Name != "",
+			StorageLocation: cfg.Target.Name,
+		}
+		// The more frequent of the full and incremental schedules sets the RPO.
+		if r := scheduleRPOHours(full); r >= 0 && (p.RPOHours < 0 || r < p.RPOHours) {
+			p.Schedule, p.RPOHours = full, r
+		}
+		if cfg.RetentionPolicy.Name != "" {
+			p.RetentionTTL = "policy " + cfg.RetentionPolicy.Name
+		}
+		return p
+	}
+
+	var policies []model.BackupPolicy
+	if raw, err := listRaw(ctx, dc, gvrTrilioBackupPlan, ""); err == nil {
+		var list struct {
+			Items []struct {
+				Metadata trilioRef `json:"metadata"`
+				Spec     struct {
+					BackupConfig trilioBackupConfig `json:"backupConfig"`
+				} `json:"spec"`
+			} `json:"items"`
+		}
+		if json.Unmarshal(raw, &list) == nil {
+			for _, item := range list.Items {
+				policies = append(policies, toPolicy(item.Metadata.Name, item.Metadata.Namespace,
+					item.Spec.BackupConfig, []string{item.Metadata.Namespace}))
+			}
+		}
+	}
+	if raw, err := listRaw(ctx, dc, gvrTrilioClusterBackupPlan, ""); err == nil {
+		var list struct {
+			Items []struct {
+				Metadata trilioRef `json:"metadata"`
+				Spec     struct {
+					BackupConfig     trilioBackupConfig `json:"backupConfig"`
+					BackupComponents []struct {
+						Namespace string `json:"namespace"`
+					} `json:"backupComponents"`
+				} `json:"spec"`
+			} `json:"items"`
+		}
+		if json.Unmarshal(raw, &list) == nil {
+			for _, item := range list.Items {
+				var nss []string
+				for _, c := range item.Spec.BackupComponents {
+					nss = append(nss, c.Namespace)
+				}
+				if len(nss) == 0 {
+					continue // an empty IncludedNS would read as "all namespaces"
+				}
+				policies = append(policies, toPolicy(item.Metadata.Name, item.Metadata.Namespace, item.Spec.BackupConfig, nss))
+			}
+		}
+	}
+	return policies
+}
+
+// trilioSchedulePolicies maps "namespace/name" of each Schedule-type Policy
+// to its first cron expression.
+func trilioSchedulePolicies(ctx context.Context, dc dynamic.Interface) map[string]string {
+	out := map[string]string{}
+	raw, err := listRaw(ctx, dc, gvrTrilioPolicy, "")
+	if err != nil {
+		return out
+	}
+	var list struct {
+		Items []struct {
+			Metadata trilioRef `json:"metadata"`
+			Spec     struct {
+				Type           string `json:"type"`
+				ScheduleConfig struct {
+					Schedule []string `json:"schedule"`
+				} `json:"scheduleConfig"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if json.Unmarshal(raw, &list) != nil {
+		return out
+	}
+	for _, item := range list.Items {
+		if item.Spec.Type == "Schedule" && len(item.Spec.ScheduleConfig.Schedule) > 0 {
+			out[item.Metadata.Namespace+"/"+item.Metadata.Name] = item.Spec.ScheduleConfig.Schedule[0]
+		}
+	}
+	return out
+}
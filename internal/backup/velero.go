@@ -0,0 +1,283 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+var (
+	gvrVeleroSchedule = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "schedules"}
+	gvrVeleroBackup   = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backups"}
+	gvrVeleroRestore  = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "restores"}
+	gvrVeleroBSL      = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backupstoragelocations"}
+)
+
+var veleroSpec = Spec{
+	Name:          "velero",
+	Namespaces:    []string{"velero"},
+	CRDGroups:     []string{"velero.io"},
+	PodLabelKey:   "app.kubernetes.io/name",
+	PodLabelValue: "velero",
+}
+
+// veleroDistributions maps the namespace of each product that ships its own
+// Velero to the tool it is reported as. Velero objects in those namespaces
+// belong to that product's provider, not to velero.
+var veleroDistributions = map[string]string{
+	"openshift-adp": "oadp",
+	"cloudcasa-io":  "cloudcasa",
+}
+
+// standaloneVelero reports whether Velero objects in ns belong to a
+// standalone Velero install.
+func standaloneVelero(ns string) bool { return veleroDistributions[ns] == "" }
+
+// veleroProvider reads Schedules as policies and Backups/Restores as evidence.
+type veleroProvider struct{ specProvider }
+
+// Detect does not report velero when its CRDs are the only sign of it and a
+// distribution that bundles Velero is installed: those CRDs are the
+// distribution's.
+func (v veleroProvider) Detect(ctx context.Context, env Env) model.BackupDetectedTool {
+	tool := v.specProvider.Detect(ctx, env)
+	if !tool.Detected || tool.Namespace != "" {
+		return tool
+	}
+	for _, ns := range env.Bundle.Inventory.Namespaces {
+		if !standaloneVelero(ns.Name) {
+			tool.Detected = false
+			break
+		}
+	}
+	return tool
+}
+
+func (veleroProvider) Policies(ctx context.Context, env Env, _ model.BackupDetectedTool) []model.BackupPolicy {
+	var policies []model.BackupPolicy
+	for _, p := range veleroSchedules(ctx, env.Dynamic) {
+		if standaloneVelero(p.PolicyNamespace) {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+func (veleroProvider) LastBackups(ctx context.Context, env Env, policies []model.BackupPolicy) *model.BackupEvidence {
+	return veleroEvidence(ctx, env.Dynamic, env.Bundle, policies, "velero", standaloneVelero)
+}
+
+// veleroSchedules reads velero.io/v1 Schedule objects.
+func veleroSchedules(ctx context.Context, dc dynamic.Interface) []model.BackupPolicy {
+	raw, err := listRaw(ctx, dc, gvrVeleroSchedule, "")
+	if err != nil {
+		return nil
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Schedule string `json:"schedule"`
+				Template struct {
+					IncludedNamespaces []string `json:"includedNamespaces"`
+					ExcludedNamespaces []string `json:"excludedNamespaces"`
+					TTL                string   `json:"ttl"`
+					StorageLocation    string   `json:"storageLocation"`
+				} `json:"template"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+
+	var policies []model.BackupPolicy
+	for _, item := range list.Items {
+		p := model.BackupPolicy{
+			Tool:            "velero",
+			Name:            item.Metadata.Name,
+			PolicyNamespace: item.Metadata.Namespace,
+			IncludedNS:      item.Spec.Template.IncludedNamespaces,
+			ExcludedNS:      item.Spec.Template.ExcludedNamespaces,
+			Schedule:        item.Spec.Schedule,
+			RetentionTTL:    item.Spec.Template.TTL,
+			RPOHours:        scheduleRPOHours(item.Spec.Schedule),
+			StorageLocation: item.Spec.Template.StorageLocation,
+		}
+		// Non-default storage location is a strong offsite signal.
+		loc := strings.ToLower(item.Spec.Template.StorageLocation)
+		p.HasOffsite = loc != "" && loc != "default"
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+type veleroBackupList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string            `json:"name"`
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			IncludedNamespaces []string `json:"includedNamespaces"`
+			ExcludedNamespaces []string `json:"excludedNamespaces"`
+		} `json:"spec"`
+		Status struct {
+			Phase               string     `json:"phase"`
+			StartTimestamp      *time.Time `json:"startTimestamp"`
+			CompletionTimestamp *time.Time `json:"completionTimestamp"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type veleroRestoreList struct {
+	Items []struct {
+		Metadata struct {
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Status struct {
+			Phase               string     `json:"phase"`
+			CompletionTimestamp *time.Time `json:"completionTimestamp"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// veleroEvidence reads velero.io/v1 Backup and Restore objects and measures
+// what actually ran: the last Completed backup per namespace (its age is the
+// real RPO), Failed/PartiallyFailed counts, and whether any restore has ever
+// completed. PartiallyFailed backups do not count as a successful backup.
+// Backups created by a Schedule also give that policy its run history.
+// Only objects in namespaces for which owns is true are read, and they are
+// attributed to tool. Returns nil when Backups cannot be listed.
+func veleroEvidence(ctx context.Context, dc dynamic.Interface, b *model.Bundle, policies []model.BackupPolicy, tool string, owns func(ns string) bool) *model.BackupEvidence {
+	raw, err := listRaw(ctx, dc, gvrVeleroBackup, "")
+	if err != nil {
+		return nil
+	}
+	var backups veleroBackupList
+	if err := json.Unmarshal(raw, &backups); err != nil {
+		return nil
+	}
+
+	var nsNames []string
+	for _, ns := range b.Inventory.Namespaces {
+		nsNames = append(nsNames, ns.Name)
+	}
+
+	ev := &model.BackupEvidence{}
+	last := map[string]model.NamespaceBackup{}
+	type failure struct {
+		ref model.ResourceRef
+		at  time.Time
+	}
+	var failures []failure
+	var runs []policyRun
+	for _, item := range backups.Items {
+		if !owns(item.Metadata.Namespace) {
+			continue
+		}
+		ev.Backups++
+		at := item.Status.CompletionTimestamp
+		if at == nil {
+			at = item.Status.StartTimestamp
+		}
+		if sched := item.Metadata.Labels["velero.io/schedule-name"]; sched != "" && at != nil {
+			switch item.Status.Phase {
+			case "Completed", "Failed", "PartiallyFailed":
+				runs = append(runs, policyRun{policy: sched, state: item.Status.Phase, ok: item.Status.Phase == "Completed", at: *at})
+			}
+		}
+		switch item.Status.Phase {
+		case "Completed":
+			ev.Completed++
+			if at == nil {
+				continue
+			}
+			if ev.LastSuccess == nil || at.After(*ev.LastSuccess) {
+				t := *at
+				ev.LastSuccess = &t
+			}
+			for _, ns := range veleroBackupNamespaces(item.Spec.IncludedNamespaces, item.Spec.ExcludedNamespaces, nsNames) {
+				if cur, ok := last[ns]; !ok || at.After(cur.LastSuccess) {
+					last[ns] = model.NamespaceBackup{Namespace: ns, Tool: tool, Backup: item.Metadata.Name, LastSuccess: *at}
+				}
+			}
+		case "Failed", "PartiallyFailed":
+			if item.Status.Phase == "Failed" {
+				ev.Failed++
+			} else {
+				ev.PartiallyFailed++
+			}
+			f := failure{ref: model.ResourceRef{Kind: "Backup", Namespace: item.Metadata.Namespace, Name: item.Metadata.Name}}
+			if at != nil {
+				f.at = *at
+			}
+			failures = append(failures, f)
+		}
+	}
+	applyRunHistory(policies, tool, runs)
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].at.After(failures[j].at) })
+	for _, f := range failures {
+		ev.FailedBackups = append(ev.FailedBackups, f.ref)
+	}
+	ev.Namespaces = namespaceBackups(b, last)
+
+	if raw, err := listRaw(ctx, dc, gvrVeleroRestore, ""); err == nil {
+		var restores veleroRestoreList
+		if json.Unmarshal(raw, &restores) == nil {
+			for _, r := range restores.Items {
+				if !owns(r.Metadata.Namespace) {
+					continue
+				}
+				ev.Restores++
+				if r.Status.Phase != "Completed" {
+					continue
+				}
+				ev.RestoresCompleted++
+				if at := r.Status.CompletionTimestamp; at != nil && (ev.LastRestore == nil || at.After(*ev.LastRestore)) {
+					t := *at
+					ev.LastRestore = &t
+				}
+			}
+		}
+	}
+	return ev
+}
+
+// veleroBackupNamespaces expands a Backup's namespace selection against the
+// scanned namespaces. Empty included = all; both lists accept Velero's glob
+// patterns ("*", "app-*").
+func veleroBackupNamespaces(included, excluded, all []string) []string {
+	match := func(patterns []string, ns string) bool {
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, ns); ok {
+				return true
+			}
+		}
+		return false
+	}
+	var out []string
+	for _, ns := range all {
+		if len(included) > 0 && !match(included, ns) {
+			continue
+		}
+		if match(excluded, ns) {
+			continue
+		}
+		out = append(out, ns)
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// newObj builds an unstructured object for the fake dynamic client. ns is
+// empty for cluster-scoped kinds; nil labels, spec or status are left out.
+func newObj(apiVersion, kind, ns, name string, labels, spec, status map[string]interface{}) *unstructured.Unstructured {
+	meta := map[string]interface{}{"name": name}
+	if ns != "" {
+		meta["namespace"] = ns
+	}
+	if labels != nil {
+		meta["labels"] = labels
+	}
+	obj := map[string]interface{}{"apiVersion": apiVersion, "kind": kind, "metadata": meta}
+	if spec != nil {
+		obj["spec"] = spec
+	}
+	if status != nil {
+		obj["status"] = status
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestVeleroEvidence(t *testing.T) {
+	dc := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvrVeleroBackup: "BackupList", gvrVeleroRestore: "RestoreList"},
+		newObj("velero.io/v1", "Backup", "velero", "nightly-1", nil, map[string]interface{}{},
+			map[string]interface{}{"phase": "Completed", "completionTimestamp": "2026-03-01T02:00:00Z"}),
+		newObj("velero.io/v1", "Backup", "velero", "db-2", nil, map[string]interface{}{"includedNamespaces": []interface{}{"db"}},
+			map[string]interface{}{"phase": "Completed", "completionTimestamp": "2026-03-02T02:00:00Z"}),
+		newObj("velero.io/v1", "Backup", "velero", "db-3", nil, map[string]interface{}{"includedNamespaces": []interface{}{"db"}},
+			map[string]interface{}{"phase": "PartiallyFailed", "completionTimestamp": "2026-03-02T08:00:00Z"}),
+		newObj("velero.io/v1", "Backup", "velero", "nightly-2", nil, map[string]interface{}{"excludedNamespaces": []interface{}{"scratch-*"}},
+			map[string]interface{}{"phase": "Failed", "completionTimestamp": "2026-03-02T02:10:00Z"}),
+		newObj("velero.io/v1", "Restore", "velero", "drill", nil, map[string]interface{}{},
+			map[string]interface{}{"phase": "Completed", "completionTimestamp": "2026-02-20T10:00:00Z"}),
+	)
+
+	b := model.NewBundle("test", time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	b.Inventory.Namespaces = []model.Namespace{{Name: "app"}, {Name: "db"}}
+	ev := veleroEvidence(context.Background(), dc, &b, nil, "velero", standaloneVelero)
+	if ev == nil {
+		t.Fatal("veleroEvidence returned nil")
+	}
+	if ev.Backups != 4 || ev.Completed != 2 || ev.Failed != 1 || ev.PartiallyFailed != 1 {
+		t.Errorf("counts = %d/%d/%d/%d, want 4 backups, 2 completed, 1 failed, 1 partial",
+			ev.Backups, ev.Completed, ev.Failed, ev.PartiallyFailed)
+	}
+	if len(ev.FailedBackups) != 2 || ev.FailedBackups[0].Name != "db-3" {
+		t.Errorf("FailedBackups = %v, want db-3 first", ev.FailedBackups)
+	}
+	want := map[string]float64{"app": 34, "db": 10}
+	if len(ev.Namespaces) != len(want) {
+		t.Fatalf("Namespaces = %+v", ev.Namespaces)
+	}
+	for _, nb := range ev.Namespaces {
+		if nb.AgeHours != want[nb.Namespace] {
+			t.Errorf("%s age = %.1fh, want %.1fh", nb.Namespace, nb.AgeHours, want[nb.Namespace])
+		}
+	}
+	if ev.RestoresCompleted != 1 || ev.LastRestore == nil {
+		t.Errorf("restores = %d completed, last %v; want the drill restore", ev.RestoresCompleted, ev.LastRestore)
+	}
+}
@@ -0,0 +1,247 @@
+// Package capacity checks whether a DR site has room to run the protected
+// workloads after a failover.
+package capacity
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	"k8s-recovery-visualizer/internal/kutil"
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// defaultPodsPerNode is the kubelet's default maxPods, used for node pools
+// that do not set pods.
+const defaultPodsPerNode = 110
+
+// Site is the DR site: its name and the nodes workloads would be scheduled
+// on. Only the nodes' allocatable fields are used.
+type Site struct {
+	Name string
+	// Source is "spec" for a --dr-capacity file, "scan" for a target cluster.
+	Source string
+	Nodes  []model.Node
+}
+
+// siteFile is the --dr-capacity document.
+type siteFile struct {
+	Name  string     `json:"name,omitempty"`
+	Nodes []nodePool `json:"nodes"`
+}
+
+// nodePool is count identical nodes. CPU and memory are allocatable
+// quantities ("7500m", "30Gi"), i.e. what is left after system reservations.
+type nodePool struct {
+	Name   string `json:"name,omitempty"`
+	Count  int    `json:"count"`
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+	Pods   int64  `json:"pods,omitempty"`
+}
+
+// LoadSite reads a --dr-capacity YAML (or JSON) file describing the DR
+// site's nodes:
+//
+//	name: dr-west
+//	nodes:
+//	  - name: workers
+//	    count: 3
+//	    cpu: "8"
+//	    memory: 30Gi
+//	    pods: 110
+//
+// pods defaults to 110 per node.
+func LoadSite(file string) (*Site, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("dr capacity: %w", err)
+	}
+	var f siteFile
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("dr capacity %s: %w", file, err)
+	}
+	if len(f.Nodes) == 0 {
+		return nil, fmt.Errorf("dr capacity %s: no nodes", file)
+	}
+	site := &Site{Name: f.Name, Source: "spec"}
+	if site.Name == "" {
+		site.Name = file
+	}
+	for i, p := range f.Nodes {
+		if p.Name == "" {
+			p.Name = "pool-" + strconv.Itoa(i+1)
+		}
+		if p.Count <= 0 {
+			return nil, fmt.Errorf("dr capacity %s: node pool %s: count must be positive", file, p.Name)
+		}
+		cpu, err := resource.ParseQuantity(p.CPU)
+		if err != nil || cpu.Sign() <= 0 {
+			return nil, fmt.Errorf("dr capacity %s: node pool %s: invalid cpu %q", file, p.Name, p.CPU)
+		}
+		mem, err := resource.ParseQuantity(p.Memory)
+		if err != nil || mem.Sign() <= 0 {
+			return nil, fmt.Errorf("dr capacity %s: node pool %s: invalid memory %q", file, p.Name, p.Memory)
+		}
+		if p.Pods < 0 {
+			return nil, fmt.Errorf("dr capacity %s: node pool %s: pods must not be negative", file, p.Name)
+		}
+		if p.Pods == 0 {
+			p.Pods = defaultPodsPerNode
+		}
+		for n := 1; n <= p.Count; n++ {
+			site.Nodes = append(site.Nodes, model.Node{
+				Name:                   fmt.Sprintf("%s-%d", p.Name, n),
+				Ready:                  true,
+				AllocatableCPUMillis:   cpu.MilliValue(),
+				AllocatableMemoryBytes: mem.Value(),
+				AllocatablePods:        p.Pods,
+			})
+		}
+	}
+	return site, nil
+}
+
+// SiteFromBundle uses the Ready nodes of target, a scan of the DR cluster
+// named name, as the DR site.
+func SiteFromBundle(target *model.Bundle, name string) *Site {
+	site := &Site{Name: name, Source: "scan"}
+	for _, n := range target.Inventory.Nodes {
+		if n.Ready {
+			site.Nodes = append(site.Nodes, n)
+		}
+	}
+	return site
+}
+
+// Check compares what the protected workloads in b request with what site
+// can allocate. A namespace is protected when a backup policy covers it;
+// its running and pending pods are what a failover has to schedule.
+// Namespaces are taken in the restore simulation's order, so each one's
+// headroom is what is left once it and everything restored before it run.
+//
+// DaemonSet pods are counted as they run on the source, not once per DR
+// node, and pods without requests count as zero.
+func Check(b *model.Bundle, site *Site) model.CapacityReport {
+	r := model.CapacityReport{
+		Site:       site.Name,
+		Source:     site.Source,
+		Namespaces: []model.CapacityNamespace{},
+	}
+	podsKnown := true
+	for _, n := range site.Nodes {
+		r.Nodes++
+		r.CPUMillis += n.AllocatableCPUMillis
+		r.MemoryBytes += n.AllocatableMemoryBytes
+		r.Pods += n.AllocatablePods
+		podsKnown = podsKnown && n.AllocatablePods > 0
+	}
+	if !podsKnown {
+		r.Pods = 0
+	}
+
+	byNS := map[string]*model.CapacityNamespace{}
+	for _, p := range b.Inventory.Pods {
+		if p.Phase == "Succeeded" || p.Phase == "Failed" || kutil.SystemNamespace(p.Namespace) {
+			continue
+		}
+		ns := byNS[p.Namespace]
+		if ns == nil {
+			ns = &model.CapacityNamespace{Namespace: p.Namespace, Protected: protected(b, p.Namespace)}
+			byNS[p.Namespace] = ns
+		}
+		ns.Pods++
+		ns.CPUMillis += p.RequestsCPUMillis
+		ns.MemoryBytes += p.RequestsMemoryBytes
+		if p.RequestsCPUMillis == 0 || p.RequestsMemoryBytes == 0 {
+			ns.PodsWithoutRequests++
+		}
+		if ns.Protected && len(site.Nodes) > 0 && !fitsAnyNode(p, site.Nodes) {
+			r.Oversized = append(r.Oversized, model.ResourceRef{Kind: "Pod", Namespace: p.Namespace, Name: p.Name})
+		}
+	}
+
+	order := restoreOrder(b)
+	rank := func(ns string) int {
+		if o, ok := order[ns]; ok {
+			return o
+		}
+		return len(order) + 1
+	}
+	for _, ns := range byNS {
+		r.Namespaces = append(r.Namespaces, *ns)
+	}
+	sort.Slice(r.Namespaces, func(i, j int) bool {
+		a, c := r.Namespaces[i], r.Namespaces[j]
+		if a.Protected != c.Protected {
+			return a.Protected
+		}
+		if ra, rc := rank(a.Namespace), rank(c.Namespace); ra != rc {
+			return ra < rc
+		}
+		return a.Namespace < c.Namespace
+	})
+
+	for i := range r.Namespaces {
+		ns := &r.Namespaces[i]
+		if !ns.Protected {
+			continue
+		}
+		r.RequiredCPUMillis += ns.CPUMillis
+		r.RequiredMemoryBytes += ns.MemoryBytes
+		r.RequiredPods += ns.Pods
+		r.PodsWithoutRequests += ns.PodsWithoutRequests
+		ns.HeadroomCPUMillis = r.CPUMillis - r.RequiredCPUMillis
+		ns.HeadroomMemoryBytes = r.MemoryBytes - r.RequiredMemoryBytes
+		if r.Pods > 0 {
+			ns.HeadroomPods = r.Pods - int64(r.RequiredPods)
+		}
+		ns.Fits = ns.HeadroomCPUMillis >= 0 && ns.HeadroomMemoryBytes >= 0 && ns.HeadroomPods >= 0
+	}
+
+	if r.RequiredCPUMillis > r.CPUMillis {
+		r.Short = append(r.Short, "cpu")
+	}
+	if r.RequiredMemoryBytes > r.MemoryBytes {
+		r.Short = append(r.Short, "memory")
+	}
+	if r.Pods > 0 && int64(r.RequiredPods) > r.Pods {
+		r.Short = append(r.Short, "pods")
+	}
+	sort.Slice(r.Oversized, func(i, j int) bool { return r.Oversized[i].String() < r.Oversized[j].String() })
+	return r
+}
+
+// protected reports whether a backup tool protects namespace ns.
+func protected(b *model.Bundle, ns string) bool {
+	_, ok := b.Inventory.Backup.NamespaceCoverage(ns)
+	return ok
+}
+
+// restoreOrder maps namespaces to their place in the simulated full-cluster
+// recovery.
+func restoreOrder(b *model.Bundle) map[string]int {
+	out := map[string]int{}
+	if sim := b.Inventory.Backup.RestoreSim; sim != nil {
+		for _, ns := range sim.Namespaces {
+			if ns.RestoreOrder > 0 {
+				out[ns.Namespace] = ns.RestoreOrder
+			}
+		}
+	}
+	return out
+}
+
+// fitsAnyNode reports whether a single node can hold p's requests.
+func fitsAnyNode(p model.Pod, nodes []model.Node) bool {
+	for _, n := range nodes {
+		if p.RequestsCPUMillis <= n.AllocatableCPUMillis && p.RequestsMemoryBytes <= n.AllocatableMemoryBytes {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package capacity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestLoadSite(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "dr.yaml")
+	if err := os.WriteFile(file, []byte(`name: dr-west
+nodes:
+  - name: workers
+    count: 2
+    cpu: "4"
+    memory: 16Gi
+  - count: 1
+    cpu: 7500m
+    memory: 64Gi
+    pods: 30
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	site, err := LoadSite(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site.Name != "dr-west" || len(site.Nodes) != 3 {
+		t.Fatalf("site = %s with %d nodes; want dr-west with 3", site.Name, len(site.Nodes))
+	}
+	if n := site.Nodes[1]; n.Name != "workers-2" || n.AllocatableCPUMillis != 4000 || n.AllocatableMemoryBytes != 16<<30 || n.AllocatablePods != 110 {
+		t.Errorf("workers-2 = %+v", n)
+	}
+	if n := site.Nodes[2]; n.Name != "pool-2-1" || n.AllocatableCPUMillis != 7500 || n.AllocatablePods != 30 {
+		t.Errorf("pool-2-1 = %+v", n)
+	}
+
+	if err := os.WriteFile(file, []byte("nodes:\n  - count: 1\n    cpu: lots\n    memory: 1Gi\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSite(file); err == nil {
+		t.Error("invalid cpu quantity accepted")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	b := model.NewBundle("src", time.Now())
+	b.Inventory.Backup.Protection = []model.NamespaceProtection{
+		{Namespace: "db", Tools: []string{"velero"}},
+		{Namespace: "web", Tools: []string{"velero"}},
+		{Namespace: "batch"},
+	}
+	b.Inventory.Backup.RestoreSim = &model.RestoreSimResult{Namespaces: []model.RestoreSimNamespace{
+		{Namespace: "web", RestoreOrder: 2},
+		{Namespace: "db", RestoreOrder: 1},
+	}}
+	b.Inventory.Pods = []model.Pod{
+		{Namespace: "db", Name: "pg-0", Phase: "Running", RequestsCPUMillis: 3000, RequestsMemoryBytes: 12 << 30},
+		{Namespace: "web", Name: "shop-1", Phase: "Running", RequestsCPUMillis: 2000, RequestsMemoryBytes: 4 << 30},
+		{Namespace: "web", Name: "shop-2", Phase: "Pending"},
+		{Namespace: "web", Name: "migrate", Phase: "Succeeded", RequestsCPUMillis: 8000},
+		{Namespace: "batch", Name: "report", Phase: "Running", RequestsCPUMillis: 6000, RequestsMemoryBytes: 1 << 30},
+		{Namespace: "kube-system", Name: "coredns", Phase: "Running", RequestsCPUMillis: 100},
+	}
+	site := &Site{Name: "dr", Source: "spec", Nodes: []model.Node{
+		{Name: "a", AllocatableCPUMillis: 2000, AllocatableMemoryBytes: 8 << 30, AllocatablePods: 10},
+		{Name: "b", AllocatableCPUMillis: 2000, AllocatableMemoryBytes: 8 << 30, AllocatablePods: 10},
+	}}
+
+	r := Check(&b, site)
+	if r.CPUMillis != 4000 || r.MemoryBytes != 16<<30 || r.Pods != 20 {
+		t.Errorf("site totals %d/%d/%d; want 4000m, 16Gi, 20 pods", r.CPUMillis, r.MemoryBytes, r.Pods)
+	}
+	if r.RequiredCPUMillis != 5000 || r.RequiredMemoryBytes != 16<<30 || r.RequiredPods != 3 || r.PodsWithoutRequests != 1 {
+		t.Errorf("required %+v; want db and web only, without the completed job", r)
+	}
+	if len(r.Short) != 1 || r.Short[0] != "cpu" {
+		t.Errorf("short = %v; want [cpu]", r.Short)
+	}
+	if len(r.Oversized) != 1 || r.Oversized[0].Name != "pg-0" {
+		t.Errorf("oversized = %v; want db/pg-0", r.Oversized)
+	}
+
+	var order []string
+	for _, ns := range r.Namespaces {
+		order = append(order, ns.Namespace)
+	}
+	if len(order) != 3 || order[0] != "db" || order[1] != "web" || order[2] != "batch" {
+		t.Fatalf("namespaces %v; want restore order db, web, then unprotected batch", order)
+	}
+	db, web, batch := r.Namespaces[0], r.Namespaces[1], r.Namespaces[2]
+	if !db.Fits || db.HeadroomCPUMillis != 1000 || db.HeadroomPods != 19 {
+		t.Errorf("db = %+v; want it to fit with 1 core and 19 pods left", db)
+	}
+	if web.Fits || web.HeadroomCPUMillis != -1000 || web.HeadroomMemoryBytes != 0 {
+		t.Errorf("web = %+v; want 1 core short, memory exactly used", web)
+	}
+	if batch.Protected || batch.Fits || batch.HeadroomCPUMillis != 0 {
+		t.Errorf("batch = %+v; want unprotected with no headroom", batch)
+	}
+}
@@ -5,10 +5,18 @@ import (
 	"encoding/json"
 	"time"
 
-	"k8s.io/client-go/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s-recovery-visualizer/internal/model"
 )
 
+var gvrCertificate = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
 type certList struct {
 	Items []struct {
 		Metadata struct {
@@ -34,15 +42,16 @@ type certList struct {
 
 // Certificates collects cert-manager Certificate resources.
 // Returns nil (non-fatal) if cert-manager is not installed.
-func Certificates(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
-	raw, err := cs.RESTClient().
-		Get().
-		AbsPath("/apis/cert-manager.io/v1/certificates").
-		DoRaw(ctx)
+func Certificates(ctx context.Context, dc dynamic.Interface, b *model.Bundle) error {
+	list, err := dc.Resource(gvrCertificate).Namespace("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		// cert-manager not installed or not accessible — non-fatal
 		return nil
 	}
+	raw, err := list.MarshalJSON()
+	if err != nil {
+		return nil // non-fatal encode failure
+	}
 
 	var cl certList
 	if err := json.Unmarshal(raw, &cl); err != nil {
@@ -2,6 +2,7 @@ package collect
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	"k8s.io/client-go/kubernetes"
@@ -38,12 +39,15 @@ var nativeGroups = map[string]struct{}{
 // CRDs detects installed CustomResourceDefinitions via the discovery API.
 // It identifies non-native API groups as CRDs without requiring the
 // apiextensions-apiserver package.
-func CRDs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func CRDs(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	groups, err := cs.Discovery().ServerGroups()
 	if err != nil {
 		return err
 	}
 
+	// Discovery order is not guaranteed; sort so repeated scans diff cleanly.
+	sort.Slice(groups.Groups, func(i, j int) bool { return groups.Groups[i].Name < groups.Groups[j].Name })
+
 	seen := map[string]struct{}{}
 	for _, g := range groups.Groups {
 		if _, native := nativeGroups[g.Name]; native {
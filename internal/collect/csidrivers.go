@@ -0,0 +1,22 @@
+package collect
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// CSIDrivers collects the registered storage.k8s.io/v1 CSIDrivers.
+func CSIDrivers(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
+	list, err := cs.StorageV1().CSIDrivers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, d := range list.Items {
+		b.Inventory.CSIDrivers = append(b.Inventory.CSIDrivers, model.CSIDriver{Name: d.Name})
+	}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func DaemonSets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func DaemonSets(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -27,6 +27,8 @@ func DaemonSets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle)
 			Desired:   ds.Status.DesiredNumberScheduled,
 			Ready:     ds.Status.NumberReady,
 			Images:    images,
+
+			WorkloadRefs: workloadRefs(ds.ObjectMeta, ds.Spec.Template),
 		})
 	}
 	return nil
@@ -8,7 +8,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func Deployments(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func Deployments(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -34,6 +34,8 @@ func Deployments(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle)
 			Replicas:  desired,
 			Ready:     d.Status.ReadyReplicas,
 			Images:    images,
+
+			WorkloadRefs: workloadRefs(d.ObjectMeta, d.Spec.Template),
 		})
 	}
 	return nil
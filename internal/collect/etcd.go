@@ -16,7 +16,7 @@ import (
 //  3. Velero Backup resource that covers cluster-scoped resources (heuristic: no includedNamespaces).
 //
 // The result is stored in bundle.Inventory.EtcdBackup.
-func EtcdBackup(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func EtcdBackup(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	// 1. Skip for provider-managed clusters where etcd is not operator-visible.
 	provider := strings.ToLower(b.Cluster.Platform.Provider)
 	if provider == "eks" || provider == "aks" || provider == "gke" || provider == "rancher" {
@@ -4,7 +4,13 @@ import "k8s-recovery-visualizer/internal/model"
 
 // InScope returns true when ns is within the scan's namespace scope.
 // If b.ScanNamespaces is empty, all namespaces are in scope.
+// Namespaces in b.ExcludeNamespaces are never in scope.
 func InScope(ns string, b *model.Bundle) bool {
+	for _, n := range b.ExcludeNamespaces {
+		if n == ns {
+			return false
+		}
+	}
 	if len(b.ScanNamespaces) == 0 {
 		return true
 	}
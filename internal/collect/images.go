@@ -22,7 +22,7 @@ var publicRegistries = map[string]struct{}{
 
 // Images builds a unique container image inventory from already-collected workloads.
 // No additional K8s API calls are made.
-func Images(_ context.Context, _ *kubernetes.Clientset, b *model.Bundle) error {
+func Images(_ context.Context, _ kubernetes.Interface, b *model.Bundle) error {
 	type imgEntry struct {
 		registry  string
 		isPublic  bool
@@ -58,8 +58,9 @@ func Images(_ context.Context, _ *kubernetes.Clientset, b *model.Bundle) error {
 	}
 	for _, sts := range b.Inventory.StatefulSets {
 		ref := sts.Namespace + "/" + sts.Name
-		// StatefulSet model doesn't carry images directly; skip for now
-		_ = ref
+		for _, img := range sts.Images {
+			addImage(img, ref)
+		}
 	}
 
 	for img, entry := range seen {
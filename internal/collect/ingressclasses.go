@@ -0,0 +1,27 @@
+package collect
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// IngressClasses collects networking.k8s.io/v1 IngressClasses and which one
+// is the cluster default.
+func IngressClasses(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
+	list, err := cs.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, ic := range list.Items {
+		b.Inventory.IngressClasses = append(b.Inventory.IngressClasses, model.IngressClass{
+			Name:       ic.Name,
+			Controller: ic.Spec.Controller,
+			Default:    ic.Annotations["ingressclass.kubernetes.io/is-default-class"] == "true",
+		})
+	}
+	return nil
+}
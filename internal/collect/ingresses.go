@@ -9,7 +9,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func Ingresses(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func Ingresses(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -23,6 +23,12 @@ func Ingresses(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) e
 		if ing.Spec.IngressClassName != nil {
 			className = *ing.Spec.IngressClassName
 		}
+		var tlsSecrets []string
+		for _, t := range ing.Spec.TLS {
+			if t.SecretName != "" {
+				tlsSecrets = append(tlsSecrets, t.SecretName)
+			}
+		}
 		var rules []model.IngressRule
 		for _, r := range ing.Spec.Rules {
 			backend := ""
@@ -43,6 +49,9 @@ func Ingresses(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) e
 			ClassName: className,
 			TLS:       hasTLS,
 			Rules:     rules,
+
+			TLSSecrets:  tlsSecrets,
+			HelmRelease: helmRelease(ing.ObjectMeta),
 		})
 	}
 	return nil
@@ -8,7 +8,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func Jobs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func Jobs(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.BatchV1().Jobs("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -30,7 +30,7 @@ func Jobs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error
 	return nil
 }
 
-func CronJobs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func CronJobs(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.BatchV1().CronJobs("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -9,7 +9,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func LimitRanges(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func LimitRanges(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().LimitRanges("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
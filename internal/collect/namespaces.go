@@ -2,13 +2,14 @@ package collect
 
 import (
 	"context"
+	"strings"
 
 	"k8s-recovery-visualizer/internal/model"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
-func Namespaces(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func Namespaces(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -19,12 +20,23 @@ func Namespaces(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle)
 			continue
 		}
 		labels := ns.Labels
+		var annotations map[string]string
+		for k, v := range ns.Annotations {
+			if strings.HasPrefix(k, model.AnnotationPrefix) {
+				if annotations == nil {
+					annotations = map[string]string{}
+				}
+				annotations[k] = v
+			}
+		}
 		b.Inventory.Namespaces = append(b.Inventory.Namespaces, model.Namespace{
-			ID:         "ns:" + ns.Name,
-			Name:       ns.Name,
-			PSAEnforce: labels["pod-security.kubernetes.io/enforce"],
-			PSAWarn:    labels["pod-security.kubernetes.io/warn"],
-			PSAAudit:   labels["pod-security.kubernetes.io/audit"],
+			ID:          "ns:" + ns.Name,
+			Name:        ns.Name,
+			PSAEnforce:  labels["pod-security.kubernetes.io/enforce"],
+			PSAWarn:     labels["pod-security.kubernetes.io/warn"],
+			PSAAudit:    labels["pod-security.kubernetes.io/audit"],
+			Labels:      labels,
+			Annotations: annotations,
 		})
 	}
 	return nil
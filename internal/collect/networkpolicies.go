@@ -9,7 +9,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func NetworkPolicies(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func NetworkPolicies(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
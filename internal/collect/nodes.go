@@ -86,5 +86,16 @@ func toModelNode(n *v1.Node) model.Node {
     m.Taints = append(m.Taints, t.Key+"="+t.Value+":"+string(t.Effect))
   }
 
+  // Allocatable
+  if q, ok := n.Status.Allocatable[v1.ResourceCPU]; ok {
+    m.AllocatableCPUMillis = q.MilliValue()
+  }
+  if q, ok := n.Status.Allocatable[v1.ResourceMemory]; ok {
+    m.AllocatableMemoryBytes = q.Value()
+  }
+  if q, ok := n.Status.Allocatable[v1.ResourcePods]; ok {
+    m.AllocatablePods = q.Value()
+  }
+
   return m
 }
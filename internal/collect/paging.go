@@ -0,0 +1,52 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PageSize is the Limit sent on each List call made through listPaged.
+// Large clusters (50k+ pods, thousands of Helm release secrets) are read in
+// chunks of this size so only one page of full API objects is in memory at a
+// time. 0 disables paging and lists everything in one call.
+var PageSize int64 = 500
+
+// pagedList is satisfied by every typed *XxxList (via the embedded ListMeta).
+type pagedList interface {
+	GetContinue() string
+}
+
+// listPaged calls list with Limit/Continue until the server reports no more
+// pages, handing each page to visit before fetching the next. visit should
+// convert what it needs into model types and keep no reference to the page.
+//
+//	err := listPaged(ctx, metav1.ListOptions{}, cs.CoreV1().Pods("").List, func(page *corev1.PodList) {
+//		for i := range page.Items { ... }
+//	})
+//
+// Servers (and the offline fake clientset) that ignore Limit return a single
+// page with an empty continue token, which ends the loop after one call.
+func listPaged[L pagedList](ctx context.Context, opts metav1.ListOptions, list func(context.Context, metav1.ListOptions) (L, error), visit func(L)) error {
+	opts.Limit = PageSize
+	opts.Continue = ""
+	for {
+		page, err := list(ctx, opts)
+		if err != nil {
+			if apierrors.IsResourceExpired(err) {
+				// The continue token outlived etcd compaction; earlier pages were
+				// already consumed, so restarting would double-count items.
+				return fmt.Errorf("list expired mid-pagination (try a larger --page-size): %w", err)
+			}
+			return err
+		}
+		visit(page)
+		next := page.GetContinue()
+		if next == "" {
+			return nil
+		}
+		opts.Continue = next
+	}
+}
@@ -0,0 +1,55 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListPaged(t *testing.T) {
+	old := PageSize
+	PageSize = 2
+	defer func() { PageSize = old }()
+
+	all := []string{"a", "b", "c", "d", "e"}
+	calls := 0
+	list := func(_ context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+		calls++
+		if opts.Limit != 2 {
+			return nil, fmt.Errorf("limit = %d, want 2", opts.Limit)
+		}
+		start := 0
+		if opts.Continue != "" {
+			start, _ = strconv.Atoi(opts.Continue)
+		}
+		end := min(start+int(opts.Limit), len(all))
+		page := &corev1.PodList{}
+		for _, n := range all[start:end] {
+			page.Items = append(page.Items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: n}})
+		}
+		if end < len(all) {
+			page.Continue = strconv.Itoa(end)
+		}
+		return page, nil
+	}
+
+	var got []string
+	err := listPaged(context.Background(), metav1.ListOptions{}, list, func(page *corev1.PodList) {
+		for i := range page.Items {
+			got = append(got, page.Items[i].Name)
+		}
+	})
+	if err != nil {
+		t.Fatalf("listPaged: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(all) {
+		t.Errorf("got %v, want %v", got, all)
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func PodDisruptionBudgets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func PodDisruptionBudgets(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -9,7 +9,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func Platform(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func Platform(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	platform := model.Platform{
 		Provider: "vanilla",
 	}
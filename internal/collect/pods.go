@@ -2,6 +2,7 @@ package collect
 
 import (
 	"context"
+	"strings"
 
 	"k8s-recovery-visualizer/internal/model"
 	corev1 "k8s.io/api/core/v1"
@@ -9,31 +10,34 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func Pods(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
-
-	list, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-
-	for _, pod := range list.Items {
-		if !InScope(pod.Namespace, b) {
-			continue
+// Pods lists pods page by page and converts each one to model.Pod as it
+// arrives, so the full corev1 objects for a large cluster are never held at once.
+func Pods(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
+	return listPaged(ctx, metav1.ListOptions{}, cs.CoreV1().Pods("").List, func(page *corev1.PodList) {
+		for i := range page.Items {
+			pod := &page.Items[i]
+			if !InScope(pod.Namespace, b) {
+				continue
+			}
+			b.Inventory.Pods = append(b.Inventory.Pods, toModelPod(pod))
 		}
+	})
+}
 
-		usesHostPath := false
-		for _, vol := range pod.Spec.Volumes {
-			if vol.HostPath != nil {
-				usesHostPath = true
-				break
-			}
+func toModelPod(pod *corev1.Pod) model.Pod {
+	usesHostPath := false
+	for _, vol := range pod.Spec.Volumes {
+		if vol.HostPath != nil {
+			usesHostPath = true
+			break
 		}
+	}
 
-		// Round 11 — resource governance: check every container (including init)
-		allContainers := append(pod.Spec.Containers, pod.Spec.InitContainers...)
-		allHaveRequests := true
-		allHaveLimits := true
-		for _, c := range allContainers {
+	// Round 11 — resource governance: check every container (including init)
+	allHaveRequests := true
+	allHaveLimits := true
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, c := range containers {
 			if !containerHasRequests(c) {
 				allHaveRequests = false
 			}
@@ -41,36 +45,84 @@ func Pods(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error
 				allHaveLimits = false
 			}
 		}
+	}
 
-		// Round 12 — pod security: privileged containers
-		hasPrivileged := false
-		for _, c := range pod.Spec.Containers {
-			if c.SecurityContext != nil &&
-				c.SecurityContext.Privileged != nil &&
-				*c.SecurityContext.Privileged {
-				hasPrivileged = true
-				break
-			}
+	// Round 12 — pod security: privileged containers
+	hasPrivileged := false
+	for _, c := range pod.Spec.Containers {
+		if c.SecurityContext != nil &&
+			c.SecurityContext.Privileged != nil &&
+			*c.SecurityContext.Privileged {
+			hasPrivileged = true
+			break
 		}
+	}
+
+	// Round 18 — ServiceAccount token: automount enabled when field is nil (default) or explicitly true
+	automount := pod.Spec.AutomountServiceAccountToken == nil || *pod.Spec.AutomountServiceAccountToken
 
-		// Round 18 — ServiceAccount token: automount enabled when field is nil (default) or explicitly true
-		automount := pod.Spec.AutomountServiceAccountToken == nil || *pod.Spec.AutomountServiceAccountToken
+	cpu, mem := podRequests(pod)
+	ownerKind, ownerName := podOwner(pod)
 
-		b.Inventory.Pods = append(b.Inventory.Pods, model.Pod{
-			Namespace:        pod.Namespace,
-			Name:             pod.Name,
-			UsesHostPath:     usesHostPath,
-			ContainerCount:   len(pod.Spec.Containers),
-			HasRequests:      allHaveRequests,
-			HasLimits:        allHaveLimits,
-			Privileged:       hasPrivileged,
-			HostNetwork:      pod.Spec.HostNetwork,
-			HostPID:          pod.Spec.HostPID,
-			AutomountSAToken: automount,
-		})
+	return model.Pod{
+		Namespace:        pod.Namespace,
+		Name:             pod.Name,
+		UsesHostPath:     usesHostPath,
+		OwnerKind:        ownerKind,
+		OwnerName:        ownerName,
+		ContainerCount:   len(pod.Spec.Containers),
+		HasRequests:      allHaveRequests,
+		HasLimits:        allHaveLimits,
+		Privileged:       hasPrivileged,
+		HostNetwork:      pod.Spec.HostNetwork,
+		HostPID:          pod.Spec.HostPID,
+		AutomountSAToken: automount,
+
+		Phase:               string(pod.Status.Phase),
+		RequestsCPUMillis:   cpu,
+		RequestsMemoryBytes: mem,
 	}
+}
 
-	return nil
+// podOwner returns the workload controlling pod. A ReplicaSet created by a
+// Deployment is named after it plus the pod-template-hash label, so the
+// Deployment is resolved without listing ReplicaSets.
+func podOwner(pod *corev1.Pod) (kind, name string) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "", ""
+	}
+	if ref.Kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(ref.Name, "-"+hash) {
+			return "Deployment", strings.TrimSuffix(ref.Name, "-"+hash)
+		}
+	}
+	return ref.Kind, ref.Name
+}
+
+// podRequests returns the CPU (millicores) and memory (bytes) the scheduler
+// reserves for pod: the larger of its containers' summed requests and its
+// biggest init container, with sidecars (restartable init containers) and
+// pod overhead added on.
+func podRequests(pod *corev1.Pod) (cpuMillis, memBytes int64) {
+	var sidecarCPU, sidecarMem, initCPU, initMem int64
+	for _, c := range pod.Spec.InitContainers {
+		cpu, mem := c.Resources.Requests.Cpu().MilliValue(), c.Resources.Requests.Memory().Value()
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecarCPU += cpu
+			sidecarMem += mem
+			continue
+		}
+		initCPU = max(initCPU, sidecarCPU+cpu)
+		initMem = max(initMem, sidecarMem+mem)
+	}
+	for _, c := range pod.Spec.Containers {
+		cpuMillis += c.Resources.Requests.Cpu().MilliValue()
+		memBytes += c.Resources.Requests.Memory().Value()
+	}
+	cpuMillis = max(cpuMillis+sidecarCPU, initCPU) + pod.Spec.Overhead.Cpu().MilliValue()
+	memBytes = max(memBytes+sidecarMem, initMem) + pod.Spec.Overhead.Memory().Value()
+	return cpuMillis, memBytes
 }
 
 // containerHasRequests returns true when the container defines non-zero CPU and memory requests.
@@ -9,7 +9,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func PVCs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func PVCs(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -31,6 +31,7 @@ func PVCs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error
 			StorageClass:  deref(pvc.Spec.StorageClassName),
 			AccessModes:   accessModesToStrings(pvc.Spec.AccessModes),
 			RequestedSize: size,
+			HelmRelease:   helmRelease(pvc.ObjectMeta),
 		})
 	}
 
@@ -9,7 +9,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func PVs(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func PVs(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
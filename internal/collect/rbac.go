@@ -10,7 +10,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func ClusterRoles(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func ClusterRoles(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -73,7 +73,7 @@ func ClusterRoles(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle
 	return nil
 }
 
-func ClusterRoleBindings(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func ClusterRoleBindings(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -0,0 +1,121 @@
+package collect
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// helmRelease returns the Helm release an object belongs to: the
+// meta.helm.sh/release-name annotation Helm 3 sets, else the
+// app.kubernetes.io/instance label on objects managed by Helm.
+func helmRelease(meta metav1.ObjectMeta) string {
+	if r := meta.Annotations["meta.helm.sh/release-name"]; r != "" {
+		return r
+	}
+	if meta.Labels["app.kubernetes.io/managed-by"] == "Helm" {
+		return meta.Labels["app.kubernetes.io/instance"]
+	}
+	return ""
+}
+
+// workloadRefs collects what restoring a workload depends on: the
+// ConfigMaps, Secrets and PVCs its pod template uses, its owners and its
+// Helm release.
+func workloadRefs(meta metav1.ObjectMeta, tmpl corev1.PodTemplateSpec) model.WorkloadRefs {
+	cms, secrets, pvcs := map[string]struct{}{}, map[string]struct{}{}, map[string]struct{}{}
+	add := func(set map[string]struct{}, name string) {
+		if name != "" {
+			set[name] = struct{}{}
+		}
+	}
+	spec := tmpl.Spec
+	for _, v := range spec.Volumes {
+		switch {
+		case v.ConfigMap != nil:
+			add(cms, v.ConfigMap.Name)
+		case v.Secret != nil:
+			add(secrets, v.Secret.SecretName)
+		case v.PersistentVolumeClaim != nil:
+			add(pvcs, v.PersistentVolumeClaim.ClaimName)
+		case v.Projected != nil:
+			for _, src := range v.Projected.Sources {
+				if src.ConfigMap != nil {
+					add(cms, src.ConfigMap.Name)
+				}
+				if src.Secret != nil {
+					add(secrets, src.Secret.Name)
+				}
+			}
+		}
+	}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, ef := range c.EnvFrom {
+			if ef.ConfigMapRef != nil {
+				add(cms, ef.ConfigMapRef.Name)
+			}
+			if ef.SecretRef != nil {
+				add(secrets, ef.SecretRef.Name)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if r := e.ValueFrom.ConfigMapKeyRef; r != nil {
+				add(cms, r.Name)
+			}
+			if r := e.ValueFrom.SecretKeyRef; r != nil {
+				add(secrets, r.Name)
+			}
+		}
+	}
+	for _, ps := range spec.ImagePullSecrets {
+		add(secrets, ps.Name)
+	}
+
+	refs := model.WorkloadRefs{
+		PodLabels:   tmpl.Labels,
+		AppLabels:   appLabels(meta.Labels),
+		ConfigMaps:  sortedKeys(cms),
+		Secrets:     sortedKeys(secrets),
+		PVCs:        sortedKeys(pvcs),
+		HelmRelease: helmRelease(meta),
+	}
+	for _, o := range meta.OwnerReferences {
+		refs.Owners = append(refs.Owners, model.OwnerRef{APIVersion: o.APIVersion, Kind: o.Kind, Name: o.Name})
+	}
+	return refs
+}
+
+// appLabels returns the app.kubernetes.io/* labels, or nil when there are
+// none.
+func appLabels(labels map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range labels {
+		if strings.HasPrefix(k, "app.kubernetes.io/") {
+			if out == nil {
+				out = map[string]string{}
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
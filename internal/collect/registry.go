@@ -0,0 +1,282 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Collector is one named step in the collection phase.
+type Collector struct {
+	Name string
+	// Required collectors feed the core inventory; if one fails the scan aborts.
+	// Any other failure is recorded as a CollectorSkip and the scan continues.
+	Required bool
+	// After lists collectors whose output this one reads from the bundle.
+	// It only starts once all of them have finished (successfully or not).
+	After []string
+	Run   func(ctx context.Context, b *model.Bundle) error
+	// Count reports how many items the collector added, for CollectorRuns.
+	Count func(b *model.Bundle) int
+}
+
+// RunOptions controls how Run schedules collectors.
+type RunOptions struct {
+	// Parallelism bounds how many collectors run at once. Values < 1 mean 1.
+	Parallelism int
+	// Timeout is the deadline given to each collector individually. 0 = none.
+	Timeout time.Duration
+	// Progress, when set, is called after each collector finishes.
+	Progress func(done, total int, run model.CollectorRun)
+}
+
+// Registry returns the standard set of collectors, in report order.
+func Registry(cs kubernetes.Interface, dc dynamic.Interface) []Collector {
+	typed := func(fn func(context.Context, kubernetes.Interface, *model.Bundle) error) func(context.Context, *model.Bundle) error {
+		return func(ctx context.Context, b *model.Bundle) error { return fn(ctx, cs, b) }
+	}
+	dyn := func(fn func(context.Context, dynamic.Interface, *model.Bundle) error) func(context.Context, *model.Bundle) error {
+		return func(ctx context.Context, b *model.Bundle) error { return fn(ctx, dc, b) }
+	}
+	inv := func(b *model.Bundle) *model.Inventory { return &b.Inventory }
+
+	return []Collector{
+		// ── Core collectors ────────────────────────────────────────────────
+		{Name: "Namespaces", Required: true, Run: typed(Namespaces), Count: func(b *model.Bundle) int { return len(inv(b).Namespaces) }},
+		{Name: "Nodes", Required: true, Run: typed(Nodes), Count: func(b *model.Bundle) int { return len(inv(b).Nodes) }},
+		{Name: "Pods", Required: true, Run: typed(Pods), Count: func(b *model.Bundle) int { return len(inv(b).Pods) }},
+		{Name: "PVCs", Required: true, Run: typed(PVCs), Count: func(b *model.Bundle) int { return len(inv(b).PVCs) }},
+		{Name: "PVs", Required: true, Run: typed(PVs), Count: func(b *model.Bundle) int { return len(inv(b).PVs) }},
+		{Name: "StatefulSets", Required: true, Run: typed(StatefulSets), Count: func(b *model.Bundle) int { return len(inv(b).StatefulSets) }},
+		{Name: "StorageClasses", Required: true, Run: typed(StorageClasses), Count: func(b *model.Bundle) int { return len(inv(b).StorageClasses) }},
+
+		// ── Workload collectors ────────────────────────────────────────────
+		{Name: "Deployments", Run: typed(Deployments), Count: func(b *model.Bundle) int { return len(inv(b).Deployments) }},
+		{Name: "DaemonSets", Run: typed(DaemonSets), Count: func(b *model.Bundle) int { return len(inv(b).DaemonSets) }},
+		{Name: "Jobs", Run: typed(Jobs), Count: func(b *model.Bundle) int { return len(inv(b).Jobs) }},
+		{Name: "CronJobs", Run: typed(CronJobs), Count: func(b *model.Bundle) int { return len(inv(b).CronJobs) }},
+
+		// ── Networking collectors ──────────────────────────────────────────
+		{Name: "Services", Run: typed(Services), Count: func(b *model.Bundle) int { return len(inv(b).Services) }},
+		{Name: "Ingresses", Run: typed(Ingresses), Count: func(b *model.Bundle) int { return len(inv(b).Ingresses) }},
+		{Name: "NetworkPolicies", Run: typed(NetworkPolicies), Count: func(b *model.Bundle) int { return len(inv(b).NetworkPolicies) }},
+
+		// ── Config / RBAC collectors ───────────────────────────────────────
+		{Name: "ConfigMaps", Run: typed(ConfigMaps), Count: func(b *model.Bundle) int { return len(inv(b).ConfigMaps) }},
+		{Name: "Secrets", Run: typed(Secrets), Count: func(b *model.Bundle) int { return len(inv(b).Secrets) }},
+		{Name: "ClusterRoles", Run: typed(ClusterRoles), Count: func(b *model.Bundle) int { return len(inv(b).ClusterRoles) }},
+		{Name: "ClusterRoleBindings", Run: typed(ClusterRoleBindings), Count: func(b *model.Bundle) int { return len(inv(b).ClusterRoleBindings) }},
+		{Name: "HPAs", Run: typed(HPAs), Count: func(b *model.Bundle) int { return len(inv(b).HPAs) }},
+		{Name: "PodDisruptionBudgets", Run: typed(PodDisruptionBudgets), Count: func(b *model.Bundle) int { return len(inv(b).PodDisruptionBudgets) }},
+		{Name: "ResourceQuotas", Run: typed(ResourceQuotas), Count: func(b *model.Bundle) int { return len(inv(b).ResourceQuotas) }},
+		{Name: "CRDs", Run: typed(CRDs), Count: func(b *model.Bundle) int { return len(inv(b).CRDs) }},
+
+		// ── Advanced collectors ────────────────────────────────────────────
+		{Name: "HelmReleases", Run: typed(HelmReleases), Count: func(b *model.Bundle) int { return len(inv(b).HelmReleases) }},
+		{Name: "Platform", Run: typed(Platform), Count: func(b *model.Bundle) int { return 1 }},
+		{Name: "Certificates", Run: dyn(Certificates), Count: func(b *model.Bundle) int { return len(inv(b).Certificates) }},
+
+		// Images is post-collection (derives data from already-collected workloads)
+		{Name: "Images", After: []string{"Deployments", "DaemonSets", "StatefulSets"}, Run: typed(Images), Count: func(b *model.Bundle) int { return len(inv(b).Images) }},
+
+		// ── Round 13: VolumeSnapshot collectors (dynamic client) ───────────
+		{Name: "VolumeSnapshotClasses", Run: dyn(VolumeSnapshotClasses), Count: func(b *model.Bundle) int { return len(inv(b).VolumeSnapshotClasses) }},
+		{Name: "VolumeSnapshots", Run: dyn(VolumeSnapshots), Count: func(b *model.Bundle) int { return len(inv(b).VolumeSnapshots) }},
+
+		// ── Round 14: LimitRange + etcd backup collectors ──────────────────
+		{Name: "LimitRanges", Run: typed(LimitRanges), Count: func(b *model.Bundle) int { return len(inv(b).LimitRanges) }},
+		// EtcdBackup reads the detected provider to skip managed control planes.
+		{Name: "EtcdBackup", After: []string{"Platform"}, Run: typed(EtcdBackup), Count: func(b *model.Bundle) int {
+			if eb := inv(b).EtcdBackup; eb != nil && eb.Detected {
+				return 1
+			}
+			return 0
+		}},
+
+		// ── Round 18: ServiceAccount token audit ───────────────────────────
+		{Name: "ServiceAccounts", Run: typed(ServiceAccounts), Count: func(b *model.Bundle) int { return len(inv(b).ServiceAccounts) }},
+
+		// ── DR target portability ──────────────────────────────────────────
+		{Name: "CSIDrivers", Run: typed(CSIDrivers), Count: func(b *model.Bundle) int { return len(inv(b).CSIDrivers) }},
+		{Name: "IngressClasses", Run: typed(IngressClasses), Count: func(b *model.Bundle) int { return len(inv(b).IngressClasses) }},
+	}
+}
+
+// Only returns the named collectors, in registry order, for partial scans
+// such as a DR target cluster. Dependencies on collectors left out are
+// dropped.
+func Only(collectors []Collector, names ...string) []Collector {
+	keep := map[string]bool{}
+	for _, n := range names {
+		keep[n] = true
+	}
+	var out []Collector
+	for _, c := range collectors {
+		if !keep[c.Name] {
+			continue
+		}
+		var after []string
+		for _, dep := range c.After {
+			if keep[dep] {
+				after = append(after, dep)
+			}
+		}
+		c.After = after
+		out = append(out, c)
+	}
+	return out
+}
+
+// Run executes collectors concurrently, honouring After dependencies and the
+// Parallelism bound, and records one CollectorRun per collector on the bundle.
+//
+// Collectors write disjoint Bundle fields, so they can run side by side. The
+// shared fields (CollectorRuns, CollectorSkips) are only touched by this
+// goroutine. Failures of optional collectors become CollectorSkips; the first
+// failure of a Required collector cancels the collectors still running, stops
+// new ones from starting, and is returned.
+func Run(ctx context.Context, b *model.Bundle, collectors []Collector, opts RunOptions) error {
+	parallel := opts.Parallelism
+	if parallel < 1 {
+		parallel = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	known := map[string]bool{}
+	for _, c := range collectors {
+		known[c.Name] = true
+	}
+	for _, c := range collectors {
+		for _, dep := range c.After {
+			if !known[dep] {
+				return fmt.Errorf("collector %s: unknown dependency %q", c.Name, dep)
+			}
+		}
+	}
+
+	type result struct {
+		idx int
+		run model.CollectorRun
+	}
+	results := make(chan result)
+	finished := map[string]bool{}
+	started := make([]bool, len(collectors))
+	runs := make([]model.CollectorRun, len(collectors))
+	running, done := 0, 0
+	var fatal error
+
+	ready := func(c Collector) bool {
+		for _, dep := range c.After {
+			if !finished[dep] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for {
+		// Start as many ready collectors as the bound allows, in registry order.
+		for i, c := range collectors {
+			if fatal != nil || running >= parallel {
+				break
+			}
+			if started[i] || !ready(c) {
+				continue
+			}
+			started[i] = true
+			running++
+			go func(i int, c Collector) {
+				results <- result{idx: i, run: runOne(ctx, b, c, opts.Timeout)}
+			}(i, c)
+		}
+		if running == 0 {
+			if fatal == nil && done < len(collectors) {
+				fatal = fmt.Errorf("collector dependency cycle among pending collectors")
+			}
+			break
+		}
+
+		r := <-results
+		running--
+		done++
+		c := collectors[r.idx]
+		finished[c.Name] = true
+		runs[r.idx] = r.run
+
+		if r.run.Status != "ok" {
+			if !c.Required {
+				recordSkip(b, c.Name, r.run.Error)
+			} else if fatal == nil {
+				fatal = fmt.Errorf("collect %s: %s", strings.ToLower(c.Name), r.run.Error)
+				cancel()
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(done, len(collectors), r.run)
+		}
+	}
+
+	// Keep registry order in the bundle regardless of completion order.
+	for i, run := range runs {
+		if started[i] {
+			b.CollectorRuns = append(b.CollectorRuns, run)
+		}
+	}
+	return fatal
+}
+
+// runOne executes a single collector under its own deadline and recovers from
+// panics so one misbehaving collector cannot take the scan down.
+func runOne(parent context.Context, b *model.Bundle, c Collector, timeout time.Duration) (run model.CollectorRun) {
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	run = model.CollectorRun{Name: c.Name, Status: "ok"}
+	defer func() {
+		run.DurationMs = time.Since(start).Milliseconds()
+		if p := recover(); p != nil {
+			run.Status = "failed"
+			run.Error = fmt.Sprintf("panic: %v", p)
+		}
+	}()
+
+	if err := c.Run(ctx, b); err != nil {
+		run.Status = "failed"
+		run.Error = err.Error()
+		if ctx.Err() == context.DeadlineExceeded {
+			run.Status = "timeout"
+		}
+		return run
+	}
+	if c.Count != nil {
+		run.Items = c.Count(b)
+	}
+	return run
+}
+
+// recordSkip appends a CollectorSkip for an optional collector that failed.
+// Errors that look like RBAC denials are flagged so the report can say so.
+func recordSkip(b *model.Bundle, name, msg string) {
+	isRBAC := strings.Contains(msg, "forbidden") ||
+		strings.Contains(msg, "Forbidden") ||
+		strings.Contains(msg, "unauthorized") ||
+		strings.Contains(msg, "Unauthorized")
+	b.CollectorSkips = append(b.CollectorSkips, model.CollectorSkip{
+		Name:   name,
+		Reason: msg,
+		RBAC:   isRBAC,
+	})
+	log.Printf("collect %s: %s (skipping)", name, msg)
+}
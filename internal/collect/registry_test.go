@@ -0,0 +1,98 @@
+package collect
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestRunDependenciesAndSkips(t *testing.T) {
+	var platformDone atomic.Bool
+	collectors := []Collector{
+		{Name: "Platform", Run: func(ctx context.Context, b *model.Bundle) error {
+			time.Sleep(20 * time.Millisecond)
+			platformDone.Store(true)
+			return nil
+		}},
+		{Name: "EtcdBackup", After: []string{"Platform"}, Run: func(ctx context.Context, b *model.Bundle) error {
+			if !platformDone.Load() {
+				t.Error("EtcdBackup started before Platform finished")
+			}
+			return nil
+		}},
+		{Name: "Secrets", Run: func(ctx context.Context, b *model.Bundle) error {
+			return errors.New(`secrets is forbidden: User "scan" cannot list resource "secrets"`)
+		}},
+		{Name: "CRDs", Run: func(ctx context.Context, b *model.Bundle) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{Name: "Pods", Required: true,
+			Run: func(ctx context.Context, b *model.Bundle) error {
+				b.Inventory.Pods = append(b.Inventory.Pods, model.Pod{Name: "a"}, model.Pod{Name: "b"})
+				return nil
+			},
+			Count: func(b *model.Bundle) int { return len(b.Inventory.Pods) }},
+	}
+
+	b := model.NewBundle("test", time.Now())
+	err := Run(context.Background(), &b, collectors, RunOptions{Parallelism: 2, Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(b.CollectorRuns) != len(collectors) {
+		t.Fatalf("got %d CollectorRuns, want %d", len(b.CollectorRuns), len(collectors))
+	}
+	for i, run := range b.CollectorRuns {
+		if run.Name != collectors[i].Name {
+			t.Errorf("CollectorRuns[%d] = %s, want registry order %s", i, run.Name, collectors[i].Name)
+		}
+	}
+	if got := b.CollectorRuns[3].Status; got != "timeout" {
+		t.Errorf("CRDs status = %q, want timeout", got)
+	}
+	if got := b.CollectorRuns[4].Items; got != 2 {
+		t.Errorf("Pods items = %d, want 2", got)
+	}
+
+	skips := map[string]model.CollectorSkip{}
+	for _, sk := range b.CollectorSkips {
+		skips[sk.Name] = sk
+	}
+	if len(skips) != 2 {
+		t.Errorf("got skips %v, want Secrets and CRDs", b.CollectorSkips)
+	}
+	if !skips["Secrets"].RBAC {
+		t.Error("Secrets skip should be flagged as RBAC")
+	}
+}
+
+func TestRunRequiredFailureStopsScan(t *testing.T) {
+	var lateRan atomic.Bool
+	collectors := []Collector{
+		{Name: "Namespaces", Required: true, Run: func(ctx context.Context, b *model.Bundle) error {
+			return errors.New("connection refused")
+		}},
+		{Name: "Images", After: []string{"Namespaces"}, Run: func(ctx context.Context, b *model.Bundle) error {
+			lateRan.Store(true)
+			return nil
+		}},
+	}
+
+	b := model.NewBundle("test", time.Now())
+	err := Run(context.Background(), &b, collectors, RunOptions{Parallelism: 1})
+	if err == nil || err.Error() != "collect namespaces: connection refused" {
+		t.Fatalf("Run error = %v, want collect namespaces: connection refused", err)
+	}
+	if lateRan.Load() {
+		t.Error("dependent collector ran after a required collector failed")
+	}
+	if len(b.CollectorSkips) != 0 {
+		t.Errorf("required failure must not be recorded as a skip: %v", b.CollectorSkips)
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func ResourceQuotas(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func ResourceQuotas(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().ResourceQuotas("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -3,26 +3,27 @@ package collect
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func Secrets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
-	list, err := cs.CoreV1().Secrets("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	for _, s := range list.Items {
-		if !InScope(s.Namespace, b) {
-			continue
+func Secrets(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
+	return listPaged(ctx, metav1.ListOptions{}, cs.CoreV1().Secrets("").List, func(page *corev1.SecretList) {
+		for i := range page.Items {
+			s := &page.Items[i]
+			if !InScope(s.Namespace, b) {
+				continue
+			}
+			b.Inventory.Secrets = append(b.Inventory.Secrets, model.Secret{
+				Namespace: s.Namespace,
+				Name:      s.Name,
+				Type:      string(s.Type),
+				KeyCount:  len(s.Data),
+
+				HelmRelease: helmRelease(s.ObjectMeta),
+			})
 		}
-		b.Inventory.Secrets = append(b.Inventory.Secrets, model.Secret{
-			Namespace: s.Namespace,
-			Name:      s.Name,
-			Type:      string(s.Type),
-			KeyCount:  len(s.Data),
-		})
-	}
-	return nil
+	})
 }
@@ -10,7 +10,7 @@ import (
 )
 
 // ServiceAccounts collects all ServiceAccounts across namespaces.
-func ServiceAccounts(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func ServiceAccounts(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().ServiceAccounts("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
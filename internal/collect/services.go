@@ -8,7 +8,7 @@ import (
 	"k8s-recovery-visualizer/internal/model"
 )
 
-func Services(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func Services(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 	list, err := cs.CoreV1().Services("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -43,6 +43,8 @@ func Services(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) er
 			ExternalIP: externalIP,
 			Ports:      ports,
 			Selector:   svc.Spec.Selector,
+
+			HelmRelease: helmRelease(svc.ObjectMeta),
 		})
 	}
 	return nil
@@ -8,7 +8,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func StatefulSets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle) error {
+func StatefulSets(ctx context.Context, cs kubernetes.Interface, b *model.Bundle) error {
 
 	list, err := cs.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -21,6 +21,18 @@ func StatefulSets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle
 		}
 
 		hasPVC := len(sts.Spec.VolumeClaimTemplates) > 0
+		var claimTemplates []string
+		for _, t := range sts.Spec.VolumeClaimTemplates {
+			claimTemplates = append(claimTemplates, t.Name)
+		}
+
+		var images []string
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		for _, c := range sts.Spec.Template.Spec.InitContainers {
+			images = append(images, c.Image)
+		}
 
 		replicas := int32(0)
 		if sts.Spec.Replicas != nil {
@@ -32,6 +44,9 @@ func StatefulSets(ctx context.Context, cs *kubernetes.Clientset, b *model.Bundle
 			Name:           sts.Name,
 			Replicas:       replicas,
 			HasVolumeClaim: hasPVC,
+			Images:         images,
+			ClaimTemplates: claimTemplates,
+			WorkloadRefs:   workloadRefs(sts.ObjectMeta, sts.Spec.Template),
 		})
 	}
 
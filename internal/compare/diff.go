@@ -46,14 +46,8 @@ func Diff(prev, curr *model.Bundle) model.ComparisonSummary {
 	r.ImagesAdded, r.ImagesRemoved = img.added, img.removed
 
 	// Backup tool
-	prevTool := prev.Inventory.Backup.PrimaryTool
-	currTool := curr.Inventory.Backup.PrimaryTool
-	if prevTool == "" {
-		prevTool = "none"
-	}
-	if currTool == "" {
-		currTool = "none"
-	}
+	prevTool := prev.Inventory.Backup.ToolList()
+	currTool := curr.Inventory.Backup.ToolList()
 	r.BackupToolPrevious = prevTool
 	r.BackupToolCurrent = currTool
 	r.BackupToolChanged = prevTool != currTool
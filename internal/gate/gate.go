@@ -0,0 +1,255 @@
+// Package gate evaluates a policy-as-code gate file (cmd/check --gate)
+// against a recovery-scan.json.
+package gate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s-recovery-visualizer/internal/compare"
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// File is the gate document. Every field is optional; rules left out are
+// not evaluated.
+//
+//	maxFindings:
+//	  CRITICAL: 0
+//	  HIGH: 3
+//	forbid:
+//	  - BACKUP_NONE
+//	  - "PORT_*"
+//	minScores:
+//	  backup: 80
+//	  storage: 70
+//	minBackupCoverage: 90
+//	noNewCritical: true
+type File struct {
+	// MaxFindings caps the active (unwaived) findings per severity.
+	MaxFindings map[string]int `json:"maxFindings,omitempty"`
+	// Forbid lists finding IDs (globs) that must not be raised at all.
+	Forbid []string `json:"forbid,omitempty"`
+	// MinScores sets the lowest acceptable score per domain: storage,
+	// workload, config, backup or overall.
+	MinScores map[string]int `json:"minScores,omitempty"`
+	// MinBackupCoverage is the lowest acceptable percentage of the
+	// namespaces in the restore simulation that a backup policy covers.
+	MinBackupCoverage *float64 `json:"minBackupCoverage,omitempty"`
+	// NoNewCritical fails on CRITICAL findings the baseline scan did not
+	// have. It is skipped when no baseline is given.
+	NoNewCritical bool `json:"noNewCritical,omitempty"`
+}
+
+// severities in the order rules are reported.
+var severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// domains in the order rules are reported.
+var domains = []string{"storage", "workload", "config", "backup", "overall"}
+
+// Load reads and validates a gate YAML (or JSON) file.
+func Load(file string) (*File, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("gate: %w", err)
+	}
+	var f File
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("gate %s: %w", file, err)
+	}
+	maxFindings := map[string]int{}
+	for sev, n := range f.MaxFindings {
+		key := strings.ToUpper(sev)
+		if !contains(severities, key) {
+			return nil, fmt.Errorf("gate %s: maxFindings: unknown severity %q (want %s)", file, sev, strings.Join(severities, ", "))
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("gate %s: maxFindings.%s must not be negative", file, key)
+		}
+		maxFindings[key] = n
+	}
+	f.MaxFindings = maxFindings
+	minScores := map[string]int{}
+	for d, n := range f.MinScores {
+		key := strings.ToLower(d)
+		if !contains(domains, key) {
+			return nil, fmt.Errorf("gate %s: minScores: unknown domain %q (want %s)", file, d, strings.Join(domains, ", "))
+		}
+		minScores[key] = n
+	}
+	f.MinScores = minScores
+	for i, id := range f.Forbid {
+		f.Forbid[i] = strings.ToUpper(strings.TrimSpace(id))
+		if _, err := path.Match(f.Forbid[i], ""); err != nil {
+			return nil, fmt.Errorf("gate %s: forbid %q: %w", file, id, err)
+		}
+	}
+	if c := f.MinBackupCoverage; c != nil && (*c < 0 || *c > 100) {
+		return nil, fmt.Errorf("gate %s: minBackupCoverage must be a percentage between 0 and 100", file)
+	}
+	return &f, nil
+}
+
+// Rule is the outcome of one gate rule.
+type Rule struct {
+	// ID names the rule as written in the gate file, e.g.
+	// "maxFindings.CRITICAL", "forbid.PORT_*", "minScores.backup".
+	ID      string
+	Passed  bool
+	Skipped bool
+	Message string
+	// Findings are the findings that broke the rule, if it is about findings.
+	Findings []model.Finding
+}
+
+// Result is every evaluated rule, in gate file order.
+type Result struct {
+	Rules []Rule
+}
+
+// Passed reports whether no rule failed. Skipped rules do not fail the gate.
+func (r Result) Passed() bool {
+	for _, rule := range r.Rules {
+		if !rule.Passed && !rule.Skipped {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate checks scan b against f. baseline is the scan to compare with for
+// noNewCritical, or nil.
+func Evaluate(f *File, b, baseline *model.Bundle) Result {
+	var res Result
+	add := func(r Rule) { res.Rules = append(res.Rules, r) }
+	findings := b.Inventory.Findings
+
+	for _, sev := range severities {
+		limit, ok := f.MaxFindings[sev]
+		if !ok {
+			continue
+		}
+		var hit []model.Finding
+		for _, fd := range findings {
+			if fd.Severity == sev {
+				hit = append(hit, fd)
+			}
+		}
+		r := Rule{ID: "maxFindings." + sev, Passed: len(hit) <= limit}
+		r.Message = fmt.Sprintf("%d %s finding(s), at most %d allowed", len(hit), sev, limit)
+		if !r.Passed {
+			r.Findings = hit
+		}
+		add(r)
+	}
+
+	for _, pattern := range f.Forbid {
+		var hit []model.Finding
+		for _, fd := range findings {
+			if ok, _ := path.Match(pattern, fd.ID); ok {
+				hit = append(hit, fd)
+			}
+		}
+		r := Rule{ID: "forbid." + pattern, Passed: len(hit) == 0, Findings: hit}
+		if r.Passed {
+			r.Message = fmt.Sprintf("no %s finding raised", pattern)
+		} else {
+			r.Message = fmt.Sprintf("forbidden finding(s) raised: %s", strings.Join(ids(hit), ", "))
+		}
+		add(r)
+	}
+
+	scores := map[string]model.DomainScore{
+		"storage":  b.Score.Storage,
+		"workload": b.Score.Workload,
+		"config":   b.Score.Config,
+		"backup":   b.Score.Backup,
+		"overall":  b.Score.Overall,
+	}
+	for _, d := range domains {
+		want, ok := f.MinScores[d]
+		if !ok {
+			continue
+		}
+		got := scores[d].Final
+		add(Rule{
+			ID:      "minScores." + d,
+			Passed:  got >= want,
+			Message: fmt.Sprintf("%s score %d, at least %d required", d, got, want),
+		})
+	}
+
+	if want := f.MinBackupCoverage; want != nil {
+		covered, total := coverage(b)
+		r := Rule{ID: "minBackupCoverage"}
+		if total == 0 {
+			r.Skipped = true
+			r.Message = "no namespaces in the restore simulation to measure coverage against"
+		} else {
+			pct := float64(covered) / float64(total) * 100
+			r.Passed = pct >= *want
+			r.Message = fmt.Sprintf("backup covers %d of %d namespaces (%.0f%%), at least %.0f%% required", covered, total, pct, *want)
+		}
+		add(r)
+	}
+
+	if f.NoNewCritical {
+		r := Rule{ID: "noNewCritical"}
+		if baseline == nil {
+			r.Skipped = true
+			r.Message = "no baseline scan given"
+		} else {
+			for _, fd := range compare.Diff(baseline, b).FindingsNew {
+				if fd.Severity == "CRITICAL" {
+					r.Findings = append(r.Findings, fd)
+				}
+			}
+			sort.Slice(r.Findings, func(i, j int) bool { return r.Findings[i].ID < r.Findings[j].ID })
+			r.Passed = len(r.Findings) == 0
+			r.Message = fmt.Sprintf("%d new CRITICAL finding(s) since the baseline scan of %s", len(r.Findings), baseline.Metadata.GeneratedAt)
+		}
+		add(r)
+	}
+	return res
+}
+
+// coverage counts the restore simulation's namespaces and how many of them
+// a backup policy covers.
+func coverage(b *model.Bundle) (covered, total int) {
+	sim := b.Inventory.Backup.RestoreSim
+	if sim == nil {
+		return 0, 0
+	}
+	for _, ns := range sim.Namespaces {
+		total++
+		if ns.HasCoverage {
+			covered++
+		}
+	}
+	return covered, total
+}
+
+func ids(findings []model.Finding) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, f := range findings {
+		if !seen[f.ID] {
+			seen[f.ID] = true
+			out = append(out, f.ID)
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
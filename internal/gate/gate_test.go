@@ -0,0 +1,117 @@
+package gate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func writeGate(t *testing.T, body string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "gate.yaml")
+	if err := os.WriteFile(file, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoad(t *testing.T) {
+	f, err := Load(writeGate(t, "maxFindings:\n  critical: 0\nminScores:\n  Backup: 80\nforbid: [port_*]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.MaxFindings["CRITICAL"]; !ok || f.MinScores["backup"] != 80 || f.Forbid[0] != "PORT_*" {
+		t.Errorf("keys not normalised: %+v", f)
+	}
+	for _, bad := range []string{
+		"maxFindings:\n  SEVERE: 1\n",
+		"minScores:\n  network: 50\n",
+		"minBackupCoverage: 120\n",
+		"maxFindings:\n  HIGH: -1\n",
+		"forbidden: [X]\n",
+	} {
+		if _, err := Load(writeGate(t, bad)); err == nil {
+			t.Errorf("accepted %q", bad)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	crit := model.Finding{ID: "PVC_UNBOUND", Severity: "CRITICAL", ResourceID: "db/data"}
+	b := model.NewBundle("now", time.Now())
+	b.Score.Backup.Final = 60
+	b.Score.Storage.Final = 90
+	b.Inventory.Findings = []model.Finding{
+		crit,
+		{ID: "PORT_CSI_DRIVER_MISSING", Severity: "HIGH", ResourceID: "csidrivers:x"},
+		{ID: "SA_AUTOMOUNT_TOKEN", Severity: "MEDIUM", ResourceID: "pods:a/b"},
+	}
+	b.Inventory.Backup.RestoreSim = &model.RestoreSimResult{Namespaces: []model.RestoreSimNamespace{
+		{Namespace: "db", HasCoverage: true},
+		{Namespace: "web", HasCoverage: true},
+		{Namespace: "tmp"},
+	}}
+	baseline := model.NewBundle("before", time.Now())
+	baseline.Inventory.Findings = []model.Finding{{ID: "SA_AUTOMOUNT_TOKEN", Severity: "MEDIUM", ResourceID: "pods:a/b"}}
+
+	cov := 60.0
+	f := &File{
+		MaxFindings:       map[string]int{"CRITICAL": 0, "MEDIUM": 5},
+		Forbid:            []string{"PORT_*", "BACKUP_NONE"},
+		MinScores:         map[string]int{"backup": 80, "storage": 70},
+		MinBackupCoverage: &cov,
+		NoNewCritical:     true,
+	}
+	res := Evaluate(f, &b, &baseline)
+	got := map[string]Rule{}
+	var order []string
+	for _, r := range res.Rules {
+		got[r.ID] = r
+		order = append(order, r.ID)
+	}
+	want := map[string]bool{
+		"maxFindings.CRITICAL": false,
+		"maxFindings.MEDIUM":   true,
+		"forbid.PORT_*":        false,
+		"forbid.BACKUP_NONE":   true,
+		"minScores.storage":    true,
+		"minScores.backup":     false,
+		"minBackupCoverage":    true,
+		"noNewCritical":        false,
+	}
+	if len(res.Rules) != len(want) {
+		t.Fatalf("rules %v; want %d", order, len(want))
+	}
+	for id, pass := range want {
+		if got[id].Passed != pass {
+			t.Errorf("%s passed = %v (%s); want %v", id, got[id].Passed, got[id].Message, pass)
+		}
+	}
+	if n := got["noNewCritical"].Findings; len(n) != 1 || n[0].ID != "PVC_UNBOUND" {
+		t.Errorf("new critical findings %v; want PVC_UNBOUND", n)
+	}
+	if res.Passed() {
+		t.Error("gate passed with failing rules")
+	}
+
+	if r := Evaluate(&File{NoNewCritical: true}, &b, nil); !r.Passed() || !r.Rules[0].Skipped {
+		t.Errorf("noNewCritical without a baseline = %+v; want skipped", r.Rules[0])
+	}
+
+	sarif := SARIF(res, "recovery-scan.json")
+	results := sarif.Runs[0].Results
+	var ruleIDs []string
+	for _, r := range results {
+		ruleIDs = append(ruleIDs, r.RuleID)
+	}
+	if len(ruleIDs) != 3 || ruleIDs[0] != "PVC_UNBOUND" || ruleIDs[1] != "PORT_CSI_DRIVER_MISSING" || ruleIDs[2] != "gate/minScores.backup" {
+		t.Errorf("SARIF results %v; want PVC_UNBOUND once, PORT_CSI_DRIVER_MISSING and gate/minScores.backup", ruleIDs)
+	}
+	junit := JUnit(res, "recovery-scan.json")
+	if cases := junit.Suites[0].Cases; len(cases) != len(want) || cases[0].Failure == nil || cases[1].Failure != nil {
+		t.Errorf("JUnit cases %+v", cases)
+	}
+}
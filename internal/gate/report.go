@@ -0,0 +1,93 @@
+package gate
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-recovery-visualizer/internal/output"
+)
+
+// JUnit renders res as one test suite with a test case per rule; the
+// findings that broke a rule are listed in its failure text.
+func JUnit(res Result, scanFile string) output.JUnitSuites {
+	suite := output.JUnitSuite{Name: "dr-gate " + scanFile}
+	for _, r := range res.Rules {
+		class, _, _ := strings.Cut(r.ID, ".")
+		tc := output.JUnitTestCase{Name: r.ID, ClassName: "gate." + class, SystemOut: r.Message}
+		switch {
+		case r.Skipped:
+			tc.Skipped = &output.JUnitSkipped{Message: r.Message}
+		case !r.Passed:
+			var text strings.Builder
+			for _, f := range r.Findings {
+				fmt.Fprintf(&text, "%s %s %s: %s\n", f.Severity, f.ID, f.ResourceID, f.Message)
+			}
+			tc.Failure = &output.JUnitFailure{Message: r.Message, Type: class, Text: text.String()}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	return output.JUnitSuites{Name: "dr-gate", Suites: []output.JUnitSuite{suite}}
+}
+
+// SARIF renders the failed rules of res. Each finding that broke a rule is
+// a result under its own finding ID; rules about scores and coverage are a
+// result under "gate/<rule>" located at the scan file.
+func SARIF(res Result, scanFile string) output.SARIFLog {
+	driver := output.SARIFDriver{Name: "k8s-recovery-visualizer-gate"}
+	var results []output.SARIFResult
+	ruled := map[string]bool{}
+	for _, r := range res.Rules {
+		if r.Passed || r.Skipped {
+			continue
+		}
+		if len(r.Findings) == 0 {
+			id := "gate/" + r.ID
+			if !ruled[id] {
+				ruled[id] = true
+				driver.Rules = append(driver.Rules, output.SARIFRule{
+					ID:                   id,
+					ShortDescription:     &output.SARIFMessage{Text: "DR gate rule " + r.ID},
+					DefaultConfiguration: &output.SARIFConfiguration{Level: "error"},
+				})
+			}
+			results = append(results, output.SARIFResult{
+				RuleID:  id,
+				Level:   "error",
+				Message: output.SARIFMessage{Text: "Gate " + r.ID + " failed: " + r.Message},
+				Locations: []output.SARIFLocation{{
+					PhysicalLocation: &output.SARIFPhysicalLocation{ArtifactLocation: output.SARIFArtifactLocation{URI: scanFile}},
+				}},
+			})
+			continue
+		}
+		for _, f := range r.Findings {
+			if !ruled[f.ID] {
+				ruled[f.ID] = true
+				driver.Rules = append(driver.Rules, output.FindingRule(f))
+			}
+			result := output.FindingResult(f, scanFile)
+			// The gate decides what fails, so broken rules are errors
+			// whatever the finding's severity.
+			result.Level = "error"
+			result.Message.Text = fmt.Sprintf("%s (gate %s)", f.Message, r.ID)
+			results = append(results, result)
+		}
+	}
+	return output.NewSARIFLog(driver, dedupe(results))
+}
+
+// dedupe drops repeats of the same finding broken under several rules,
+// keeping the first.
+func dedupe(results []output.SARIFResult) []output.SARIFResult {
+	seen := map[string]bool{}
+	out := results[:0]
+	for _, r := range results {
+		key := r.RuleID + "|" + r.PartialFingerprints["findingKey/v1"]
+		if r.PartialFingerprints != nil && seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, r)
+	}
+	return out
+}
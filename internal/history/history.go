@@ -30,6 +30,10 @@ type Index struct {
 	Entries []IndexEntry `json:"entries"`
 }
 
+// maxEntries is how many scans the index keeps. The files of older scans
+// are removed, so a long-running exporter or operator does not fill the disk.
+const maxEntries = 200
+
 type Trend struct {
 	Previous int
 	Current  int
@@ -86,8 +90,15 @@ func Record(outDir string, b *model.Bundle) (Trend, error) {
 
 	idx.Entries = append(idx.Entries, entry)
 
-	if len(idx.Entries) > 200 {
-		idx.Entries = idx.Entries[len(idx.Entries)-200:]
+	if n := len(idx.Entries) - maxEntries; n > 0 {
+		for _, old := range idx.Entries[:n] {
+			for _, f := range []string{old.JSONFile, old.MDFile, old.HTMLFile} {
+				if f != "" {
+					_ = os.Remove(filepath.Join(outDir, filepath.FromSlash(f)))
+				}
+			}
+		}
+		idx.Entries = idx.Entries[n:]
 	}
 
 	raw, _ := json.MarshalIndent(idx, "", "  ")
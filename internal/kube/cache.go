@@ -0,0 +1,314 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// cachedResources are the built-in resources the collectors list. Custom
+// resources (backup tools, snapshots, cert-manager) and discovery are not
+// cached and are still read from the API server.
+var cachedResources = []schema.GroupVersionResource{
+	{Version: "v1", Resource: "namespaces"},
+	{Version: "v1", Resource: "nodes"},
+	{Version: "v1", Resource: "pods"},
+	{Version: "v1", Resource: "persistentvolumeclaims"},
+	{Version: "v1", Resource: "persistentvolumes"},
+	{Version: "v1", Resource: "services"},
+	{Version: "v1", Resource: "configmaps"},
+	{Version: "v1", Resource: "secrets"},
+	{Version: "v1", Resource: "serviceaccounts"},
+	{Version: "v1", Resource: "resourcequotas"},
+	{Version: "v1", Resource: "limitranges"},
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+	{Group: "batch", Version: "v1", Resource: "cronjobs"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "ingressclasses"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"},
+	{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"},
+	{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+	{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+	{Group: "storage.k8s.io", Version: "v1", Resource: "csidrivers"},
+}
+
+// CacheOptions controls when a Cache reports changes.
+type CacheOptions struct {
+	// Quiet is how long the watched resources must stay unchanged before a
+	// change is reported, so a rollout touching many objects is one rescan.
+	Quiet time.Duration
+	// MaxDelay bounds how long a change waits under constant churn. 0 means
+	// no bound.
+	MaxDelay time.Duration
+}
+
+// Cache keeps the built-in resources the collectors read in informer caches,
+// updated from watch events, so repeated scans of a large cluster re-list
+// nothing.
+//
+//	c, err := kube.NewCache(ctx, clientset, dc, kube.CacheOptions{Quiet: 10 * time.Second, MaxDelay: time.Minute})
+//	collect.Run(ctx, &b, collect.Registry(c.Clientset(), dc), opts)
+//	<-c.Changes() // debounced; rescan
+type Cache struct {
+	live    kubernetes.Interface
+	listers map[schema.GroupVersionResource]cache.GenericLister
+	// skipped resources could not be listed at start-up (RBAC, API not
+	// served); the cache answers them with the same error so collectors
+	// record the same skips as a live scan.
+	skipped map[schema.GroupVersionResource]error
+	touched chan struct{}
+	changes chan struct{}
+}
+
+// NewCache lists and starts watching the cached resources, using dc to probe
+// which ones the scanner may read, and returns once every cache has synced.
+// Watches stop when ctx is cancelled.
+func NewCache(ctx context.Context, live kubernetes.Interface, dc dynamic.Interface, opts CacheOptions) (*Cache, error) {
+	c := &Cache{
+		live:    live,
+		listers: map[schema.GroupVersionResource]cache.GenericLister{},
+		skipped: map[schema.GroupVersionResource]error{},
+		touched: make(chan struct{}, 1),
+		changes: make(chan struct{}, 1),
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(live, 0, informers.WithTransform(strip))
+	handler := cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ any, initial bool) {
+			if !initial {
+				c.touch()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			if relevant(oldObj, newObj) {
+				c.touch()
+			}
+		},
+		DeleteFunc: func(any) { c.touch() },
+	}
+	for _, gvr := range cachedResources {
+		if _, err := dc.Resource(gvr).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+			c.skipped[gvr] = err
+			continue
+		}
+		inf, err := factory.ForResource(gvr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := inf.Informer().AddEventHandler(handler); err != nil {
+			return nil, err
+		}
+		c.listers[gvr] = inf.Lister()
+	}
+	factory.Start(ctx.Done())
+	for typ, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		if !ok {
+			return nil, fmt.Errorf("cache: %v did not sync", typ)
+		}
+	}
+	go c.debounce(ctx, opts)
+	return c, nil
+}
+
+// Changes receives once after the cached resources change, debounced by
+// CacheOptions. Changes that arrive while nobody is receiving are merged.
+func (c *Cache) Changes() <-chan struct{} { return c.changes }
+
+func (c *Cache) touch() {
+	select {
+	case c.touched <- struct{}{}:
+	default:
+	}
+}
+
+// debounce turns bursts of touches into one change: it fires once nothing
+// has changed for Quiet, or MaxDelay after the first change of the burst.
+func (c *Cache) debounce(ctx context.Context, opts CacheOptions) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.touched:
+		}
+		quiet := time.NewTimer(opts.Quiet)
+		var deadline <-chan time.Time
+		if opts.MaxDelay > 0 {
+			deadline = time.After(opts.MaxDelay)
+		}
+	burst:
+		for {
+			select {
+			case <-ctx.Done():
+				quiet.Stop()
+				return
+			case <-c.touched:
+				quiet.Reset(opts.Quiet)
+			case <-quiet.C:
+				break burst
+			case <-deadline:
+				quiet.Stop()
+				break burst
+			}
+		}
+		select {
+		case c.changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Clientset returns a read-only clientset served from the caches, with
+// discovery passed through to the API server. Call it once per scan: the
+// fake clientset underneath records every request it serves.
+func (c *Cache) Clientset() kubernetes.Interface {
+	cs := &fake.Clientset{}
+	cs.AddReactor("*", "*", c.react)
+	return cachedClientset{Clientset: cs, live: c.live}
+}
+
+type cachedClientset struct {
+	*fake.Clientset
+	live kubernetes.Interface
+}
+
+func (c cachedClientset) Discovery() discovery.DiscoveryInterface { return c.live.Discovery() }
+
+// react answers list and get requests from the caches. Label selectors are
+// applied by the typed fake clients.
+func (c *Cache) react(action k8stesting.Action) (bool, runtime.Object, error) {
+	gvr := action.GetResource()
+	if err, ok := c.skipped[gvr]; ok {
+		return true, nil, err
+	}
+	lister, ok := c.listers[gvr]
+	if !ok {
+		return true, nil, apierrors.NewMethodNotSupported(gvr.GroupResource(), action.GetVerb())
+	}
+	ns := action.GetNamespace()
+	switch a := action.(type) {
+	case k8stesting.GetActionImpl:
+		if ns != "" {
+			obj, err := lister.ByNamespace(ns).Get(a.GetName())
+			return true, obj, err
+		}
+		obj, err := lister.Get(a.GetName())
+		return true, obj, err
+	case k8stesting.ListActionImpl:
+		var items []runtime.Object
+		var err error
+		if ns != "" {
+			items, err = lister.ByNamespace(ns).List(labels.Everything())
+		} else {
+			items, err = lister.List(labels.Everything())
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		kind := a.GetKind()
+		list, err := scheme.Scheme.New(kind.GroupVersion().WithKind(kind.Kind + "List"))
+		if err != nil {
+			return true, nil, err
+		}
+		return true, list, meta.SetList(list, items)
+	}
+	return true, nil, apierrors.NewMethodNotSupported(gvr.GroupResource(), action.GetVerb())
+}
+
+// strip drops what the collectors never read before an object is cached:
+// managed fields, and Secret and ConfigMap values (their keys are kept for
+// key counts).
+func strip(obj any) (any, error) {
+	if m, err := meta.Accessor(obj); err == nil {
+		m.SetManagedFields(nil)
+	}
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		for k := range o.Data {
+			o.Data[k] = nil
+		}
+		o.StringData = nil
+	case *corev1.ConfigMap:
+		for k := range o.Data {
+			o.Data[k] = ""
+		}
+		for k := range o.BinaryData {
+			o.BinaryData[k] = nil
+		}
+	}
+	return obj, nil
+}
+
+// relevant reports whether an update can change the inventory. Resyncs and
+// status-only writes to objects with a generation (replica counts, rollout
+// conditions) are ignored. Nodes only count when their labels, taints,
+// schedulability, allocatable resources or condition statuses change, not on
+// heartbeats; pods when their phase, spec or metadata changes. Anything else
+// counts.
+func relevant(oldObj, newObj any) bool {
+	o, err1 := meta.Accessor(oldObj)
+	n, err2 := meta.Accessor(newObj)
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	if o.GetResourceVersion() == n.GetResourceVersion() {
+		return false
+	}
+	metaChanged := !equality.Semantic.DeepEqual(o.GetLabels(), n.GetLabels()) ||
+		!equality.Semantic.DeepEqual(o.GetAnnotations(), n.GetAnnotations()) ||
+		!equality.Semantic.DeepEqual(o.GetOwnerReferences(), n.GetOwnerReferences())
+	switch nn := newObj.(type) {
+	case *corev1.Node:
+		on := oldObj.(*corev1.Node)
+		return metaChanged || on.Spec.Unschedulable != nn.Spec.Unschedulable ||
+			!equality.Semantic.DeepEqual(on.Spec.Taints, nn.Spec.Taints) ||
+			!equality.Semantic.DeepEqual(on.Status.Allocatable, nn.Status.Allocatable) ||
+			conditionsChanged(on.Status.Conditions, nn.Status.Conditions)
+	case *corev1.Pod:
+		op := oldObj.(*corev1.Pod)
+		return metaChanged || op.Status.Phase != nn.Status.Phase ||
+			!equality.Semantic.DeepEqual(op.Spec, nn.Spec)
+	}
+	if n.GetGeneration() > 0 {
+		return metaChanged || o.GetGeneration() != n.GetGeneration()
+	}
+	return true
+}
+
+// conditionsChanged reports whether any node condition changed status, such
+// as Ready turning False. Heartbeat and transition times are ignored.
+func conditionsChanged(old, cur []corev1.NodeCondition) bool {
+	if len(old) != len(cur) {
+		return true
+	}
+	status := map[corev1.NodeConditionType]corev1.ConditionStatus{}
+	for _, c := range old {
+		status[c.Type] = c.Status
+	}
+	for _, c := range cur {
+		if s, ok := status[c.Type]; !ok || s != c.Status {
+			return true
+		}
+	}
+	return false
+}
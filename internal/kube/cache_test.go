@@ -0,0 +1,137 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objs := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "shop", Labels: map[string]string{"app": "db"}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cfg", Namespace: "shop"}, Data: map[string]string{"password": "hunter2"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", Generation: 1}},
+	}
+	live := fake.NewClientset(objs...)
+	dc := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...)
+	dc.PrependReactor("list", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", nil)
+	})
+
+	c, err := NewCache(ctx, live, dc, CacheOptions{Quiet: 20 * time.Millisecond, MaxDelay: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := c.Clientset()
+
+	pods, err := cs.CoreV1().Pods("shop").List(ctx, metav1.ListOptions{LabelSelector: "app=db"})
+	if err != nil || len(pods.Items) != 1 {
+		t.Fatalf("cached pods = %v, %v; want db-0", pods, err)
+	}
+	if _, err := cs.CoreV1().Namespaces().Get(ctx, "shop", metav1.GetOptions{}); err != nil {
+		t.Errorf("get namespace: %v", err)
+	}
+	if _, err := cs.CoreV1().Namespaces().Get(ctx, "nope", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("get missing namespace: %v; want NotFound", err)
+	}
+	cm, err := cs.CoreV1().ConfigMaps("shop").Get(ctx, "cfg", metav1.GetOptions{})
+	if v, ok := cm.Data["password"]; err != nil || !ok || v != "" {
+		t.Errorf("cached configmap data = %v, %v; want the key without its value", cm.Data, err)
+	}
+	if _, err := cs.CoreV1().Secrets("").List(ctx, metav1.ListOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("list secrets: %v; want the Forbidden seen at start-up", err)
+	}
+	if err := cs.CoreV1().Pods("shop").Delete(ctx, "db-0", metav1.DeleteOptions{}); err == nil {
+		t.Error("cached clientset accepted a delete")
+	}
+
+	noChange := func(what string) {
+		t.Helper()
+		select {
+		case <-c.Changes():
+			t.Errorf("%s reported a change", what)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	changed := func(what string) {
+		t.Helper()
+		select {
+		case <-c.Changes():
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s reported no change", what)
+		}
+	}
+	noChange("initial sync")
+
+	// A status-only write to a Deployment does not touch the inventory.
+	dep, _ := live.AppsV1().Deployments("shop").Get(ctx, "web", metav1.GetOptions{})
+	dep.Status.ReadyReplicas = 3
+	dep.ResourceVersion = "2"
+	if _, err := live.AppsV1().Deployments("shop").UpdateStatus(ctx, dep, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	noChange("deployment status update")
+
+	// A burst of new pods is one change.
+	for _, name := range []string{"db-1", "db-2", "db-3"} {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"}}
+		if _, err := live.CoreV1().Pods("shop").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	changed("new pods")
+	noChange("the same burst")
+	if pods, _ := c.Clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{}); len(pods.Items) != 4 {
+		t.Errorf("cached pods after the burst = %d; want 4", len(pods.Items))
+	}
+}
+
+func TestRelevant(t *testing.T) {
+	node := func(rv string, allocatable string, heartbeat time.Time) *corev1.Node {
+		n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", ResourceVersion: rv}}
+		n.Status.Allocatable = corev1.ResourceList{corev1.ResourcePods: resource.MustParse(allocatable)}
+		n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.NewTime(heartbeat)}}
+		return n
+	}
+	now := time.Now()
+	if relevant(node("1", "110", now), node("2", "110", now.Add(time.Minute))) {
+		t.Error("node heartbeat is relevant")
+	}
+	if !relevant(node("1", "110", now), node("2", "250", now)) {
+		t.Error("node allocatable change is not relevant")
+	}
+	notReady := node("2", "110", now.Add(time.Minute))
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+	notReady.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(time.Minute))
+	if !relevant(node("1", "110", now), notReady) {
+		t.Error("node turning NotReady is not relevant")
+	}
+	if relevant(node("1", "110", now), node("1", "110", now)) {
+		t.Error("resync is relevant")
+	}
+	pod := func(rv string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p", ResourceVersion: rv}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	if !relevant(pod("1", corev1.PodPending), pod("2", corev1.PodRunning)) {
+		t.Error("pod phase change is not relevant")
+	}
+	if relevant(pod("1", corev1.PodRunning), pod("2", corev1.PodRunning)) {
+		t.Error("pod condition-only update is relevant")
+	}
+}
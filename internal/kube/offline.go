@@ -0,0 +1,209 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// NewOfflineClient builds a clientset and dynamic client backed by a directory
+// of kubectl dumps instead of a live API server.
+//
+//	clientset, dc, err := kube.NewOfflineClient("./must-gather")
+//
+// Every *.yaml, *.yml and *.json file under dir is read (recursively). Files may
+// hold a single object, a multi-document YAML stream, or a "kind: List" as
+// produced by `kubectl get -A -o yaml`. Built-in kinds are served by a fake
+// typed clientset so the collect.* functions run unchanged; everything else
+// (Velero, Kasten, cert-manager, snapshot CRs, ...) is served by the dynamic
+// client. Discovery reports every API group seen in the dump, including groups
+// declared only by a CustomResourceDefinition.
+func NewOfflineClient(dir string) (kubernetes.Interface, dynamic.Interface, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("offline dump: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("offline dump: %s is not a directory", dir)
+	}
+
+	var objs []*unstructured.Unstructured
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		fileObjs, err := decodeFile(path)
+		if err != nil {
+			return fmt.Errorf("offline dump: %s: %w", path, err)
+		}
+		objs = append(objs, fileObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cs := fake.NewClientset()
+	dc := newOfflineDynamic()
+	res := map[string]map[string]metav1.APIResource{} // groupVersion → resource name → resource
+
+	addResource := func(gv, name, kind string, namespaced bool) {
+		if res[gv] == nil {
+			res[gv] = map[string]metav1.APIResource{}
+		}
+		if _, ok := res[gv][name]; !ok {
+			res[gv][name] = metav1.APIResource{Name: name, Kind: kind, Namespaced: namespaced}
+		}
+	}
+
+	// CRDs go first so their declared plurals and scopes are known before any
+	// custom resource is indexed.
+	plurals := map[schema.GroupKind]string{}
+	for _, u := range objs {
+		if u.GetKind() != "CustomResourceDefinition" {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+		plural, _, _ := unstructured.NestedString(u.Object, "spec", "names", "plural")
+		scope, _, _ := unstructured.NestedString(u.Object, "spec", "scope")
+		if group == "" || kind == "" || plural == "" {
+			continue
+		}
+		plurals[schema.GroupKind{Group: group, Kind: kind}] = plural
+		dc.declare(schema.GroupResource{Group: group, Resource: plural})
+		versions, _, _ := unstructured.NestedSlice(u.Object, "spec", "versions")
+		for _, v := range versions {
+			vm, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if served, ok := vm["served"].(bool); ok && !served {
+				continue
+			}
+			if name, ok := vm["name"].(string); ok && name != "" {
+				addResource(group+"/"+name, plural, kind, scope == "Namespaced")
+			}
+		}
+	}
+
+	for _, u := range objs {
+		gvk := u.GroupVersionKind()
+		if gvk.Kind == "" || gvk.Version == "" {
+			continue
+		}
+		resource := plurals[gvk.GroupKind()]
+		if resource == "" {
+			plural, _ := meta.UnsafeGuessKindToResource(gvk)
+			resource = plural.Resource
+		}
+		gvr := gvk.GroupVersion().WithResource(resource)
+		addResource(gvk.GroupVersion().String(), resource, gvk.Kind, u.GetNamespace() != "")
+
+		if scheme.Scheme.Recognizes(gvk) {
+			typed, err := scheme.Scheme.New(gvk)
+			if err != nil {
+				return nil, nil, fmt.Errorf("offline dump: %s %s: %w", gvk.Kind, u.GetName(), err)
+			}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+				return nil, nil, fmt.Errorf("offline dump: %s %s: %w", gvk.Kind, u.GetName(), err)
+			}
+			if err := cs.Tracker().Add(typed); err != nil && !apierrors.IsAlreadyExists(err) {
+				return nil, nil, fmt.Errorf("offline dump: %s %s: %w", gvk.Kind, u.GetName(), err)
+			}
+			continue
+		}
+		dc.add(gvr, u)
+	}
+
+	disc := cs.Discovery().(*fakediscovery.FakeDiscovery)
+	gvs := make([]string, 0, len(res))
+	for gv := range res {
+		gvs = append(gvs, gv)
+	}
+	sort.Strings(gvs)
+	for _, gv := range gvs {
+		list := &metav1.APIResourceList{GroupVersion: gv}
+		names := make([]string, 0, len(res[gv]))
+		for name := range res[gv] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			list.APIResources = append(list.APIResources, res[gv][name])
+		}
+		disc.Resources = append(disc.Resources, list)
+	}
+
+	return cs, dc, nil
+}
+
+// decodeFile reads every object in a YAML/JSON file, flattening List kinds.
+func decodeFile(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []*unstructured.Unstructured
+	dec := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var doc map[string]interface{}
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return out, nil
+			}
+			return nil, err
+		}
+		if len(doc) == 0 {
+			continue
+		}
+		out = append(out, flatten(&unstructured.Unstructured{Object: doc})...)
+	}
+}
+
+// flatten expands List kinds (including nested lists) into their items.
+func flatten(u *unstructured.Unstructured) []*unstructured.Unstructured {
+	if !u.IsList() {
+		if u.GetKind() == "" {
+			return nil
+		}
+		unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+		return []*unstructured.Unstructured{u}
+	}
+	var out []*unstructured.Unstructured
+	items, _, _ := unstructured.NestedSlice(u.Object, "items")
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			out = append(out, flatten(&unstructured.Unstructured{Object: m})...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package kube
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// offlineDynamic is a read-only dynamic.Interface over objects loaded from disk.
+//
+// Objects are indexed by group+resource, ignoring version, the same way the API
+// server serves every version of a CRD: a dump taken at velero.io/v1 still
+// answers a v1beta1 probe. Unknown resources return NotFound, matching what a
+// live cluster returns when the CRD is not installed.
+type offlineDynamic struct {
+	objects map[schema.GroupResource][]*unstructured.Unstructured
+}
+
+func newOfflineDynamic() *offlineDynamic {
+	return &offlineDynamic{objects: map[schema.GroupResource][]*unstructured.Unstructured{}}
+}
+
+// declare registers gr as served even when the dump holds no instances of it,
+// so a CRD with zero objects lists as empty rather than NotFound.
+func (d *offlineDynamic) declare(gr schema.GroupResource) {
+	if _, ok := d.objects[gr]; !ok {
+		d.objects[gr] = []*unstructured.Unstructured{}
+	}
+}
+
+func (d *offlineDynamic) add(gvr schema.GroupVersionResource, u *unstructured.Unstructured) {
+	gr := gvr.GroupResource()
+	for _, existing := range d.objects[gr] {
+		if existing.GetNamespace() == u.GetNamespace() && existing.GetName() == u.GetName() {
+			return // first copy wins, as with the typed tracker
+		}
+	}
+	d.objects[gr] = append(d.objects[gr], u)
+}
+
+func (d *offlineDynamic) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &offlineResource{d: d, gvr: gvr}
+}
+
+type offlineResource struct {
+	d         *offlineDynamic
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (r *offlineResource) Namespace(ns string) dynamic.ResourceInterface {
+	return &offlineResource{d: r.d, gvr: r.gvr, namespace: ns}
+}
+
+func (r *offlineResource) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	items, ok := r.d.objects[r.gvr.GroupResource()]
+	if !ok {
+		return nil, apierrors.NewGenericServerResponse(http.StatusNotFound, "list", r.gvr.GroupResource(), "", "", 0, false)
+	}
+	sel := labels.Everything()
+	if opts.LabelSelector != "" {
+		s, err := labels.Parse(opts.LabelSelector)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		sel = s
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	list.SetAPIVersion(r.gvr.GroupVersion().String())
+	list.SetKind("List")
+	for _, u := range items {
+		if r.namespace != "" && u.GetNamespace() != r.namespace {
+			continue
+		}
+		if !sel.Matches(labels.Set(u.GetLabels())) {
+			continue
+		}
+		list.Items = append(list.Items, *u.DeepCopy())
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		if list.Items[i].GetNamespace() != list.Items[j].GetNamespace() {
+			return list.Items[i].GetNamespace() < list.Items[j].GetNamespace()
+		}
+		return list.Items[i].GetName() < list.Items[j].GetName()
+	})
+	return list, nil
+}
+
+func (r *offlineResource) Get(_ context.Context, name string, _ metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	for _, u := range r.d.objects[r.gvr.GroupResource()] {
+		if u.GetName() == name && u.GetNamespace() == r.namespace {
+			return u.DeepCopy(), nil
+		}
+	}
+	return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+}
+
+func (r *offlineResource) readOnly(verb string) error {
+	return apierrors.NewMethodNotSupported(r.gvr.GroupResource(), verb)
+}
+
+func (r *offlineResource) Create(context.Context, *unstructured.Unstructured, metav1.CreateOptions, ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly("create")
+}
+
+func (r *offlineResource) Update(context.Context, *unstructured.Unstructured, metav1.UpdateOptions, ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly("update")
+}
+
+func (r *offlineResource) UpdateStatus(context.Context, *unstructured.Unstructured, metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly("update")
+}
+
+func (r *offlineResource) Delete(context.Context, string, metav1.DeleteOptions, ...string) error {
+	return r.readOnly("delete")
+}
+
+func (r *offlineResource) DeleteCollection(context.Context, metav1.DeleteOptions, metav1.ListOptions) error {
+	return r.readOnly("deletecollection")
+}
+
+func (r *offlineResource) Watch(context.Context, metav1.ListOptions) (watch.Interface, error) {
+	return nil, r.readOnly("watch")
+}
+
+func (r *offlineResource) Patch(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly("patch")
+}
+
+func (r *offlineResource) Apply(context.Context, string, *unstructured.Unstructured, metav1.ApplyOptions, ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly("apply")
+}
+
+func (r *offlineResource) ApplyStatus(context.Context, string, *unstructured.Unstructured, metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly("apply")
+}
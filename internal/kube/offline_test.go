@@ -0,0 +1,67 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewOfflineClient(t *testing.T) {
+	cs, dc, err := NewOfflineClient("testdata/cluster")
+	if err != nil {
+		t.Fatalf("NewOfflineClient: %v", err)
+	}
+	ctx := context.Background()
+
+	// Built-in kinds from a "kind: List" dump are served by the typed clientset.
+	pods, err := cs.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list pods: %v", err)
+	}
+	if len(pods.Items) != 1 || pods.Items[0].Name != "db-0" {
+		t.Errorf("pods = %v, want [db-0]", pods.Items)
+	}
+	sel, err := cs.CoreV1().Pods("shop").List(ctx, metav1.ListOptions{LabelSelector: "app=other"})
+	if err != nil || len(sel.Items) != 0 {
+		t.Errorf("label selector app=other: got %d pods, err %v; want 0", len(sel.Items), err)
+	}
+	if _, err := cs.AppsV1().StatefulSets("shop").Get(ctx, "db", metav1.GetOptions{}); err != nil {
+		t.Errorf("get statefulset shop/db: %v", err)
+	}
+
+	// Custom resources are served by the dynamic client, at any version of the group.
+	for _, v := range []string{"v1", "v1beta1"} {
+		gvr := schema.GroupVersionResource{Group: "velero.io", Version: v, Resource: "schedules"}
+		list, err := dc.Resource(gvr).Namespace("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("list %s: %v", gvr, err)
+		}
+		if len(list.Items) != 1 || list.Items[0].GetName() != "nightly" {
+			t.Errorf("%s: got %d items, want [nightly]", gvr, len(list.Items))
+		}
+	}
+
+	// Resources absent from the dump behave like an uninstalled CRD.
+	missing := schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+	if _, err := dc.Resource(missing).List(ctx, metav1.ListOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("list %s: err = %v, want NotFound", missing, err)
+	}
+
+	// Discovery reports the CRD group so collect.CRDs and backup.Detect see it.
+	groups, err := cs.Discovery().ServerGroups()
+	if err != nil {
+		t.Fatalf("server groups: %v", err)
+	}
+	found := false
+	for _, g := range groups.Groups {
+		if g.Name == "velero.io" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("discovery groups missing velero.io: %v", groups.Groups)
+	}
+}
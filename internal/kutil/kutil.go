@@ -0,0 +1,35 @@
+// Package kutil holds small Kubernetes helpers shared by the packages that
+// analyse a scan bundle.
+package kutil
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SystemNamespace reports whether ns is created by Kubernetes itself; a
+// rebuilt or DR cluster runs its own.
+func SystemNamespace(ns string) bool {
+	return ns == "kube-system" || ns == "kube-public" || ns == "kube-node-lease"
+}
+
+// Selects reports whether every selector label is set on labels.
+func Selects(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// SizeGiB converts a storage quantity ("10Gi", "500M") to GiB; 0 when
+// unparseable.
+func SizeGiB(q string) float64 {
+	v, err := resource.ParseQuantity(strings.TrimSpace(q))
+	if err != nil {
+		return 0
+	}
+	return v.AsApproximateFloat64() / (1 << 30)
+}
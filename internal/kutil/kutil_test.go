@@ -0,0 +1,19 @@
+package kutil
+
+import "testing"
+
+func TestSizeGiB(t *testing.T) {
+	for q, want := range map[string]float64{
+		"10Gi":       10,
+		"512Mi":      0.5,
+		"2Ti":        2048,
+		"1073741824": 1,
+		" 4Gi ":      4,
+		"":           0,
+		"lots":       0,
+	} {
+		if got := SizeGiB(q); got != want {
+			t.Errorf("SizeGiB(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
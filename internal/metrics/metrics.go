@@ -0,0 +1,229 @@
+// Package metrics exposes the latest scan in the Prometheus text exposition
+// format for scan --serve-metrics.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// severities are always exported, so a severity with no findings reads 0
+// rather than disappearing.
+var severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// Exporter serves the most recent scan on /metrics. It is safe for
+// concurrent use.
+type Exporter struct {
+	mu       sync.RWMutex
+	bundle   *model.Bundle
+	scans    int
+	failures int
+	last     time.Time
+	duration time.Duration
+}
+
+// Update records a completed scan that took d.
+func (e *Exporter) Update(b *model.Bundle, d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.bundle = b
+	e.scans++
+	e.last = time.Now()
+	e.duration = d
+}
+
+// Fail records a scan that failed. The last completed scan keeps being
+// served.
+func (e *Exporter) Fail() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures++
+}
+
+// Handler serves /metrics, and /healthz which reports 503 until the first
+// scan completes.
+func (e *Exporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := e.Write(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		e.mu.RLock()
+		ready := e.bundle != nil
+		e.mu.RUnlock()
+		if !ready {
+			http.Error(w, "no scan completed yet", http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "ok\n")
+	})
+	return mux
+}
+
+// Write renders the exporter's own counters and, once a scan has
+// completed, its metrics.
+func (e *Exporter) Write(w io.Writer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	bw := bufio.NewWriter(w)
+	m := &writer{w: bw}
+	m.family("dr_scans_total", "counter", "Scans completed since the exporter started.")
+	m.sample("dr_scans_total", nil, float64(e.scans))
+	m.family("dr_scan_failures_total", "counter", "Scans that failed since the exporter started.")
+	m.sample("dr_scan_failures_total", nil, float64(e.failures))
+	if e.bundle != nil {
+		m.family("dr_scan_timestamp_seconds", "gauge", "Unix time the last scan completed.")
+		m.sample("dr_scan_timestamp_seconds", nil, float64(e.last.Unix()))
+		m.family("dr_scan_duration_seconds", "gauge", "How long the last scan took.")
+		m.sample("dr_scan_duration_seconds", nil, e.duration.Seconds())
+		writeBundle(m, e.bundle)
+	}
+	return bw.Flush()
+}
+
+// writeBundle renders the gauges for one scan.
+func writeBundle(m *writer, b *model.Bundle) {
+	m.family("dr_score", "gauge", "DR readiness score by domain (0-100).")
+	for _, d := range []struct {
+		name  string
+		score model.DomainScore
+	}{
+		{"overall", b.Score.Overall},
+		{"storage", b.Score.Storage},
+		{"workload", b.Score.Workload},
+		{"config", b.Score.Config},
+		{"backup", b.Score.Backup},
+	} {
+		m.sample("dr_score", []string{"domain", d.name}, float64(d.score.Final))
+	}
+	m.family("dr_maturity_info", "gauge", "DR maturity level of the last scan.")
+	m.sample("dr_maturity_info", []string{"maturity", b.Score.Maturity}, 1)
+
+	bySeverity := map[string]int{}
+	type idKey struct{ id, severity, domain string }
+	byID := map[idKey]int{}
+	for _, f := range b.Inventory.Findings {
+		bySeverity[f.Severity]++
+		byID[idKey{f.ID, f.Severity, f.Domain}]++
+	}
+	m.family("dr_findings", "gauge", "Active findings by severity.")
+	for _, sev := range severities {
+		m.sample("dr_findings", []string{"severity", sev}, float64(bySeverity[sev]))
+	}
+	m.family("dr_finding", "gauge", "Active findings by finding ID.")
+	keys := make([]idKey, 0, len(byID))
+	for k := range byID {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].id < keys[j].id })
+	for _, k := range keys {
+		m.sample("dr_finding", []string{"id", k.id, "severity", k.severity, "domain", k.domain}, float64(byID[k]))
+	}
+	m.family("dr_findings_waived", "gauge", "Findings accepted by an unexpired waiver.")
+	m.sample("dr_findings_waived", nil, float64(len(b.Inventory.WaivedFindings)))
+
+	if sim := b.Inventory.Backup.RestoreSim; sim != nil {
+		covered := 0
+		for _, ns := range sim.Namespaces {
+			if ns.HasCoverage {
+				covered++
+			}
+		}
+		if len(sim.Namespaces) > 0 {
+			m.family("dr_backup_coverage_percent", "gauge", "Share of the restore simulation's namespaces a backup policy covers.")
+			m.sample("dr_backup_coverage_percent", nil, float64(covered)/float64(len(sim.Namespaces))*100)
+		}
+		if sim.TotalPVCsGB > 0 {
+			m.family("dr_backup_volume_coverage_percent", "gauge", "Share of PVC data a backup policy covers.")
+			m.sample("dr_backup_volume_coverage_percent", nil, sim.CoveredPVCsGB/sim.TotalPVCsGB*100)
+		}
+		m.family("dr_namespace_rpo_hours", "gauge", "Worst of scheduled and measured RPO per namespace; +Inf when no backup covers it.")
+		for _, ns := range sim.Namespaces {
+			switch {
+			case !ns.HasCoverage:
+				m.sample("dr_namespace_rpo_hours", []string{"namespace", ns.Namespace}, math.Inf(1))
+			case ns.AchievedRPOHours >= 0:
+				m.sample("dr_namespace_rpo_hours", []string{"namespace", ns.Namespace}, ns.AchievedRPOHours)
+			}
+		}
+		m.family("dr_namespace_rto_hours", "gauge", "Estimated hours to restore each namespace on its own.")
+		for _, ns := range sim.Namespaces {
+			m.sample("dr_namespace_rto_hours", []string{"namespace", ns.Namespace}, ns.EstimatedRTOHours)
+		}
+	}
+
+	if len(b.Inventory.Certificates) > 0 {
+		m.family("dr_certificate_expiry_days", "gauge", "Days until each cert-manager Certificate expires.")
+		for _, c := range b.Inventory.Certificates {
+			if c.NotAfter != "" {
+				m.sample("dr_certificate_expiry_days", []string{"namespace", c.Namespace, "name", c.Name}, float64(c.DaysToExpiry))
+			}
+		}
+	}
+
+	rbac := 0
+	for _, sk := range b.CollectorSkips {
+		if sk.RBAC {
+			rbac++
+		}
+	}
+	m.family("dr_collector_skips", "gauge", "Collectors skipped in the last scan, by reason.")
+	m.sample("dr_collector_skips", []string{"reason", "rbac"}, float64(rbac))
+	m.sample("dr_collector_skips", []string{"reason", "other"}, float64(len(b.CollectorSkips)-rbac))
+}
+
+// writer emits text exposition format lines.
+type writer struct {
+	w *bufio.Writer
+}
+
+func (m *writer) family(name, typ, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample; labels are name, value pairs.
+func (m *writer) sample(name string, labels []string, v float64) {
+	m.w.WriteString(name)
+	if len(labels) > 0 {
+		m.w.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				m.w.WriteByte(',')
+			}
+			fmt.Fprintf(m.w, "%s=\"%s\"", labels[i], escape(labels[i+1]))
+		}
+		m.w.WriteByte('}')
+	}
+	m.w.WriteByte(' ')
+	m.w.WriteString(formatValue(v))
+	m.w.WriteByte('\n')
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(s string) string { return escaper.Replace(s) }
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
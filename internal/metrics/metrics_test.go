@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestWrite(t *testing.T) {
+	b := model.NewBundle("scan", time.Now())
+	b.Score.Overall.Final = 72
+	b.Score.Backup.Final = 40
+	b.Score.Maturity = "SILVER"
+	b.Inventory.Findings = []model.Finding{
+		{ID: "PVC_UNBOUND", Severity: "CRITICAL", Domain: "storage"},
+		{ID: "PVC_UNBOUND", Severity: "CRITICAL", Domain: "storage"},
+		{ID: "SA_AUTOMOUNT_TOKEN", Severity: "MEDIUM", Domain: "config"},
+	}
+	b.Inventory.Backup.RestoreSim = &model.RestoreSimResult{
+		TotalPVCsGB:   100,
+		CoveredPVCsGB: 25,
+		Namespaces: []model.RestoreSimNamespace{
+			{Namespace: "db", HasCoverage: true, AchievedRPOHours: 26, EstimatedRTOHours: 1.5},
+			{Namespace: "web"},
+		},
+	}
+	b.Inventory.Certificates = []model.Certificate{{Namespace: "web", Name: `a"b`, NotAfter: "2026-01-01", DaysToExpiry: 9}}
+	b.CollectorSkips = []model.CollectorSkip{{Name: "secrets", RBAC: true}}
+
+	e := &Exporter{}
+	e.Update(&b, 2*time.Second)
+	var out strings.Builder
+	if err := e.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# TYPE dr_score gauge\n",
+		`dr_score{domain="overall"} 72` + "\n",
+		`dr_score{domain="backup"} 40` + "\n",
+		`dr_maturity_info{maturity="SILVER"} 1` + "\n",
+		`dr_findings{severity="CRITICAL"} 2` + "\n",
+		`dr_findings{severity="HIGH"} 0` + "\n",
+		`dr_finding{id="PVC_UNBOUND",severity="CRITICAL",domain="storage"} 2` + "\n",
+		"dr_backup_coverage_percent 50\n",
+		"dr_backup_volume_coverage_percent 25\n",
+		`dr_namespace_rpo_hours{namespace="db"} 26` + "\n",
+		`dr_namespace_rpo_hours{namespace="web"} +Inf` + "\n",
+		`dr_certificate_expiry_days{namespace="web",name="a\"b"} 9` + "\n",
+		`dr_collector_skips{reason="rbac"} 1` + "\n",
+		"dr_scans_total 1\n",
+		"dr_scan_failures_total 0\n",
+		"dr_scan_duration_seconds 2\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	e := &Exporter{}
+	srv := httptest.NewServer(e.Handler())
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	if code, _ := get("/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("healthz before first scan = %d; want 503", code)
+	}
+	if _, body := get("/metrics"); strings.Contains(body, "dr_score") {
+		t.Errorf("scan metrics before first scan:\n%s", body)
+	}
+	b := model.NewBundle("scan", time.Now())
+	e.Update(&b, time.Second)
+	if code, _ := get("/healthz"); code != http.StatusOK {
+		t.Errorf("healthz after scan = %d; want 200", code)
+	}
+	if _, body := get("/metrics"); !strings.Contains(body, `dr_score{domain="overall"}`) {
+		t.Errorf("no scores after scan:\n%s", body)
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+// Application is the set of resources that make up one app, grouped by
+// app.kubernetes.io labels, Helm release and owner references, with its DR
+// status.
+type Application struct {
+	Name string `json:"name"`
+	// Source is what grouped the resources: "part-of", "instance", "helm",
+	// "name", "owner" (the custom resource owning the workloads) or
+	// "workload" (an unlabelled workload on its own).
+	Source     string        `json:"source"`
+	Namespaces []string      `json:"namespaces"`
+	Resources  []ResourceRef `json:"resources"`
+
+	PVCs          int     `json:"pvcs"`
+	ProtectedPVCs int     `json:"protectedPvcs"` // PVCs at least one backup tool protects
+	PVCSizeGB     float64 `json:"pvcSizeGb"`
+	// Coverage is "full", "partial" or "none": how many of the app's PVCs
+	// are protected or, for a stateless app, how many of its namespaces a
+	// backup policy covers.
+	Coverage string   `json:"coverage"`
+	Tools    []string `json:"tools,omitempty"`
+	// RPOHours is the worst of the app's namespaces' best policy RPO;
+	// -1 = uncovered or unknown.
+	RPOHours int `json:"rpoHours"`
+	// Findings lists the IDs of active findings on the app's resources or
+	// namespaces; WorstSeverity is the highest of their severities.
+	Findings      []string `json:"findings,omitempty"`
+	WorstSeverity string   `json:"worstSeverity,omitempty"`
+}
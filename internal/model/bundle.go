@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"path"
+	"strings"
+	"time"
+)
 
 type Bundle struct {
 	Checks        []Check          `json:"checks,omitempty"`
@@ -17,10 +21,22 @@ type Bundle struct {
 	Profile       string           `json:"profile,omitempty"`
 	// CollectorSkips records collectors that were skipped due to RBAC or missing APIs.
 	CollectorSkips []CollectorSkip `json:"collectorSkips,omitempty"`
+	// CollectorRuns records duration and item count for every collector that ran.
+	CollectorRuns []CollectorRun `json:"collectorRuns,omitempty"`
 	// ScanNamespaces restricts the scan to specific namespaces. Empty = all namespaces.
 	ScanNamespaces []string `json:"scanNamespaces,omitempty"`
+	// ExcludeNamespaces are skipped by every collector (rules file overrides.excludeNamespaces).
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// RulesProfile is the name of the --rules-file profile applied, if any.
+	RulesProfile string `json:"rulesProfile,omitempty"`
 	// Comparison holds the diff against a previous scan when --compare is used.
 	Comparison *ComparisonSummary `json:"comparison,omitempty"`
+	// Portability compares this cluster with the DR target cluster when
+	// --target-kubeconfig or --target-scan is used.
+	Portability *PortabilityReport `json:"portability,omitempty"`
+	// Capacity checks the DR site can run the protected workloads when
+	// --dr-capacity or a DR target cluster is given.
+	Capacity *CapacityReport `json:"capacity,omitempty"`
 	// TrendHistory holds the last N scan scores for sparkline rendering in the report.
 	TrendHistory []TrendPoint `json:"trendHistory,omitempty"`
 }
@@ -66,6 +82,15 @@ type CollectorSkip struct {
 	RBAC   bool   `json:"rbac"` // true when error appears to be a permissions/forbidden error
 }
 
+// CollectorRun records the outcome of one collector during the scan.
+type CollectorRun struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // ok, failed, timeout
+	DurationMs int64  `json:"durationMs"`
+	Items      int    `json:"items"`
+	Error      string `json:"error,omitempty"`
+}
+
 type Metadata struct {
 	CustomerID  string `json:"customerId,omitempty"`
 	Site        string `json:"site,omitempty"`
@@ -114,17 +139,70 @@ type BackupPolicy struct {
 	ExcludedNS      []string `json:"excludedNamespaces,omitempty"`
 	Schedule        string   `json:"schedule,omitempty"`      // cron expression or label e.g. "@daily"
 	RetentionTTL    string   `json:"retentionTtl,omitempty"` // e.g. "720h0m0s"
-	RPOHours        int      `json:"rpoHours"`                // estimated RPO in hours; -1 = unknown
+	RPOHours        int      `json:"rpoHours"`                // worst-case gap between scheduled runs, hours rounded up; -1 = unknown
 	HasOffsite      bool     `json:"hasOffsite"`
 	StorageLocation string   `json:"storageLocation,omitempty"`
+
+	// Run history, when the tool's backup/run objects were readable.
+	LastSuccess    *time.Time `json:"lastSuccess,omitempty"`
+	LastRunState   string     `json:"lastRunState,omitempty"`
+	FailureStreak  int        `json:"failureStreak,omitempty"`  // consecutive failed runs since the last success
+	ExportProfiles []string   `json:"exportProfiles,omitempty"` // location profiles a completed export wrote to
+}
+
+// Covers reports whether the policy selects namespace ns. An empty
+// IncludedNS means all namespaces; both lists accept glob patterns ("*",
+// "app-*") and exclusions win.
+func (p BackupPolicy) Covers(ns string) bool {
+	for _, ex := range p.ExcludedNS {
+		if ok, _ := path.Match(ex, ns); ok {
+			return false
+		}
+	}
+	if len(p.IncludedNS) == 0 {
+		return true
+	}
+	for _, incl := range p.IncludedNS {
+		if ok, _ := path.Match(incl, ns); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // RestoreSimNamespace holds the restore feasibility assessment for one namespace.
 type RestoreSimNamespace struct {
 	Namespace   string   `json:"namespace"`
 	HasCoverage bool     `json:"hasCoverage"`
+	// Stateful is false for namespaces simulated only because they run
+	// stateless workloads or declare a recovery target.
+	Stateful    bool     `json:"stateful"`
 	RPOHours    int      `json:"rpoHours"` // best RPO from applicable policies; -1 = unknown
 	PVCSizeGB   float64  `json:"pvcSizeGb"`
+	// MeasuredRPOHours is the age of the last successful backup that included
+	// the namespace; -1 = none seen or no backup evidence.
+	MeasuredRPOHours float64    `json:"measuredRpoHours"`
+	LastBackup       *time.Time `json:"lastBackup,omitempty"`
+	// AchievedRPOHours is the worse of the scheduled and measured RPO;
+	// -1 = uncovered or unknown.
+	AchievedRPOHours float64 `json:"achievedRpoHours"`
+	// EstimatedRTOHours is how long a restore of the namespace alone would
+	// take; RTO splits it by phase.
+	EstimatedRTOHours float64       `json:"estimatedRtoHours"`
+	RTO               *RTOBreakdown `json:"rtoBreakdown,omitempty"`
+	PVCCount          int           `json:"pvcCount"`
+	ObjectCount       int           `json:"objectCount"` // namespaced API objects to re-create
+	ImageCount        int           `json:"imageCount"`  // distinct container images to pull
+	// RestoreOrder (1-based) and ReadyAfterHours place the namespace in the
+	// full-cluster recovery sequence: ReadyAfterHours counts from the start
+	// of the recovery, including cluster-wide setup and earlier namespaces.
+	RestoreOrder    int     `json:"restoreOrder"`
+	ReadyAfterHours float64 `json:"readyAfterHours"`
+	// Target and the statuses ("met", "missed", "unknown") are set when the
+	// namespace declares a recovery objective.
+	Target    *DRTarget `json:"target,omitempty"`
+	RPOStatus string    `json:"rpoStatus,omitempty"`
+	RTOStatus string    `json:"rtoStatus,omitempty"`
 	Blockers    []string `json:"blockers,omitempty"`
 	Warnings    []string `json:"warnings,omitempty"`
 }
@@ -135,17 +213,173 @@ type RestoreSimResult struct {
 	TotalPVCsGB   float64               `json:"totalPvcsGb"`
 	CoveredPVCsGB float64               `json:"coveredPvcsGb"`
 	UncoveredNS   []string              `json:"uncoveredNamespaces,omitempty"`
+	// RestoreTested is true when at least one restore has completed.
+	RestoreTested bool       `json:"restoreTested"`
+	LastRestore   *time.Time `json:"lastRestore,omitempty"`
+	// EstimatedFullRecoveryHours is when the last namespace is ready if the
+	// whole cluster is restored in RestoreOrder under RTOModel.
+	EstimatedFullRecoveryHours float64  `json:"estimatedFullRecoveryHours"`
+	RTOModel                   RTOModel `json:"rtoModel"`
+}
+
+// RestorePlan is the order to bring objects back in after a full loss,
+// topologically sorted over their dependencies.
+type RestorePlan struct {
+	Steps []RestoreStep `json:"steps"`
+	// Cyclic lists objects caught in a dependency cycle. They are appended to
+	// Steps in kind order.
+	Cyclic []string `json:"cyclic,omitempty"`
+}
+
+// RestoreStep is one object in the restore plan. Steps in the same wave do
+// not depend on each other and can be restored together.
+type RestoreStep struct {
+	Order       int      `json:"order"`
+	Wave        int      `json:"wave"`
+	ID          string   `json:"id"` // Kind/namespace/name, or Kind/name when cluster-scoped
+	Kind        string   `json:"kind"`
+	Namespace   string   `json:"namespace,omitempty"`
+	Name        string   `json:"name"`
+	HelmRelease string   `json:"helmRelease,omitempty"`
+	Operator    bool     `json:"operator,omitempty"`  // a workload restored with the operators
+	DependsOn   []string `json:"dependsOn,omitempty"` // IDs of direct dependencies
+}
+
+// RTOBreakdown is a namespace's estimated restore time split by phase, in
+// hours.
+type RTOBreakdown struct {
+	BaseHours    float64 `json:"baseHours"`    // fixed per-namespace overhead
+	ObjectsHours float64 `json:"objectsHours"` // re-creating API objects
+	VolumesHours float64 `json:"volumesHours"` // provisioning and attaching PVCs
+	DataHours    float64 `json:"dataHours"`    // copying PVC data back
+	ImagesHours  float64 `json:"imagesHours"`  // pulling container images
+	// GBPerHour is the effective data throughput (0 = no PVC data).
+	GBPerHour float64 `json:"gbPerHour,omitempty"`
+}
+
+// RTOModel holds the assumptions restore time estimates are built from. It
+// is loaded from --rto-model; fields left at zero take the defaults.
+type RTOModel struct {
+	// GBPerHour is the PVC data restore throughput when Throughput has no
+	// entry for the PVC's provisioner or covering backup tool.
+	GBPerHour float64 `json:"gbPerHour,omitempty"`
+	// Throughput is GB/h keyed by CSI provisioner ("ebs.csi.aws.com") or
+	// backup tool ("kasten"). A provisioner entry takes precedence.
+	Throughput         map[string]float64 `json:"throughput,omitempty"`
+	NamespaceMinutes   float64            `json:"namespaceMinutes,omitempty"` // fixed cost per namespace
+	PVCMinutes         float64            `json:"pvcMinutes,omitempty"`       // provision + attach per PVC
+	ObjectSeconds      float64            `json:"objectSeconds,omitempty"`    // per re-created API object
+	ImageGB            float64            `json:"imageGb,omitempty"`          // average image size
+	ImagePullGBPerHour float64            `json:"imagePullGbPerHour,omitempty"`
+	// Parallel is how many namespaces are restored at once.
+	Parallel int `json:"parallel,omitempty"`
+	// ClusterMinutes covers CRDs, operators and storage setup before any
+	// namespace can be restored.
+	ClusterMinutes float64 `json:"clusterMinutes,omitempty"`
 }
 
 // BackupInventory holds the result of backup tool detection.
+//
+// Coverage, policies, offsite and RPO span every detected tool. PrimaryTool
+// is the first detected tool, kept for consumers of earlier scan files.
 type BackupInventory struct {
-	Tools               []BackupDetectedTool `json:"tools"`
-	PrimaryTool         string               `json:"primaryTool"` // "none" if nothing found
-	CoveredNamespaces   []string             `json:"coveredNamespaces,omitempty"`
-	UncoveredStatefulNS []string             `json:"uncoveredStatefulNamespaces,omitempty"`
-	Policies            []BackupPolicy       `json:"policies,omitempty"`
-	HasOffsite          bool                 `json:"hasOffsite"`
-	RestoreSim          *RestoreSimResult    `json:"restoreSim,omitempty"`
+	Tools               []BackupDetectedTool  `json:"tools"`
+	PrimaryTool         string                `json:"primaryTool"`           // "none" if nothing found
+	ActiveTools         []string              `json:"activeTools,omitempty"` // every detected tool, in detection order
+	CoveredNamespaces   []string              `json:"coveredNamespaces,omitempty"`
+	UncoveredStatefulNS []string              `json:"uncoveredStatefulNamespaces,omitempty"`
+	Policies            []BackupPolicy        `json:"policies,omitempty"`
+	HasOffsite          bool                  `json:"hasOffsite"`
+	Protection          []NamespaceProtection `json:"protection,omitempty"`
+	Evidence            *BackupEvidence       `json:"evidence,omitempty"`
+	RestoreSim          *RestoreSimResult     `json:"restoreSim,omitempty"`
+}
+
+// ToolList names the detected tools for display ("velero, longhorn"), or
+// "none".
+func (inv BackupInventory) ToolList() string {
+	if len(inv.ActiveTools) > 0 {
+		return strings.Join(inv.ActiveTools, ", ")
+	}
+	if inv.PrimaryTool != "" {
+		return inv.PrimaryTool
+	}
+	return "none"
+}
+
+// NamespaceCoverage returns the merged protection of namespace ns and
+// whether any tool protects it. Bundles without Protection (written by
+// earlier versions) fall back to CoveredNamespaces, with an unknown RPO.
+func (inv BackupInventory) NamespaceCoverage(ns string) (NamespaceProtection, bool) {
+	if len(inv.Protection) > 0 {
+		for _, np := range inv.Protection {
+			if np.Namespace == ns {
+				return np, len(np.Tools) > 0
+			}
+		}
+		return NamespaceProtection{Namespace: ns, RPOHours: -1}, false
+	}
+	np := NamespaceProtection{Namespace: ns, RPOHours: -1}
+	for _, covered := range inv.CoveredNamespaces {
+		if covered == ns || covered == "*" {
+			return np, true
+		}
+	}
+	return np, false
+}
+
+// NamespaceProtection merges what every tool's policies say about one
+// namespace and its PVCs.
+type NamespaceProtection struct {
+	Namespace  string          `json:"namespace"`
+	Tools      []string        `json:"tools,omitempty"`    // tools protecting the namespace
+	Policies   []string        `json:"policies,omitempty"` // covering policies as "tool/name"
+	RPOHours   int             `json:"rpoHours"`           // best across covering policies; -1 = unknown
+	HasOffsite bool            `json:"hasOffsite"`
+	PVCs       []PVCProtection `json:"pvcs,omitempty"`
+}
+
+// PVCProtection lists the tools that protect one PVC's data. Volume-level
+// tools (Longhorn) only protect PVCs on their own storage.
+type PVCProtection struct {
+	Name  string   `json:"name"`
+	Tools []string `json:"tools,omitempty"`
+}
+
+// BackupEvidence summarises the backup and restore objects a tool has
+// actually produced (Velero Backups/Restores, Kasten actions and
+// RestorePoints), as opposed to what its schedules promise. Nil when no such
+// objects could be read.
+type BackupEvidence struct {
+	Tools             []string           `json:"tools,omitempty"` // tools whose backup objects were read
+	Backups           int                `json:"backups"`
+	Completed         int                `json:"completed"`
+	Failed            int                `json:"failed"`
+	PartiallyFailed   int                `json:"partiallyFailed"`
+	FailedBackups     []ResourceRef      `json:"failedBackups,omitempty"` // Failed and PartiallyFailed, newest first
+	LastSuccess       *time.Time         `json:"lastSuccess,omitempty"`
+	Namespaces        []NamespaceBackup  `json:"namespaces,omitempty"`
+	Restores          int                `json:"restores"`
+	RestoresCompleted int                `json:"restoresCompleted"`
+	LastRestore       *time.Time         `json:"lastRestore,omitempty"`
+	RestorePoints     []AppRestorePoints `json:"restorePoints,omitempty"`
+}
+
+// AppRestorePoints counts the restore points held for one application
+// (Kasten RestorePoints, keyed by app namespace).
+type AppRestorePoints struct {
+	App    string     `json:"app"`
+	Count  int        `json:"count"`
+	Latest *time.Time `json:"latest,omitempty"`
+}
+
+// NamespaceBackup is the most recent successful backup that included a namespace.
+type NamespaceBackup struct {
+	Namespace   string    `json:"namespace"`
+	Tool        string    `json:"tool"`
+	Backup      string    `json:"backup"`
+	LastSuccess time.Time `json:"lastSuccess"`
+	AgeHours    float64   `json:"ageHours"` // relative to the scan start
 }
 
 // RemediationStep is one prioritized DR remediation action.
@@ -179,6 +413,7 @@ type Inventory struct {
 	Services       []Service       `json:"services,omitempty"`
 	Ingresses      []Ingress       `json:"ingresses,omitempty"`
 	NetworkPolicies []NetworkPolicy `json:"networkPolicies,omitempty"`
+	IngressClasses  []IngressClass  `json:"ingressClasses,omitempty"`
 
 	// Config resources
 	ConfigMaps     []ConfigMap          `json:"configMaps,omitempty"`
@@ -198,6 +433,7 @@ type Inventory struct {
 	// Round 13 — volume snapshot coverage
 	VolumeSnapshotClasses []VolumeSnapshotClass `json:"volumeSnapshotClasses,omitempty"`
 	VolumeSnapshots       []VolumeSnapshot      `json:"volumeSnapshots,omitempty"`
+	CSIDrivers            []CSIDriver           `json:"csiDrivers,omitempty"`
 
 	// Round 14 — LimitRange enforcement + etcd backup
 	LimitRanges []LimitRange        `json:"limitRanges,omitempty"`
@@ -206,6 +442,12 @@ type Inventory struct {
 	// Round 18 — ServiceAccount token audit
 	ServiceAccounts []ServiceAccount `json:"serviceAccounts,omitempty"`
 
+	// Dependency-ordered restore plan
+	RestorePlan *RestorePlan `json:"restorePlan,omitempty"`
+
+	// Resources grouped into applications, with per-application DR status
+	Applications []Application `json:"applications,omitempty"`
+
 	// Backup detection result
 	Backup BackupInventory `json:"backup,omitempty"`
 
@@ -213,8 +455,15 @@ type Inventory struct {
 	RemediationSteps []RemediationStep `json:"remediationSteps,omitempty"`
 
 	Findings []Finding `json:"findings"`
+	// WaivedFindings are findings covered by an unexpired --waivers entry.
+	// They are excluded from scores, counts and remediation.
+	WaivedFindings []Finding `json:"waivedFindings,omitempty"`
 }
 
+// AnnotationPrefix is the prefix of the namespace annotations the scanner
+// reads, such as the recovery targets dr.example/rpo and dr.example/rto.
+const AnnotationPrefix = "dr.example/"
+
 type Namespace struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -222,6 +471,21 @@ type Namespace struct {
 	PSAEnforce string `json:"psaEnforce,omitempty"` // pod-security.kubernetes.io/enforce
 	PSAWarn    string `json:"psaWarn,omitempty"`    // pod-security.kubernetes.io/warn
 	PSAAudit   string `json:"psaAudit,omitempty"`   // pod-security.kubernetes.io/audit
+	// Labels holds all namespace labels (used by custom rules, e.g. tier=gold).
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations holds only the AnnotationPrefix annotations (recovery targets).
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Target is the namespace's recovery objective, when one is declared.
+	Target *DRTarget `json:"drTarget,omitempty"`
+}
+
+// DRTarget is a namespace's recovery point and time objective in hours
+// (0 = not set). Source says where they came from: "annotation", "file"
+// (a matching --targets entry) and/or "default" (the file's default).
+type DRTarget struct {
+	RPOHours float64 `json:"rpoHours,omitempty"`
+	RTOHours float64 `json:"rtoHours,omitempty"`
+	Source   string  `json:"source"`
 }
 
 func NewBundle(scanID string, started time.Time) Bundle {
@@ -0,0 +1,54 @@
+package model
+
+// CapacityReport says whether the DR site has room to run the protected
+// workloads after a failover.
+type CapacityReport struct {
+	// Site names the DR site: the --dr-capacity file's name or the DR
+	// target cluster. Source is "spec" (node counts and sizes from the
+	// file) or "scan" (the target cluster's Ready nodes).
+	Site   string `json:"site"`
+	Source string `json:"source"`
+
+	// Allocatable totals over the DR site's nodes. Pods is 0 when the
+	// node pod limits are unknown.
+	Nodes       int   `json:"nodes"`
+	CPUMillis   int64 `json:"cpuMillis"`
+	MemoryBytes int64 `json:"memoryBytes"`
+	Pods        int64 `json:"pods"`
+
+	// Required totals the requests of the running pods in protected
+	// (backup-covered) namespaces.
+	RequiredCPUMillis   int64 `json:"requiredCpuMillis"`
+	RequiredMemoryBytes int64 `json:"requiredMemoryBytes"`
+	RequiredPods        int   `json:"requiredPods"`
+	// PodsWithoutRequests counts protected pods that request no CPU or no
+	// memory; Required understates what they will use.
+	PodsWithoutRequests int `json:"podsWithoutRequests"`
+
+	// Short lists the resources the DR site runs out of: "cpu", "memory",
+	// "pods". Empty when the protected workloads fit.
+	Short []string `json:"short,omitempty"`
+	// Oversized lists pods that request more than any single DR node can
+	// allocate; they stay Pending after a failover even when totals fit.
+	Oversized []ResourceRef `json:"oversized,omitempty"`
+
+	Namespaces []CapacityNamespace `json:"namespaces"`
+}
+
+// CapacityNamespace is one namespace's share of the DR site.
+type CapacityNamespace struct {
+	Namespace           string `json:"namespace"`
+	Protected           bool   `json:"protected"`
+	Pods                int    `json:"pods"`
+	PodsWithoutRequests int    `json:"podsWithoutRequests"`
+	CPUMillis           int64  `json:"cpuMillis"`
+	MemoryBytes         int64  `json:"memoryBytes"`
+
+	// Headroom is what the DR site has left once this namespace and the
+	// protected namespaces restored before it are running; negative means
+	// the site is short. Only set for protected namespaces.
+	HeadroomCPUMillis   int64 `json:"headroomCpuMillis"`
+	HeadroomMemoryBytes int64 `json:"headroomMemoryBytes"`
+	HeadroomPods        int64 `json:"headroomPods"`
+	Fits                bool  `json:"fits"`
+}
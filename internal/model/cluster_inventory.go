@@ -14,6 +14,11 @@ type Node struct {
 	ExternalIP       string            `json:"externalIp,omitempty"`
 	Labels           map[string]string `json:"labels,omitempty"`
 	Taints           []string          `json:"taints,omitempty"`
+
+	// Allocatable is what the scheduler can hand out to pods.
+	AllocatableCPUMillis   int64 `json:"allocatableCpuMillis,omitempty"`
+	AllocatableMemoryBytes int64 `json:"allocatableMemoryBytes,omitempty"`
+	AllocatablePods        int64 `json:"allocatablePods,omitempty"`
 }
 
 // StorageClass represents a Kubernetes StorageClass for DR suitability checks.
@@ -34,6 +39,26 @@ type Deployment struct {
 	Replicas    int32    `json:"replicas"`
 	Ready       int32    `json:"ready"`
 	Images      []string `json:"images,omitempty"`
+	WorkloadRefs
+}
+
+// WorkloadRefs is what a workload's restore depends on: the objects its pod
+// template uses, who owns it and the Helm release it belongs to.
+type WorkloadRefs struct {
+	PodLabels   map[string]string `json:"podLabels,omitempty"` // pod template labels, matched by Service selectors
+	AppLabels   map[string]string `json:"appLabels,omitempty"` // app.kubernetes.io/* labels on the workload itself
+	ConfigMaps  []string          `json:"configMaps,omitempty"`
+	Secrets     []string          `json:"secrets,omitempty"` // volumes, env and imagePullSecrets
+	PVCs        []string          `json:"pvcs,omitempty"`
+	Owners      []OwnerRef        `json:"owners,omitempty"`
+	HelmRelease string            `json:"helmRelease,omitempty"`
+}
+
+// OwnerRef is one metadata.ownerReferences entry.
+type OwnerRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
 }
 
 // DaemonSet represents a Kubernetes DaemonSet.
@@ -43,6 +68,7 @@ type DaemonSet struct {
 	Desired   int32    `json:"desired"`
 	Ready     int32    `json:"ready"`
 	Images    []string `json:"images,omitempty"`
+	WorkloadRefs
 }
 
 // Job represents a Kubernetes Job.
@@ -75,13 +101,14 @@ type ServicePort struct {
 
 // Service represents a Kubernetes Service.
 type Service struct {
-	Namespace  string            `json:"namespace"`
-	Name       string            `json:"name"`
-	Type       string            `json:"type"` // ClusterIP, NodePort, LoadBalancer, ExternalName
-	ClusterIP  string            `json:"clusterIp,omitempty"`
-	ExternalIP string            `json:"externalIp,omitempty"`
-	Ports      []ServicePort     `json:"ports,omitempty"`
-	Selector   map[string]string `json:"selector,omitempty"`
+	Namespace   string            `json:"namespace"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"` // ClusterIP, NodePort, LoadBalancer, ExternalName
+	ClusterIP   string            `json:"clusterIp,omitempty"`
+	ExternalIP  string            `json:"externalIp,omitempty"`
+	Ports       []ServicePort     `json:"ports,omitempty"`
+	Selector    map[string]string `json:"selector,omitempty"`
+	HelmRelease string            `json:"helmRelease,omitempty"`
 }
 
 // IngressRule holds a single host rule for an Ingress.
@@ -97,21 +124,25 @@ type Ingress struct {
 	ClassName   string        `json:"className,omitempty"`
 	TLS         bool          `json:"tls"`
 	Rules       []IngressRule `json:"rules,omitempty"`
+	TLSSecrets  []string      `json:"tlsSecrets,omitempty"`
+	HelmRelease string        `json:"helmRelease,omitempty"`
 }
 
 // ConfigMap represents metadata for a Kubernetes ConfigMap (no values).
 type ConfigMap struct {
-	Namespace string `json:"namespace"`
-	Name      string `json:"name"`
-	KeyCount  int    `json:"keyCount"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	KeyCount    int    `json:"keyCount"`
+	HelmRelease string `json:"helmRelease,omitempty"`
 }
 
 // Secret represents metadata for a Kubernetes Secret (no values or data).
 type Secret struct {
-	Namespace string `json:"namespace"`
-	Name      string `json:"name"`
-	Type      string `json:"type"`
-	KeyCount  int    `json:"keyCount"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	KeyCount    int    `json:"keyCount"`
+	HelmRelease string `json:"helmRelease,omitempty"`
 }
 
 // ClusterRole represents a Kubernetes ClusterRole.
@@ -241,3 +272,15 @@ type ServiceAccount struct {
 	Name                         string `json:"name"`
 	AutomountServiceAccountToken *bool  `json:"automountServiceAccountToken,omitempty"`
 }
+
+// CSIDriver represents a storage.k8s.io/v1 CSIDriver registered in the cluster.
+type CSIDriver struct {
+	Name string `json:"name"`
+}
+
+// IngressClass represents a networking.k8s.io/v1 IngressClass.
+type IngressClass struct {
+	Name       string `json:"name"`
+	Controller string `json:"controller,omitempty"`
+	Default    bool   `json:"default,omitempty"` // ingressclass.kubernetes.io/is-default-class=true
+}
@@ -1,11 +1,47 @@
 package model
 
 type Finding struct {
-	ID             string `json:"id"`
-	Severity       string `json:"severity"`
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	// ResourceID is a display summary ("ns/name", or "pods:a/x,b/y..." for
+	// findings spanning many objects). Resources holds the complete list.
 	ResourceID     string `json:"resourceId"`
 	Message        string `json:"message"`
 	Recommendation string `json:"recommendation"`
+	// Domain is the score the finding's penalty applies to: STORAGE, WORKLOAD, CONFIG or BACKUP.
+	Domain string `json:"domain,omitempty"`
+	// Custom marks findings raised by a user-supplied rule (--rules-file "custom").
+	Custom bool `json:"custom,omitempty"`
+	// Waiver is set when a --waivers entry matches this finding. Waived
+	// findings live in Inventory.WaivedFindings and carry no penalty; once the
+	// waiver has expired the finding is active again with Expired set.
+	Waiver *WaiverRef `json:"waiver,omitempty"`
+	// Resources lists every object the finding applies to. The penalty is
+	// charged once per finding regardless of its length.
+	Resources []ResourceRef `json:"resources,omitempty"`
+}
+
+// ResourceRef identifies one Kubernetes object affected by a finding.
+type ResourceRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// String returns "namespace/name", or just the name for cluster-scoped objects.
+func (r ResourceRef) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "/" + r.Name
+}
+
+// WaiverRef records the accepted-risk waiver applied to a finding.
+type WaiverRef struct {
+	Owner         string `json:"owner"`
+	Justification string `json:"justification"`
+	Expires       string `json:"expires"`
+	Expired       bool   `json:"expired,omitempty"`
 }
 
 type DomainScore struct {
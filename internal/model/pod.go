@@ -5,6 +5,11 @@ type Pod struct {
 	Name         string `json:"name"`
 	UsesHostPath bool   `json:"usesHostPath"`
 
+	// The workload that owns the pod: a ReplicaSet's pods are owned by its
+	// Deployment. Empty for bare pods.
+	OwnerKind string `json:"ownerKind,omitempty"`
+	OwnerName string `json:"ownerName,omitempty"`
+
 	// Round 11 — resource governance
 	ContainerCount int  `json:"containerCount"`
 	HasRequests    bool `json:"hasRequests"`   // every container defines CPU + memory requests
@@ -17,4 +22,9 @@ type Pod struct {
 
 	// Round 18 — ServiceAccount token audit
 	AutomountSAToken bool `json:"automountSaToken,omitempty"` // pod explicitly has automountServiceAccountToken=true
+
+	// DR capacity — what the scheduler reserves for the pod
+	Phase               string `json:"phase,omitempty"`
+	RequestsCPUMillis   int64  `json:"requestsCpuMillis,omitempty"`
+	RequestsMemoryBytes int64  `json:"requestsMemoryBytes,omitempty"`
 }
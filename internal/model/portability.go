@@ -0,0 +1,43 @@
+package model
+
+// PortabilityReport says whether what runs on the scanned cluster can be
+// restored onto the DR target cluster.
+type PortabilityReport struct {
+	// Target names the DR cluster: its cluster name, kubeconfig path or
+	// the scan file it was read from.
+	Target    string `json:"target"`
+	ScannedAt string `json:"scannedAt,omitempty"`
+
+	SourceVersion string `json:"sourceVersion,omitempty"`
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// VersionSkew is the target's minor version minus the source's; 0 when
+	// either is unknown.
+	VersionSkew int `json:"versionSkew"`
+
+	Capacity PortabilityCapacity `json:"capacity"`
+	Gaps     []PortabilityGap    `json:"gaps"`
+	// Unchecked lists the checks skipped because the target's collector
+	// failed or did not run, e.g. "CSIDrivers".
+	Unchecked []string `json:"unchecked,omitempty"`
+}
+
+// PortabilityCapacity totals node allocatable resources on both clusters.
+type PortabilityCapacity struct {
+	SourceNodes       int   `json:"sourceNodes"`
+	TargetNodes       int   `json:"targetNodes"`
+	SourceCPUMillis   int64 `json:"sourceCpuMillis"`
+	TargetCPUMillis   int64 `json:"targetCpuMillis"`
+	SourceMemoryBytes int64 `json:"sourceMemoryBytes"`
+	TargetMemoryBytes int64 `json:"targetMemoryBytes"`
+}
+
+// PortabilityGap is one thing the source uses that the target lacks.
+type PortabilityGap struct {
+	// Kind is StorageClass, CSIDriver, VolumeSnapshotClass, API,
+	// IngressClass, Capacity or Version.
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Detail string `json:"detail"`
+	// Affected lists the source objects that depend on what is missing.
+	Affected []ResourceRef `json:"affected,omitempty"`
+}
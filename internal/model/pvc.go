@@ -7,4 +7,5 @@ type PersistentVolumeClaim struct {
 	StorageClass  string   `json:"storageClass,omitempty"`
 	AccessModes   []string `json:"accessModes,omitempty"`
 	RequestedSize string   `json:"requestedSize,omitempty"`
+	HelmRelease   string   `json:"helmRelease,omitempty"`
 }
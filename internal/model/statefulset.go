@@ -1,8 +1,27 @@
 package model
 
+import "strings"
+
 type StatefulSet struct {
-	Namespace      string `json:"namespace"`
-	Name           string `json:"name"`
-	Replicas       int32  `json:"replicas"`
-	HasVolumeClaim bool   `json:"hasVolumeClaim"`
+	Namespace      string   `json:"namespace"`
+	Name           string   `json:"name"`
+	Replicas       int32    `json:"replicas"`
+	HasVolumeClaim bool     `json:"hasVolumeClaim"`
+	Images         []string `json:"images,omitempty"`
+	// ClaimTemplates are the volumeClaimTemplates names; each replica's PVC
+	// is <template>-<name>-<ordinal>.
+	ClaimTemplates []string `json:"claimTemplates,omitempty"`
+	WorkloadRefs
+}
+
+// OwnsClaim reports whether pvc is one of the StatefulSet's
+// volumeClaimTemplate PVCs, <template>-<name>-<ordinal>.
+func (s StatefulSet) OwnsClaim(pvc string) bool {
+	for _, tmpl := range s.ClaimTemplates {
+		ordinal, ok := strings.CutPrefix(pvc, tmpl+"-"+s.Name+"-")
+		if ok && ordinal != "" && strings.Trim(ordinal, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
 }
@@ -9,6 +9,26 @@ type FindingCounts struct {
 	Total    int `json:"total"`
 }
 
+// CountFindings tallies findings by severity; anything below MEDIUM counts
+// as LOW.
+func CountFindings(findings []Finding) FindingCounts {
+	var c FindingCounts
+	for _, f := range findings {
+		c.Total++
+		switch f.Severity {
+		case "CRITICAL":
+			c.Critical++
+		case "HIGH":
+			c.High++
+		case "MEDIUM":
+			c.Medium++
+		default:
+			c.Low++
+		}
+	}
+	return c
+}
+
 type ScanSummary struct {
 	ScanID       string          `json:"scanId"`
 	TimestampUtc string          `json:"timestampUtc"`
@@ -0,0 +1,344 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-recovery-visualizer/internal/analyze"
+	"k8s-recovery-visualizer/internal/backup"
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// component is the Event source and the managed-by label value.
+const component = "k8s-recovery-visualizer"
+
+// maxConfigMapBytes leaves headroom under the 1 MiB object size limit.
+const maxConfigMapBytes = 900 << 10
+
+// ScanFunc runs the collect → analyze pipeline for s and returns the scored
+// bundle.
+type ScanFunc func(ctx context.Context, s *DRScan) (*model.Bundle, error)
+
+// Controller reconciles DRScan resources: every resource that is due is
+// scanned, one at a time.
+type Controller struct {
+	Dynamic dynamic.Interface
+	Kube    kubernetes.Interface
+	Scan    ScanFunc
+	// Namespace limits the DRScans watched; empty watches all namespaces.
+	// Only DRScans in Namespace may scan beyond their own namespace.
+	Namespace string
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Run reconciles every resync until ctx is cancelled. Errors are logged and
+// retried on the next pass.
+func (c *Controller) Run(ctx context.Context, resync time.Duration) error {
+	t := time.NewTicker(resync)
+	defer t.Stop()
+	for {
+		if err := c.Reconcile(ctx); err != nil {
+			log.Printf("operator: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Reconcile makes one pass over the DRScans and scans those that are due.
+func (c *Controller) Reconcile(ctx context.Context) error {
+	list, err := c.Dynamic.Resource(GVR).Namespace(c.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list drscans: %w", err)
+	}
+	var errs []error
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if err := c.reconcile(ctx, obj); err != nil {
+			errs = append(errs, fmt.Errorf("drscan %s/%s: %w", obj.GetNamespace(), obj.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Controller) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Controller) reconcile(ctx context.Context, obj *unstructured.Unstructured) error {
+	var s DRScan
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &s); err != nil {
+		return err
+	}
+	if s.Spec.Suspend {
+		return nil
+	}
+	now := c.now().UTC()
+	next, err := backup.NextRun(s.schedule(), now)
+	if err != nil {
+		return c.reject(ctx, obj, &s, "InvalidSchedule", "invalid schedule: "+err.Error())
+	}
+	namespaces, err := c.scope(&s)
+	if err != nil {
+		return c.reject(ctx, obj, &s, "NamespaceNotAllowed", err.Error())
+	}
+	if !due(&s, now) {
+		return nil
+	}
+	s.Spec.Namespaces = namespaces
+
+	log.Printf("operator: scanning %s/%s", s.Namespace, s.Name)
+	b, err := c.Scan(ctx, &s)
+	if err != nil {
+		st := s.Status
+		st.ObservedGeneration, st.NextScanTime, st.Message = s.Generation, next.Format(time.RFC3339), "scan failed: "+err.Error()
+		c.event(ctx, &s, corev1.EventTypeWarning, "ScanFailed", err.Error())
+		return c.updateStatus(ctx, obj, st)
+	}
+
+	st := statusFor(&s, b, now, next)
+	cm, note, err := c.writeResult(ctx, &s, b, st)
+	if err != nil {
+		return err
+	}
+	st.ResultConfigMap, st.Message = cm, note
+	c.emitChanges(ctx, &s, st)
+	return c.updateStatus(ctx, obj, st)
+}
+
+// reject records a spec error in s's status with a Warning Event, once per
+// spec change.
+func (c *Controller) reject(ctx context.Context, obj *unstructured.Unstructured, s *DRScan, reason, msg string) error {
+	if s.Status.ObservedGeneration == s.Generation && s.Status.Message == msg {
+		return nil
+	}
+	st := s.Status
+	st.ObservedGeneration, st.NextScanTime, st.Message = s.Generation, "", msg
+	c.event(ctx, s, corev1.EventTypeWarning, reason, msg)
+	return c.updateStatus(ctx, obj, st)
+}
+
+// scope returns the namespaces s may scan. The operator reads every
+// namespace and writes the findings next to the DRScan, so only DRScans in
+// the operator's own namespace may scan the whole cluster or other
+// namespaces; any other DRScan scans its own namespace.
+func (c *Controller) scope(s *DRScan) ([]string, error) {
+	if c.Namespace != "" && s.Namespace == c.Namespace {
+		return s.Spec.Namespaces, nil
+	}
+	for _, ns := range s.Spec.Namespaces {
+		if ns != s.Namespace {
+			return nil, fmt.Errorf("namespace %s not allowed: only DRScans in the operator namespace may scan namespaces other than their own", ns)
+		}
+	}
+	return []string{s.Namespace}, nil
+}
+
+// due reports whether s has never been scanned, has changed since its last
+// scan, or has reached its next scheduled scan.
+func due(s *DRScan, now time.Time) bool {
+	if s.Status.ObservedGeneration != s.Generation {
+		return true
+	}
+	next, err := time.Parse(time.RFC3339, s.Status.NextScanTime)
+	return err != nil || !now.Before(next)
+}
+
+// statusFor summarises b for s's status.
+func statusFor(s *DRScan, b *model.Bundle, now, next time.Time) Status {
+	st := Status{
+		ObservedGeneration: s.Generation,
+		LastScanTime:       now.Format(time.RFC3339),
+		NextScanTime:       next.Format(time.RFC3339),
+		ScanID:             b.Scan.ScanID,
+		Score:              b.Score.Overall.Final,
+		Maturity:           b.Score.Maturity,
+		Scores: map[string]int{
+			"storage":  b.Score.Storage.Final,
+			"workload": b.Score.Workload.Final,
+			"config":   b.Score.Config.Final,
+			"backup":   b.Score.Backup.Final,
+		},
+		Findings: model.CountFindings(b.Inventory.Findings),
+		Passed:   b.Score.Overall.Final >= s.Spec.MinScore,
+	}
+	for _, f := range b.Inventory.Findings {
+		if f.Severity == "CRITICAL" {
+			st.CriticalFindings = append(st.CriticalFindings, f.ID+"|"+f.ResourceID)
+		}
+	}
+	sort.Strings(st.CriticalFindings)
+	return st
+}
+
+// emitChanges records the scan and, against the previous status, flags a
+// score drop, new CRITICAL findings and falling below spec.minScore.
+func (c *Controller) emitChanges(ctx context.Context, s *DRScan, st Status) {
+	prev := s.Status
+	c.event(ctx, s, corev1.EventTypeNormal, "ScanCompleted",
+		fmt.Sprintf("Scan %s scored %d (%s) with %d findings, %d critical", st.ScanID, st.Score, st.Maturity, st.Findings.Total, st.Findings.Critical))
+	if !st.Passed && (prev.LastScanTime == "" || prev.Passed) {
+		c.event(ctx, s, corev1.EventTypeWarning, "BelowMinScore",
+			fmt.Sprintf("DR score %d is below the minimum of %d", st.Score, s.Spec.MinScore))
+	}
+	if prev.LastScanTime == "" {
+		return
+	}
+	if st.Score < prev.Score {
+		c.event(ctx, s, corev1.EventTypeWarning, "ScoreDropped",
+			fmt.Sprintf("DR score dropped from %d to %d (%s)", prev.Score, st.Score, st.Maturity))
+	}
+	known := map[string]bool{}
+	for _, k := range prev.CriticalFindings {
+		known[k] = true
+	}
+	var added []string
+	for _, k := range st.CriticalFindings {
+		if !known[k] {
+			id, res, _ := strings.Cut(k, "|")
+			added = append(added, strings.TrimSpace(id+" "+res))
+		}
+	}
+	if len(added) > 0 {
+		shown := added
+		if len(shown) > 5 {
+			shown = append(shown[:5:5], fmt.Sprintf("and %d more", len(added)-5))
+		}
+		c.event(ctx, s, corev1.EventTypeWarning, "NewCriticalFindings",
+			fmt.Sprintf("%d new CRITICAL findings: %s", len(added), strings.Join(shown, ", ")))
+	}
+}
+
+// writeResult stores the scan summary and findings in the ConfigMap
+// <name>-result, owned by s. Findings are left out when they would push the
+// ConfigMap over the size limit; note then says so.
+func (c *Controller) writeResult(ctx context.Context, s *DRScan, b *model.Bundle, st Status) (name, note string, err error) {
+	summary := model.ScanSummary{
+		ScanID:       st.ScanID,
+		TimestampUtc: st.LastScanTime,
+		Overall:      st.Score,
+		Maturity:     st.Maturity,
+		Status:       "PASSED",
+		MinScore:     s.Spec.MinScore,
+		Profile:      b.Profile,
+		Categories:   analyze.BuildCategories(b),
+		Findings:     st.Findings,
+	}
+	if !st.Passed {
+		summary.Status = "FAILED"
+	}
+	if s.Status.LastScanTime != "" {
+		summary.Delta = st.Score - s.Status.Score
+	}
+	rawSummary, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	rawFindings, err := json.MarshalIndent(b.Inventory.Findings, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	data := map[string]string{"summary.json": string(rawSummary)}
+	if len(rawSummary)+len(rawFindings) <= maxConfigMapBytes {
+		data["findings.json"] = string(rawFindings)
+	} else {
+		note = fmt.Sprintf("findings.json left out of the result ConfigMap: %d bytes is over the size limit", len(rawFindings))
+	}
+
+	name = s.Name + "-result"
+	cms := c.Kube.CoreV1().ConfigMaps(s.Namespace)
+	cm, err := cms.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": component,
+				GVR.Group + "/drscan":          s.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{ownerRef(s)},
+		}, Data: data}
+		_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+	case err == nil:
+		cm.Data = data
+		_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("write configmap %s: %w", name, err)
+	}
+	return name, note, nil
+}
+
+func ownerRef(s *DRScan) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{
+		APIVersion: GVR.GroupVersion().String(),
+		Kind:       Kind,
+		Name:       s.Name,
+		UID:        s.UID,
+		Controller: &controller,
+	}
+}
+
+// event records an Event on s. Failures are logged, not returned: a lost
+// Event must not fail the scan.
+func (c *Controller) event(ctx context.Context, s *DRScan, typ, reason, msg string) {
+	now := metav1.NewTime(c.now())
+	ev := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%s.%x", s.Name, strings.ToLower(reason), now.UnixNano()),
+			Namespace: s.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      GVR.GroupVersion().String(),
+			Kind:            Kind,
+			Namespace:       s.Namespace,
+			Name:            s.Name,
+			UID:             s.UID,
+			ResourceVersion: s.ResourceVersion,
+		},
+		Type:           typ,
+		Reason:         reason,
+		Message:        msg,
+		Source:         corev1.EventSource{Component: component},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if _, err := c.Kube.CoreV1().Events(s.Namespace).Create(ctx, ev, metav1.CreateOptions{}); err != nil {
+		log.Printf("operator: event %s on %s/%s: %v", reason, s.Namespace, s.Name, err)
+	}
+}
+
+func (c *Controller) updateStatus(ctx context.Context, obj *unstructured.Unstructured, st Status) error {
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&st)
+	if err != nil {
+		return err
+	}
+	obj = obj.DeepCopy()
+	obj.Object["status"] = raw
+	_, err = c.Dynamic.Resource(GVR).Namespace(obj.GetNamespace()).UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
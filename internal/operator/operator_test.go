@@ -0,0 +1,231 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func drscan(name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": GVR.GroupVersion().String(),
+		"kind":       Kind,
+		"metadata":   map[string]any{"name": name, "namespace": "dr", "uid": "uid-" + name},
+		"spec":       spec,
+	}}
+}
+
+type fixture struct {
+	t     *testing.T
+	c     *Controller
+	now   time.Time
+	score int
+	crit  []string // resource IDs with a CRITICAL finding
+	scans int
+	fail  error
+	// scanned holds the namespaces each DRScan's last scan was given.
+	scanned map[string][]string
+}
+
+func newFixture(t *testing.T, objs ...runtime.Object) *fixture {
+	f := &fixture{t: t, now: time.Date(2026, 5, 4, 10, 30, 0, 0, time.UTC), score: 80, scanned: map[string][]string{}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{GVR: Kind + "List"}, objs...)
+	f.c = &Controller{
+		Dynamic: dyn,
+		Kube:    kubefake.NewSimpleClientset(),
+		Now:     func() time.Time { return f.now },
+		Scan: func(_ context.Context, s *DRScan) (*model.Bundle, error) {
+			if f.fail != nil {
+				return nil, f.fail
+			}
+			f.scans++
+			f.scanned[s.Name] = s.Spec.Namespaces
+			b := model.NewBundle("scan", f.now)
+			b.Profile = s.Spec.Profile
+			b.Score.Overall.Final = f.score
+			b.Score.Maturity = "GOLD"
+			for _, r := range f.crit {
+				b.Inventory.Findings = append(b.Inventory.Findings, model.Finding{ID: "PVC_UNBOUND", Severity: "CRITICAL", ResourceID: r})
+			}
+			b.Inventory.Findings = append(b.Inventory.Findings, model.Finding{ID: "SA_AUTOMOUNT_TOKEN", Severity: "MEDIUM"})
+			return &b, nil
+		},
+	}
+	return f
+}
+
+func (f *fixture) reconcile() {
+	f.t.Helper()
+	if err := f.c.Reconcile(context.Background()); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f *fixture) status(name string) Status {
+	f.t.Helper()
+	obj, err := f.c.Dynamic.Resource(GVR).Namespace("dr").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	var s DRScan
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &s); err != nil {
+		f.t.Fatal(err)
+	}
+	return s.Status
+}
+
+// events returns the reasons of the Events recorded so far, sorted, and
+// clears them.
+func (f *fixture) events() []string {
+	f.t.Helper()
+	list, err := f.c.Kube.CoreV1().Events("dr").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	var reasons []string
+	for _, ev := range list.Items {
+		reasons = append(reasons, ev.Reason)
+		if err := f.c.Kube.CoreV1().Events("dr").Delete(context.Background(), ev.Name, metav1.DeleteOptions{}); err != nil {
+			f.t.Fatal(err)
+		}
+	}
+	sort.Strings(reasons)
+	return reasons
+}
+
+func TestReconcile(t *testing.T) {
+	f := newFixture(t, drscan("nightly", map[string]any{"schedule": "0 * * * *", "minScore": int64(70), "profile": "enterprise"}))
+
+	f.reconcile()
+	st := f.status("nightly")
+	if f.scans != 1 || st.Score != 80 || !st.Passed || st.Findings.Medium != 1 || st.NextScanTime != "2026-05-04T11:00:00Z" {
+		t.Fatalf("after first scan: scans=%d status=%+v", f.scans, st)
+	}
+	if got := f.events(); len(got) != 1 || got[0] != "ScanCompleted" {
+		t.Errorf("events %v; want ScanCompleted", got)
+	}
+	cm, err := f.c.Kube.CoreV1().ConfigMaps("dr").Get(context.Background(), "nightly-result", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary model.ScanSummary
+	if err := json.Unmarshal([]byte(cm.Data["summary.json"]), &summary); err != nil || summary.Overall != 80 || summary.Profile != "enterprise" {
+		t.Errorf("summary.json = %s (%v)", cm.Data["summary.json"], err)
+	}
+	if cm.Data["findings.json"] == "" || len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].UID != "uid-nightly" {
+		t.Errorf("configmap %+v", cm)
+	}
+
+	// Not due until 11:00.
+	f.now = f.now.Add(20 * time.Minute)
+	f.reconcile()
+	if f.scans != 1 {
+		t.Errorf("rescanned before the schedule")
+	}
+
+	// The score drops below minScore and a CRITICAL finding appears.
+	f.now = f.now.Add(15 * time.Minute)
+	f.score, f.crit = 60, []string{"pvc:db/data"}
+	f.reconcile()
+	st = f.status("nightly")
+	if f.scans != 2 || st.Passed || len(st.CriticalFindings) != 1 {
+		t.Fatalf("after second scan: scans=%d status=%+v", f.scans, st)
+	}
+	if got, want := f.events(), []string{"BelowMinScore", "NewCriticalFindings", "ScanCompleted", "ScoreDropped"}; !equal(got, want) {
+		t.Errorf("events %v; want %v", got, want)
+	}
+
+	// Same result an hour later: nothing new to flag.
+	f.now = f.now.Add(time.Hour)
+	f.reconcile()
+	if got := f.events(); len(got) != 1 || got[0] != "ScanCompleted" {
+		t.Errorf("events %v; want ScanCompleted only", got)
+	}
+}
+
+func TestReconcileErrors(t *testing.T) {
+	f := newFixture(t,
+		drscan("bad", map[string]any{"schedule": "every day"}),
+		drscan("off", map[string]any{"suspend": true}),
+		drscan("failing", map[string]any{}),
+	)
+	f.fail = errors.New("boom")
+	f.reconcile()
+	if st := f.status("bad"); st.Message == "" || st.LastScanTime != "" {
+		t.Errorf("bad schedule status %+v", st)
+	}
+	if st := f.status("failing"); st.Message != "scan failed: boom" || st.NextScanTime != "2026-05-04T11:00:00Z" {
+		t.Errorf("failed scan status %+v", st)
+	}
+	if st := f.status("off"); st.NextScanTime != "" {
+		t.Errorf("suspended drscan was reconciled: %+v", st)
+	}
+	if got, want := f.events(), []string{"InvalidSchedule", "ScanFailed"}; !equal(got, want) {
+		t.Errorf("events %v; want %v", got, want)
+	}
+
+	// The invalid schedule is reported once, and the failed scan waits for
+	// its next run.
+	f.fail = nil
+	f.reconcile()
+	if got := f.events(); len(got) != 0 || f.scans != 0 {
+		t.Errorf("events %v, scans %d; want none", got, f.scans)
+	}
+}
+
+func TestReconcileScope(t *testing.T) {
+	f := newFixture(t,
+		drscan("own", map[string]any{}),
+		drscan("wide", map[string]any{"namespaces": []any{"dr", "shop"}}),
+	)
+	// Watching every namespace, no DRScan may scan beyond its own.
+	f.reconcile()
+	if got := f.scanned["own"]; !equal(got, []string{"dr"}) {
+		t.Errorf("own scanned %v; want its own namespace dr", got)
+	}
+	if _, ok := f.scanned["wide"]; ok || f.status("wide").Message == "" {
+		t.Errorf("wide scanned %v with status %+v; want it rejected", f.scanned["wide"], f.status("wide"))
+	}
+	if got, want := f.events(), []string{"NamespaceNotAllowed", "ScanCompleted"}; !equal(got, want) {
+		t.Errorf("events %v; want %v", got, want)
+	}
+
+	// In the operator namespace, an empty list scans the whole cluster.
+	f = newFixture(t,
+		drscan("own", map[string]any{}),
+		drscan("wide", map[string]any{"namespaces": []any{"dr", "shop"}}),
+	)
+	f.c.Namespace = "dr"
+	f.reconcile()
+	if got, ok := f.scanned["own"]; !ok || len(got) != 0 {
+		t.Errorf("own scanned %v; want all namespaces", got)
+	}
+	if got := f.scanned["wide"]; !equal(got, []string{"dr", "shop"}) {
+		t.Errorf("wide scanned %v; want dr and shop", got)
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+// Package operator runs scans continuously in-cluster. Each DRScan custom
+// resource asks for a scan on a cron schedule; the result is written to the
+// resource's status and a ConfigMap, and Events flag regressions.
+package operator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// GVR is the DRScan resource defined by deploy/drscan-crd.yaml.
+var GVR = schema.GroupVersionResource{Group: "dr.example", Version: "v1alpha1", Resource: "drscans"}
+
+// Kind is the DRScan kind, used in owner references and Events.
+const Kind = "DRScan"
+
+// DefaultSchedule applies when a DRScan has no schedule.
+const DefaultSchedule = "@hourly"
+
+// DRScan is a scan the operator repeats on Spec.Schedule.
+type DRScan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              Spec   `json:"spec"`
+	Status            Status `json:"status,omitempty"`
+}
+
+// Spec mirrors the scan flags a DRScan may set.
+type Spec struct {
+	// Schedule is a cron expression, @descriptor or "@every <duration>".
+	Schedule string `json:"schedule,omitempty"`
+	// Namespaces limits the scan, like --namespace. Empty scans all
+	// namespaces for a DRScan in the operator namespace, and the DRScan's
+	// own namespace otherwise.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Profile is the scoring profile, like --profile.
+	Profile string `json:"profile,omitempty"`
+	// MinScore is the overall score below which the scan fails; 0 disables it.
+	MinScore int `json:"minScore,omitempty"`
+	// Suspend stops further scans without deleting the resource.
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// Status is the outcome of the most recent scan. Times are RFC 3339.
+type Status struct {
+	ObservedGeneration int64               `json:"observedGeneration,omitempty"`
+	LastScanTime       string              `json:"lastScanTime,omitempty"`
+	NextScanTime       string              `json:"nextScanTime,omitempty"`
+	ScanID             string              `json:"scanId,omitempty"`
+	Score              int                 `json:"score"`
+	Maturity           string              `json:"maturity,omitempty"`
+	Scores             map[string]int      `json:"scores,omitempty"` // storage, workload, config, backup
+	Findings           model.FindingCounts `json:"findings"`
+	// CriticalFindings are the active CRITICAL findings as "ID|resource",
+	// kept so the next scan can tell which are new.
+	CriticalFindings []string `json:"criticalFindings,omitempty"`
+	Passed           bool     `json:"passed"`
+	ResultConfigMap  string   `json:"resultConfigMap,omitempty"`
+	Message          string   `json:"message,omitempty"`
+}
+
+func (s *DRScan) schedule() string {
+	if s.Spec.Schedule == "" {
+		return DefaultSchedule
+	}
+	return s.Spec.Schedule
+}
@@ -26,7 +26,9 @@ func WriteCSV(outDir string, b *model.Bundle) error {
 		writeImagesCSV,
 		writeHelmCSV,
 		writeCertificatesCSV,
+		writeApplicationsCSV,
 		writeDRScoreCSV,
+		writeFindingsCSV,
 		writeRemediationCSV,
 	}
 	for _, fn := range writers {
@@ -228,6 +230,22 @@ func writeHelmCSV(dir string, b *model.Bundle) error {
 	return w.Error()
 }
 
+func writeApplicationsCSV(dir string, b *model.Bundle) error {
+	f, w, err := csvFile(dir, "applications.csv")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_ = w.Write([]string{"Application", "Namespaces", "Grouped By", "Resources", "PVCs", "Protected PVCs", "PVC GiB", "Coverage", "Tools", "RPO Hours", "Findings", "Worst Severity"})
+	for _, a := range b.Inventory.Applications {
+		_ = w.Write([]string{a.Name, strings.Join(a.Namespaces, ";"), a.Source, fmt.Sprintf("%d", len(a.Resources)),
+			fmt.Sprintf("%d", a.PVCs), fmt.Sprintf("%d", a.ProtectedPVCs), fmt.Sprintf("%.1f", a.PVCSizeGB), a.Coverage,
+			strings.Join(a.Tools, ";"), fmt.Sprintf("%d", a.RPOHours), strings.Join(a.Findings, ";"), a.WorstSeverity})
+	}
+	w.Flush()
+	return w.Error()
+}
+
 func writeCertificatesCSV(dir string, b *model.Bundle) error {
 	f, w, err := csvFile(dir, "certificates.csv")
 	if err != nil {
@@ -259,9 +277,31 @@ func writeDRScoreCSV(dir string, b *model.Bundle) error {
 	_ = w.Write([]string{"Backup/Recovery", fmt.Sprintf("%d", b.Score.Backup.Final), "100", "30%"})
 	_ = w.Write([]string{"Overall", fmt.Sprintf("%d", b.Score.Overall.Final), "100", "100%"})
 	_ = w.Write([]string{})
-	_ = w.Write([]string{"Severity", "Resource", "Finding", "Recommendation"})
+	_ = w.Write([]string{"Severity", "Resource", "Finding", "Recommendation", "Finding ID", "Domain"})
 	for _, finding := range b.Inventory.Findings {
-		_ = w.Write([]string{finding.Severity, finding.ResourceID, finding.Message, finding.Recommendation})
+		_ = w.Write([]string{finding.Severity, finding.ResourceID, finding.Message, finding.Recommendation, finding.ID, finding.Domain})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeFindingsCSV writes one row per affected resource, so aggregate
+// findings (e.g. POD_NO_REQUESTS) list every object rather than a summary.
+func writeFindingsCSV(dir string, b *model.Bundle) error {
+	f, w, err := csvFile(dir, "findings.csv")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_ = w.Write([]string{"Finding ID", "Severity", "Domain", "Kind", "Namespace", "Name", "Finding", "Recommendation"})
+	for _, finding := range b.Inventory.Findings {
+		if len(finding.Resources) == 0 {
+			_ = w.Write([]string{finding.ID, finding.Severity, finding.Domain, "", "", finding.ResourceID, finding.Message, finding.Recommendation})
+			continue
+		}
+		for _, r := range finding.Resources {
+			_ = w.Write([]string{finding.ID, finding.Severity, finding.Domain, r.Kind, r.Namespace, r.Name, finding.Message, finding.Recommendation})
+		}
 	}
 	w.Flush()
 	return w.Error()
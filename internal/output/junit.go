@@ -0,0 +1,114 @@
+package output
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// JUnitSuites is a JUnit XML document, the format CI systems (GitHub
+// Actions, GitLab, Jenkins, Azure Pipelines) render as test results.
+type JUnitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr,omitempty"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []JUnitSuite `xml:"testsuite"`
+}
+
+// JUnitSuite groups test cases. Counts are filled in by WriteJUnit.
+type JUnitSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase passes unless Failure or Skipped is set.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitFailure is a failed test case: Message is the one-line summary,
+// Text the detail.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitSkipped marks a test case that was not evaluated.
+type JUnitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// ChecksJUnit maps each of b's checks (analyze.BuildChecks) to a test
+// case. FAIL checks fail; WARN checks pass with the warning in their output,
+// as JUnit has no warning state.
+func ChecksJUnit(b *model.Bundle) JUnitSuites {
+	suite := JUnitSuite{Name: "dr-checks", Timestamp: b.Metadata.GeneratedAt}
+	if b.Metadata.ClusterName != "" {
+		suite.Name += " " + b.Metadata.ClusterName
+	}
+	for _, c := range b.Checks {
+		area, _, _ := strings.Cut(c.ID, ".")
+		tc := JUnitTestCase{Name: c.ID + ": " + c.Title, ClassName: "dr." + area, SystemOut: c.Status + ": " + c.Message}
+		if c.Status == "FAIL" {
+			tc.Failure = &JUnitFailure{Message: c.Message, Type: c.Status, Text: c.Remediation}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	return JUnitSuites{Name: "k8s-recovery-visualizer", Suites: []JUnitSuite{suite}}
+}
+
+// WriteChecksJUnit writes b's checks as JUnit XML to path.
+func WriteChecksJUnit(path string, b *model.Bundle) error {
+	return WriteJUnit(path, ChecksJUnit(b))
+}
+
+// WriteJUnit totals the test, failure and skip counts and writes doc to
+// path.
+func WriteJUnit(path string, doc JUnitSuites) error {
+	doc.Tests, doc.Failures, doc.Skipped = 0, 0, 0
+	for i := range doc.Suites {
+		s := &doc.Suites[i]
+		s.Tests, s.Failures, s.Skipped = len(s.Cases), 0, 0
+		for _, c := range s.Cases {
+			switch {
+			case c.Failure != nil:
+				s.Failures++
+			case c.Skipped != nil:
+				s.Skipped++
+			}
+		}
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+		doc.Skipped += s.Skipped
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err = f.WriteString("\n")
+	return err
+}
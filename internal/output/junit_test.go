@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestChecksJUnit(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Checks = []model.Check{
+		{ID: "backup.tool", Title: "Backup tool", Status: "PASS"},
+		{ID: "backup.restore", Title: "Restore tested", Status: "WARN", Message: "no restore in 30 days"},
+		{ID: "storage.pvc", Title: "PVCs bound", Status: "FAIL", Message: "2 PVCs unbound", Remediation: "bind them"},
+	}
+	doc := ChecksJUnit(&b)
+	if len(doc.Suites) != 1 || len(doc.Suites[0].Cases) != 3 {
+		t.Fatalf("suites = %+v, want one suite with 3 cases", doc.Suites)
+	}
+	cases := doc.Suites[0].Cases
+	if cases[0].Failure != nil || cases[1].Failure != nil {
+		t.Error("PASS and WARN checks failed")
+	}
+	if f := cases[2].Failure; f == nil || f.Message != "2 PVCs unbound" || f.Text != "bind them" {
+		t.Errorf("FAIL check failure = %+v, want its message and remediation", f)
+	}
+	if cases[1].SystemOut != "WARN: no restore in 30 days" || cases[2].ClassName != "dr.storage" {
+		t.Errorf("cases = %+v", cases)
+	}
+}
+
+func TestWriteJUnitCounts(t *testing.T) {
+	doc := JUnitSuites{Name: "test", Suites: []JUnitSuite{
+		{Name: "a", Cases: []JUnitTestCase{
+			{Name: "pass"},
+			{Name: "fail", Failure: &JUnitFailure{Message: "broken"}},
+			{Name: "skip", Skipped: &JUnitSkipped{Message: "not evaluated"}},
+		}},
+		{Name: "b", Tests: 99, Failures: 99, Cases: []JUnitTestCase{
+			{Name: "fail", Failure: &JUnitFailure{Message: "broken"}},
+		}},
+	}}
+	path := filepath.Join(t.TempDir(), "checks.xml")
+	if err := WriteJUnit(path, doc); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got JUnitSuites
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("written XML does not parse: %v\n%s", err, data)
+	}
+	if got.Tests != 4 || got.Failures != 2 || got.Skipped != 1 {
+		t.Errorf("totals = %d tests, %d failures, %d skipped; want 4, 2, 1", got.Tests, got.Failures, got.Skipped)
+	}
+	if a := got.Suites[0]; a.Tests != 3 || a.Failures != 1 || a.Skipped != 1 {
+		t.Errorf("suite a = %d/%d/%d; want 3 tests, 1 failure, 1 skipped", a.Tests, a.Failures, a.Skipped)
+	}
+	if b := got.Suites[1]; b.Tests != 1 || b.Failures != 1 || b.Skipped != 0 {
+		t.Errorf("suite b = %d/%d/%d; want its stale counts replaced with 1, 1, 0", b.Tests, b.Failures, b.Skipped)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"k8s-recovery-visualizer/internal/model"
 )
@@ -122,6 +123,57 @@ func redact(b *model.Bundle) *model.Bundle {
 		r.Inventory.Certificates[i].Namespace = replaceNS(r.Inventory.Certificates[i].Namespace)
 	}
 
+	if plan := r.Inventory.RestorePlan; plan != nil {
+		// Step IDs are Kind/namespace/name, or Namespace/name.
+		replaceID := func(id string) string {
+			parts := strings.Split(id, "/")
+			if len(parts) == 3 || parts[0] == "Namespace" {
+				parts[1] = replaceNS(parts[1])
+			}
+			return strings.Join(parts, "/")
+		}
+		for i := range plan.Steps {
+			st := &plan.Steps[i]
+			st.ID = replaceID(st.ID)
+			st.Namespace = replaceNS(st.Namespace)
+			if st.Kind == "Namespace" {
+				st.Name = replaceNS(st.Name)
+			}
+			for j := range st.DependsOn {
+				st.DependsOn[j] = replaceID(st.DependsOn[j])
+			}
+		}
+		for i := range plan.Cyclic {
+			plan.Cyclic[i] = replaceID(plan.Cyclic[i])
+		}
+	}
+	if pr := r.Portability; pr != nil {
+		pr.Target = "[redacted]"
+		for i := range pr.Gaps {
+			for j := range pr.Gaps[i].Affected {
+				pr.Gaps[i].Affected[j].Namespace = replaceNS(pr.Gaps[i].Affected[j].Namespace)
+			}
+		}
+	}
+	if cr := r.Capacity; cr != nil {
+		cr.Site = "[redacted]"
+		for i := range cr.Namespaces {
+			cr.Namespaces[i].Namespace = replaceNS(cr.Namespaces[i].Namespace)
+		}
+		for i := range cr.Oversized {
+			cr.Oversized[i].Namespace = replaceNS(cr.Oversized[i].Namespace)
+		}
+	}
+	for i := range r.Inventory.Applications {
+		a := &r.Inventory.Applications[i]
+		for j := range a.Namespaces {
+			a.Namespaces[j] = replaceNS(a.Namespaces[j])
+		}
+		for j := range a.Resources {
+			a.Resources[j].Namespace = replaceNS(a.Resources[j].Namespace)
+		}
+	}
+
 	// Redact node names in findings resourceIds
 	for i, f := range r.Inventory.Findings {
 		for real, token := range nodeMap {
@@ -130,6 +182,13 @@ func redact(b *model.Bundle) *model.Bundle {
 			}
 		}
 	}
+	for i, f := range r.Inventory.WaivedFindings {
+		for real, token := range nodeMap {
+			if f.ResourceID == real {
+				r.Inventory.WaivedFindings[i].ResourceID = token
+			}
+		}
+	}
 
 	// Clear scan namespaces (they contain real names)
 	r.ScanNamespaces = nil
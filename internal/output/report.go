@@ -35,10 +35,7 @@ func buildReport(buf *bytes.Buffer, b *model.Bundle) {
 	if platform == "" {
 		platform = "unknown"
 	}
-	backupTool := b.Inventory.Backup.PrimaryTool
-	if backupTool == "" {
-		backupTool = "none"
-	}
+	backupTool := b.Inventory.Backup.ToolList()
 	scopeLabel := "all namespaces"
 	if len(b.ScanNamespaces) > 0 {
 		scopeLabel = strings.Join(b.ScanNamespaces, ", ")
@@ -121,11 +118,21 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 		e(b.Metadata.ClusterName), e(platform), e(scopeLabel), e(activeProfile), e(b.Metadata.GeneratedAt),
 		matColor, matColor, e(b.Score.Maturity), b.Score.Overall.Final)
 
-	// Tab bar — add Compare tab only when comparison data is present
-	tabNames := []string{"Summary", "Nodes", "Workloads", "Storage", "Networking", "Config", "Images", "Backup", "DR Score", "Findings", "Remediation"}
+	// Tab bar — add Compare, Portability and Capacity tabs only when their data is present
+	tabNames := []string{"Summary", "Nodes", "Workloads", "Storage", "Networking", "Config", "Images", "Backup", "Applications", "DR Score", "Findings", "Remediation"}
 	if b.Comparison != nil {
 		tabNames = append(tabNames, "Compare")
 	}
+	portabilityTab := 0
+	if b.Portability != nil {
+		portabilityTab = len(tabNames)
+		tabNames = append(tabNames, "Portability")
+	}
+	capacityTab := 0
+	if b.Capacity != nil {
+		capacityTab = len(tabNames)
+		tabNames = append(tabNames, "Capacity")
+	}
 	w(`<div class="tabs">`)
 	for i, t := range tabNames {
 		cls := "tab"
@@ -161,7 +168,7 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 <tr><td>Provider</td><td>%s</td></tr>
 <tr><td>K8s Version</td><td>%s</td></tr>
 <tr><td>Cluster UID</td><td>%s</td></tr>
-<tr><td>Backup Tool</td><td class="%s">%s</td></tr>
+<tr><td>Backup Tools</td><td class="%s">%s</td></tr>
 <tr><td>Nodes</td><td>%d</td></tr>
 <tr><td>Namespaces</td><td>%d</td></tr>
 <tr><td>Helm Releases</td><td>%d</td></tr>
@@ -224,11 +231,17 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			row.color, row.label, sevBar(row.count, sevMax, row.color), row.color, row.count)
 	}
 	w(`</table>`)
+	if n := len(b.Inventory.WaivedFindings); n > 0 {
+		wf(`<p style="margin-top:8px;color:#8b949e;font-size:.86em">%d finding(s) waived as accepted risk — not counted above or in the score.</p>`, n)
+	}
 	w(`<p style="margin-top:10px;color:#8b949e;font-size:.86em">Full details → <strong onclick="showTab('Findings')" style="cursor:pointer;color:#58a6ff">Findings</strong> tab. Action steps → <strong onclick="showTab('Remediation')" style="cursor:pointer;color:#58a6ff">Remediation</strong> tab.</p>`)
 	w(`</div>`)
 
 	// Scan coverage / skipped collectors callout
-	totalCollectors := 25 // total number of optional collectors attempted
+	totalCollectors := len(b.CollectorRuns)
+	if totalCollectors == 0 {
+		totalCollectors = 25 // scans written before CollectorRuns existed
+	}
 	skipped := len(b.CollectorSkips)
 	rbacSkips := 0
 	for _, sk := range b.CollectorSkips {
@@ -1119,7 +1132,7 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 		w(`<div class="empty">No backup tool detected — no policies to display.</div>`)
 	} else if len(backupInv.Policies) == 0 {
 		wf(`<div class="empty" style="color:#ffa657">%s detected but no policies or schedules found. Create backup schedules to establish coverage.</div>`,
-			e(backupInv.PrimaryTool))
+			e(backupInv.ToolList()))
 	} else {
 		offsiteCount := 0
 		for _, p := range backupInv.Policies {
@@ -1130,7 +1143,7 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 		wf(`<p style="color:#8b949e;font-size:.84em;margin-bottom:8px">%d policies found &mdash; %d with offsite/export</p>`,
 			len(backupInv.Policies), offsiteCount)
 		w(`<table id="t-policies"><thead><tr>`)
-		for _, h := range []string{"Tool", "Name", "Namespaces", "Schedule", "RPO (h)", "Offsite", "Retention"} {
+		for _, h := range []string{"Tool", "Name", "Namespaces", "Schedule", "RPO (h)", "Offsite", "Retention", "Last Success", "Failure Streak"} {
 			wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
 		}
 		w(`</tr></thead><tbody>`)
@@ -1146,14 +1159,86 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			offsiteCell := `<span class="chip n">no</span>`
 			if p.HasOffsite {
 				offsiteCell = `<span class="chip p">yes</span>`
+				if len(p.ExportProfiles) > 0 {
+					offsiteCell += ` ` + e(strings.Join(p.ExportProfiles, ", "))
+				}
+			}
+			lastCell := `<span style="color:#8b949e">—</span>`
+			if p.LastSuccess != nil {
+				lastCell = e(p.LastSuccess.Format("2006-01-02 15:04"))
+			} else if p.LastRunState != "" {
+				lastCell = `<span class="c-HIGH">never</span>`
+			}
+			streakCell := `<span style="color:#8b949e">—</span>`
+			if p.FailureStreak > 0 {
+				streakCell = fmt.Sprintf(`<span class="c-HIGH">%d</span>`, p.FailureStreak)
+			} else if p.LastRunState != "" {
+				streakCell = "0"
 			}
-			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
-				e(p.Tool), e(p.Name), e(nsCell), e(p.Schedule), rpoCell, offsiteCell, e(p.RetentionTTL))
+			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				e(p.Tool), e(p.Name), e(nsCell), e(p.Schedule), rpoCell, offsiteCell, e(p.RetentionTTL), lastCell, streakCell)
 		}
 		w(`</tbody></table>`)
 	}
 	w(`</div>`) // policies card
 
+	// Protection by namespace — every tool's policies merged per namespace and PVC
+	if len(backupInv.Protection) > 0 {
+		w(`<div class="card"><h2>Protection by Namespace</h2>`)
+		w(`<table id="t-protection"><thead><tr>`)
+		for _, h := range []string{"Namespace", "Tools", "Policies", "RPO (h)", "Offsite", "PVCs"} {
+			wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+		}
+		w(`</tr></thead><tbody>`)
+		for _, np := range backupInv.Protection {
+			toolsCell := `<span class="chip f">none</span>`
+			if len(np.Tools) > 0 {
+				toolsCell = e(strings.Join(np.Tools, ", "))
+			}
+			rpoCell := `<span style="color:#8b949e">—</span>`
+			if np.RPOHours >= 0 {
+				rpoCell = fmt.Sprintf("%d", np.RPOHours)
+			}
+			offsiteCell := `<span class="chip n">no</span>`
+			if np.HasOffsite {
+				offsiteCell = `<span class="chip p">yes</span>`
+			}
+			var pvcs []string
+			for _, pp := range np.PVCs {
+				if len(pp.Tools) == 0 {
+					pvcs = append(pvcs, fmt.Sprintf(`<span class="c-HIGH">%s (unprotected)</span>`, e(pp.Name)))
+				} else {
+					pvcs = append(pvcs, fmt.Sprintf(`%s (%s)`, e(pp.Name), e(strings.Join(pp.Tools, ", "))))
+				}
+			}
+			pvcCell := `<span style="color:#8b949e">—</span>`
+			if len(pvcs) > 0 {
+				pvcCell = strings.Join(pvcs, "<br>")
+			}
+			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				e(np.Namespace), toolsCell, e(strings.Join(np.Policies, ", ")), rpoCell, offsiteCell, pvcCell)
+		}
+		w(`</tbody></table></div>`)
+	}
+
+	// Restore points card (Kasten RestorePoints per application)
+	if ev := backupInv.Evidence; ev != nil && len(ev.RestorePoints) > 0 {
+		w(`<div class="card"><h2>Restore Points</h2>`)
+		w(`<table id="t-rpoints"><thead><tr>`)
+		for _, h := range []string{"Application", "Restore Points", "Latest"} {
+			wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+		}
+		w(`</tr></thead><tbody>`)
+		for _, rp := range ev.RestorePoints {
+			latest := "—"
+			if rp.Latest != nil {
+				latest = rp.Latest.Format("2006-01-02 15:04")
+			}
+			wf(`<tr><td>%s</td><td>%d</td><td>%s</td></tr>`, e(rp.App), rp.Count, e(latest))
+		}
+		w(`</tbody></table></div>`)
+	}
+
 	// Restore simulation card
 	w(`<div class="card"><h2>Restore Simulation</h2>`)
 	if sim := backupInv.RestoreSim; sim == nil {
@@ -1170,6 +1255,7 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 <div class="sbox"><div class="v" style="color:%s">%d</div><div class="l">Uncovered</div></div>
 <div class="sbox"><div class="v">%.1f GB</div><div class="l">Total PVC Data</div></div>
 <div class="sbox"><div class="v">%.0f%%</div><div class="l">Coverage by Volume</div></div>
+<div class="sbox"><div class="v">%.1f h</div><div class="l">Est. Full Recovery</div></div>
 </div>`,
 			len(sim.Namespaces),
 			func() string {
@@ -1180,9 +1266,20 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			}(),
 			len(sim.UncoveredNS),
 			sim.TotalPVCsGB,
-			covPct)
+			covPct,
+			sim.EstimatedFullRecoveryHours)
+		if ev := backupInv.Evidence; ev != nil {
+			restoreCell := `<span class="c-MEDIUM">never</span>`
+			if sim.LastRestore != nil {
+				restoreCell = `<span class="ok">✓</span> ` + e(sim.LastRestore.Format("2006-01-02 15:04"))
+			} else if sim.RestoreTested {
+				restoreCell = `<span class="ok">✓</span>`
+			}
+			wf(`<p style="color:#8b949e;font-size:.84em;margin-bottom:8px">Backup objects: %d (%d completed, %d failed, %d partially failed) &mdash; last completed restore: %s</p>`,
+				ev.Backups, ev.Completed, ev.Failed, ev.PartiallyFailed, restoreCell)
+		}
 		w(`<table id="t-sim"><thead><tr>`)
-		for _, h := range []string{"Namespace", "Coverage", "RPO (h)", "PVC Data (GB)", "Blockers", "Warnings"} {
+		for _, h := range []string{"Namespace", "Coverage", "RPO (h)", "Measured RPO (h)", "PVC Data (GB)", "Est. RTO (h)", "Ready After (h)", "Blockers", "Warnings"} {
 			wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
 		}
 		w(`</tr></thead><tbody>`)
@@ -1199,6 +1296,16 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 				}
 				rpoCell = fmt.Sprintf(`<span style="color:%s">%d</span>`, color, ns.RPOHours)
 			}
+			measuredCell := `<span style="color:#8b949e">—</span>`
+			if ns.MeasuredRPOHours >= 0 {
+				color := "#7ee787"
+				if ns.MeasuredRPOHours > 24 {
+					color = "#ffa657"
+				}
+				measuredCell = fmt.Sprintf(`<span style="color:%s">%.1f</span>`, color, ns.MeasuredRPOHours)
+			} else if backupInv.Evidence != nil && ns.HasCoverage {
+				measuredCell = `<span class="c-HIGH">no successful backup</span>`
+			}
 			sizeCell := fmt.Sprintf("%.1f", ns.PVCSizeGB)
 			blockersCell := `<span style="color:#8b949e">—</span>`
 			if len(ns.Blockers) > 0 {
@@ -1208,13 +1315,69 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			if len(ns.Warnings) > 0 {
 				warningsCell = fmt.Sprintf(`<span class="c-MEDIUM">%s</span>`, e(strings.Join(ns.Warnings, "; ")))
 			}
-			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
-				e(ns.Namespace), covCell, rpoCell, sizeCell, blockersCell, warningsCell)
+			rtoTitle := ""
+			if bd := ns.RTO; bd != nil {
+				rtoTitle = fmt.Sprintf("base %gh, %d objects %gh, %d PVCs %gh, data %gh, %d images %gh",
+					bd.BaseHours, ns.ObjectCount, bd.ObjectsHours, ns.PVCCount, bd.VolumesHours, bd.DataHours, ns.ImageCount, bd.ImagesHours)
+			}
+			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td title="%s">%.1f</td><td>%.1f</td><td>%s</td><td>%s</td></tr>`,
+				e(ns.Namespace), covCell, rpoCell, measuredCell, sizeCell, e(rtoTitle), ns.EstimatedRTOHours, ns.ReadyAfterHours, blockersCell, warningsCell)
 		}
 		w(`</tbody></table>`)
+		m := sim.RTOModel
+		wf(`<p style="color:#8b949e;font-size:.84em;margin-top:8px">Ready After assumes %.0f min of cluster setup, then %d namespace(s) restored at a time, tightest RTO target first. Data restores at %g GB/h unless <code>--rto-model</code> sets a rate for the provisioner or backup tool.</p>`,
+			m.ClusterMinutes, m.Parallel, m.GBPerHour)
 	}
 	w(`</div>`) // restore sim card
 
+	// ── Recovery targets: declared RPO/RTO vs achieved ───────────────────
+	if sim := backupInv.RestoreSim; sim != nil {
+		var targeted []model.RestoreSimNamespace
+		for _, ns := range sim.Namespaces {
+			if ns.Target != nil {
+				targeted = append(targeted, ns)
+			}
+		}
+		if len(targeted) > 0 {
+			status := func(s string) string {
+				switch s {
+				case "met":
+					return `<span class="chip p">met</span>`
+				case "missed":
+					return `<span class="chip f">missed</span>`
+				case "unknown":
+					return `<span class="chip n">unknown</span>`
+				}
+				return `<span style="color:#8b949e">—</span>`
+			}
+			hours := func(h float64) string {
+				if h <= 0 {
+					return `<span style="color:#8b949e">—</span>`
+				}
+				return fmt.Sprintf("%g", h)
+			}
+			w(`<div class="card"><h2>Recovery Targets</h2>`)
+			w(`<p style="color:#8b949e;font-size:.84em;margin-bottom:8px">Declared by <code>dr.example/rpo</code> / <code>dr.example/rto</code> namespace annotations or <code>--targets</code>. Achieved RPO is the worse of the scheduled and measured RPO; RTO is compared with when the namespace is ready in a full-cluster recovery.</p>`)
+			w(`<table id="t-targets"><thead><tr>`)
+			for _, h := range []string{"Namespace", "Source", "RPO Target (h)", "Achieved RPO (h)", "RPO", "RTO Target (h)", "Ready After (h)", "RTO"} {
+				wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+			}
+			w(`</tr></thead><tbody>`)
+			for _, ns := range targeted {
+				achieved := `<span style="color:#8b949e">—</span>`
+				if ns.AchievedRPOHours >= 0 {
+					achieved = fmt.Sprintf("%.1f", ns.AchievedRPOHours)
+				} else if !ns.HasCoverage {
+					achieved = `<span class="c-HIGH">no coverage</span>`
+				}
+				wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%.1f</td><td>%s</td></tr>`,
+					e(ns.Namespace), e(ns.Target.Source), hours(ns.Target.RPOHours), achieved, status(ns.RPOStatus),
+					hours(ns.Target.RTOHours), ns.ReadyAfterHours, status(ns.RTOStatus))
+			}
+			w(`</tbody></table></div>`)
+		}
+	}
+
 	// ── Round 14: etcd backup status ─────────────────────────────────────
 	w(`<div class="card"><h2>etcd Backup Status</h2>`)
 	if eb := b.Inventory.EtcdBackup; eb == nil {
@@ -1241,8 +1404,41 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 
 	w(`</div>`) // p7
 
-	// ── Tab 8: DR Score ──────────────────────────────────────────────────────
-	w(`<div class="pane" id="p8"><h2>DR Score Breakdown</h2>`)
+	// ── Tab 8: Applications ──────────────────────────────────────────────────
+	w(`<div class="pane" id="p8"><h2>Applications</h2>`)
+	if len(b.Inventory.Applications) == 0 {
+		w(`<div class="empty">No applications found outside the system namespaces.</div>`)
+	} else {
+		w(`<p style="color:#8b949e;font-size:.84em">Resources grouped by <code>app.kubernetes.io/part-of</code>, <code>instance</code> and <code>name</code> labels, Helm release and owner references. Coverage counts protected PVCs, or covered namespaces for stateless apps; RPO is the worst across the app's namespaces.</p>`)
+		w(`<table id="t-apps"><thead><tr>`)
+		for _, h := range []string{"Application", "Namespaces", "Grouped By", "Resources", "PVCs Protected", "PVC Data (GiB)", "Coverage", "Tools", "RPO (h)", "Findings"} {
+			wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+		}
+		w(`</tr></thead><tbody>`)
+		for _, a := range b.Inventory.Applications {
+			cov := map[string]string{"full": "p", "partial": "w", "none": "f"}[a.Coverage]
+			rpo := `<span style="color:#8b949e">—</span>`
+			if a.RPOHours >= 0 {
+				rpo = fmt.Sprintf("%d", a.RPOHours)
+			}
+			findings := `<span style="color:#8b949e">—</span>`
+			if len(a.Findings) > 0 {
+				findings = fmt.Sprintf(`<span class="c-%s">%s</span>`, e(a.WorstSeverity), e(strings.Join(a.Findings, ", ")))
+			}
+			kinds := make([]string, 0, len(a.Resources))
+			for _, r := range a.Resources {
+				kinds = append(kinds, r.Kind+" "+r.String())
+			}
+			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td title="%s">%d</td><td>%d / %d</td><td>%.1f</td><td><span class="chip %s">%s</span></td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				e(a.Name), e(strings.Join(a.Namespaces, ", ")), e(a.Source), e(strings.Join(kinds, "\n")), len(a.Resources),
+				a.ProtectedPVCs, a.PVCs, a.PVCSizeGB, cov, e(a.Coverage), e(strings.Join(a.Tools, ", ")), rpo, findings)
+		}
+		w(`</tbody></table>`)
+	}
+	w(`</div>`) // p8
+
+	// ── Tab 9: DR Score ──────────────────────────────────────────────────────
+	w(`<div class="pane" id="p9"><h2>DR Score Breakdown</h2>`)
 	w(`<table id="t-score"><thead><tr>`)
 	for _, h := range []string{"Domain", "Score", "Max", "Weight"} {
 		wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
@@ -1303,6 +1499,12 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 		}
 		w(`</tbody></table>`)
 	}
+	if b.RulesProfile != "" {
+		wf(`<p style="color:#8b949e;font-size:.86em;margin-top:8px">Rules profile <strong style="color:#c9d1d9">%s</strong> applied (<code>--rules-file</code>): findings may be disabled or carry overridden severity and penalty.</p>`, e(b.RulesProfile))
+	}
+	if len(b.ExcludeNamespaces) > 0 {
+		wf(`<p style="color:#8b949e;font-size:.86em">Excluded namespaces: %s</p>`, e(strings.Join(b.ExcludeNamespaces, ", ")))
+	}
 	w(`</div>`)
 
 	w(`<h2 style="margin-top:20px">Findings</h2>`)
@@ -1330,10 +1532,10 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 		}
 		w(`</tbody></table>`)
 	}
-	w(`</div>`) // p8
+	w(`</div>`) // p9
 
-	// ── Tab 9: Findings ───────────────────────────────────────────────────────
-	w(`<div class="pane" id="p9"><h2>Findings</h2>`)
+	// ── Tab 10: Findings ──────────────────────────────────────────────────────
+	w(`<div class="pane" id="p10"><h2>Findings</h2>`)
 	if len(b.Inventory.Findings) == 0 {
 		w(`<div class="empty">No findings — cluster passed all checks.</div>`)
 	} else {
@@ -1419,15 +1621,36 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			if remI, ok := remIdx[f.ID]; ok {
 				actionCell = fmt.Sprintf(`<a href="#" onclick="showRemStep(%d);return false;" style="color:#58a6ff;font-size:.82em;white-space:nowrap">→ Remediation #%d</a>`, remI, remI+1)
 			}
+			idCell := e(f.ID)
+			if f.Custom {
+				idCell += ` <span class="badge" style="background:#1f6feb33;color:#58a6ff;border-color:#1f6feb;font-size:.75em;padding:1px 6px">custom</span>`
+			}
+			if f.Waiver != nil && f.Waiver.Expired {
+				idCell += fmt.Sprintf(` <span class="badge" style="background:#f8514922;color:#f85149;border-color:#f85149;font-size:.75em;padding:1px 6px" title="owner: %s">waiver expired %s</span>`, e(f.Waiver.Owner), e(f.Waiver.Expires))
+			}
 			wf(`<tr data-sev="%s"><td class="sev-%s">%s</td><td style="color:#8b949e;font-size:.82em">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
-				e(f.Severity), e(f.Severity), e(f.Severity), e(f.ID), e(f.ResourceID), e(f.Message), e(f.Recommendation), actionCell)
+				e(f.Severity), e(f.Severity), e(f.Severity), idCell, resourceCell(f), e(f.Message), e(f.Recommendation), actionCell)
 		}
 		w(`</tbody></table>`)
 	}
-	w(`</div>`) // p9
+	if len(b.Inventory.WaivedFindings) > 0 {
+		w(`<h2 style="margin-top:20px">Waived</h2>`)
+		w(`<p style="color:#8b949e;font-size:.86em;margin-bottom:8px">Accepted risks from <code>--waivers</code>. These findings carry no penalty until the waiver expires, after which they are reported again.</p>`)
+		w(`<table id="t-waived"><thead><tr>`)
+		for _, h := range []string{"Severity", "ID", "Resource", "Finding", "Owner", "Justification", "Expires"} {
+			wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+		}
+		w(`</tr></thead><tbody>`)
+		for _, f := range b.Inventory.WaivedFindings {
+			wf(`<tr><td class="sev-%s">%s</td><td style="color:#8b949e;font-size:.82em">%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+				e(f.Severity), e(f.Severity), e(f.ID), resourceCell(f), e(f.Message), e(f.Waiver.Owner), e(f.Waiver.Justification), e(f.Waiver.Expires))
+		}
+		w(`</tbody></table>`)
+	}
+	w(`</div>`) // p10
 
-	// ── Tab 10: Remediation ───────────────────────────────────────────────────
-	w(`<div class="pane" id="p10"><h2>Remediation Plan</h2>`)
+	// ── Tab 11: Remediation ───────────────────────────────────────────────────
+	w(`<div class="pane" id="p11"><h2>Remediation Plan</h2>`)
 	if len(b.Inventory.RemediationSteps) == 0 {
 		w(`<div class="empty">No remediation steps generated. Run with a live cluster to produce findings.</div>`)
 	} else {
@@ -1457,11 +1680,11 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			w(`</div></div>`)
 		}
 	}
-	w(`</div>`) // p10
+	w(`</div>`) // p11
 
-	// ── Tab 11: Compare (only rendered when --compare was used) ──────────────
+	// ── Tab 12: Compare (only rendered when --compare was used) ──────────────
 	if c := b.Comparison; c != nil {
-		w(`<div class="pane" id="p11">`)
+		w(`<div class="pane" id="p12">`)
 		wf(`<h2>Comparison vs scan from %s</h2>`, e(c.PreviousScannedAt))
 
 		// Score delta card
@@ -1555,7 +1778,130 @@ pre{background:#0d1117;border:1px solid #21262d;border-radius:4px;padding:9px;ov
 			w(`<div class="card"><p class="ok">No finding changes between scans.</p></div>`)
 		}
 
-		w(`</div>`) // p10
+		w(`</div>`) // p12
+	}
+
+	// ── Portability (only rendered with --target-kubeconfig/--target-scan) ───
+	if pr := b.Portability; pr != nil {
+		wf(`<div class="pane" id="p%d">`, portabilityTab)
+		wf(`<h2>Portability to %s</h2>`, e(pr.Target))
+		c := pr.Capacity
+		gapColor := "#7ee787"
+		if len(pr.Gaps) > 0 {
+			gapColor = "#f85149"
+		}
+		skew := fmt.Sprintf("%+d", pr.VersionSkew)
+		if pr.SourceVersion == "" || pr.TargetVersion == "" {
+			skew = "—"
+		}
+		wf(`<div class="card">
+<div class="grid">
+<div class="sbox"><div class="v" style="color:%s">%d</div><div class="l">Gaps</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%s → %s</div><div class="l">K8s Version (skew %s)</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%.1f → %.1f</div><div class="l">Allocatable CPU (cores)</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%.1f → %.1f</div><div class="l">Allocatable Memory (GiB)</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%d → %d</div><div class="l">Ready Nodes</div></div>
+</div></div>`,
+			gapColor, len(pr.Gaps), e(pr.SourceVersion), e(pr.TargetVersion), e(skew),
+			float64(c.SourceCPUMillis)/1000, float64(c.TargetCPUMillis)/1000,
+			float64(c.SourceMemoryBytes)/(1<<30), float64(c.TargetMemoryBytes)/(1<<30),
+			c.SourceNodes, c.TargetNodes)
+
+		w(`<div class="card"><h2>Gaps</h2>`)
+		if len(pr.Gaps) == 0 {
+			w(`<p class="ok">Everything checked on the source is available on the target.</p>`)
+		} else {
+			w(`<table id="t-portability"><thead><tr>`)
+			for _, h := range []string{"Kind", "Name", "Detail", "Affected"} {
+				wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+			}
+			w(`</tr></thead><tbody>`)
+			for _, g := range pr.Gaps {
+				affected := make([]string, 0, len(g.Affected))
+				for _, r := range g.Affected {
+					affected = append(affected, r.Kind+" "+r.String())
+				}
+				wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+					e(g.Kind), e(g.Name), e(g.Detail), e(strings.Join(affected, ", ")))
+			}
+			w(`</tbody></table>`)
+		}
+		if len(pr.Unchecked) > 0 {
+			wf(`<p style="color:#8b949e;font-size:.84em">Not checked — the target's collectors failed or did not run: %s</p>`, e(strings.Join(pr.Unchecked, ", ")))
+		}
+		w(`</div>`)
+		w(`</div>`) // portability
+	}
+
+	// ── Capacity (only rendered with --dr-capacity or a DR target) ───────────
+	if cr := b.Capacity; cr != nil {
+		wf(`<div class="pane" id="p%d">`, capacityTab)
+		wf(`<h2>DR Site Capacity — %s</h2>`, e(cr.Site))
+		fitColor, fitLabel := "#7ee787", "Fits"
+		if len(cr.Short) > 0 {
+			fitColor, fitLabel = "#f85149", "Short: "+strings.Join(cr.Short, ", ")
+		}
+		pods := "—"
+		if cr.Pods > 0 {
+			pods = fmt.Sprintf("%d / %d", cr.RequiredPods, cr.Pods)
+		}
+		wf(`<div class="card">
+<div class="grid">
+<div class="sbox"><div class="v" style="color:%s;font-size:.8em">%s</div><div class="l">Protected Workloads</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%.1f / %.1f</div><div class="l">CPU Requested / Allocatable (cores)</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%.1f / %.1f</div><div class="l">Memory Requested / Allocatable (GiB)</div></div>
+<div class="sbox"><div class="v" style="font-size:.8em">%s</div><div class="l">Pods / Pod Slots</div></div>
+<div class="sbox"><div class="v">%d</div><div class="l">DR Nodes (%s)</div></div>
+<div class="sbox"><div class="v">%d</div><div class="l">Pods Without Requests</div></div>
+</div></div>`,
+			fitColor, e(fitLabel),
+			float64(cr.RequiredCPUMillis)/1000, float64(cr.CPUMillis)/1000,
+			float64(cr.RequiredMemoryBytes)/(1<<30), float64(cr.MemoryBytes)/(1<<30),
+			e(pods), cr.Nodes, e(cr.Source), cr.PodsWithoutRequests)
+
+		w(`<div class="card"><h2>Headroom by Namespace</h2>`)
+		w(`<p style="color:#8b949e;font-size:.84em">Protected namespaces in restore order; headroom is what the DR site has left once the namespace and those before it are running. Pods without requests count as zero.</p>`)
+		if len(cr.Namespaces) == 0 {
+			w(`<div class="empty">No running workload pods outside the system namespaces.</div>`)
+		} else {
+			w(`<table id="t-capacity"><thead><tr>`)
+			for _, h := range []string{"Namespace", "Protected", "Pods", "CPU (cores)", "Memory (GiB)", "CPU Headroom", "Memory Headroom", "Pod Headroom", "Fits"} {
+				wf(`<th onclick="sortTbl(this)">%s</th>`, e(h))
+			}
+			w(`</tr></thead><tbody>`)
+			for _, ns := range cr.Namespaces {
+				protected, cpuH, memH, podH, fits := "No", "—", "—", "—", "—"
+				if ns.Protected {
+					protected = "Yes"
+					cpuH = fmt.Sprintf("%.1f", float64(ns.HeadroomCPUMillis)/1000)
+					memH = fmt.Sprintf("%.1f", float64(ns.HeadroomMemoryBytes)/(1<<30))
+					if cr.Pods > 0 {
+						podH = fmt.Sprintf("%d", ns.HeadroomPods)
+					}
+					fits = `<span class="ok">Yes</span>`
+					if !ns.Fits {
+						fits = `<span class="bad">No</span>`
+					}
+				}
+				podsCell := fmt.Sprintf("%d", ns.Pods)
+				if ns.PodsWithoutRequests > 0 {
+					podsCell += fmt.Sprintf(" (%d without requests)", ns.PodsWithoutRequests)
+				}
+				wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%.1f</td><td>%.1f</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+					e(ns.Namespace), protected, e(podsCell),
+					float64(ns.CPUMillis)/1000, float64(ns.MemoryBytes)/(1<<30), cpuH, memH, podH, fits)
+			}
+			w(`</tbody></table>`)
+		}
+		if len(cr.Oversized) > 0 {
+			names := make([]string, 0, len(cr.Oversized))
+			for _, r := range cr.Oversized {
+				names = append(names, r.String())
+			}
+			wf(`<p class="c-MEDIUM" style="font-size:.84em">Larger than any single DR node: %s</p>`, e(strings.Join(names, ", ")))
+		}
+		w(`</div>`)
+		w(`</div>`) // capacity
 	}
 
 	// JS
@@ -1612,3 +1958,19 @@ function showRemStep(idx){
 }
 </script></body></html>`)
 }
+
+// resourceCell renders a finding's Resource column. Findings spanning more
+// than one object get an expandable list of every affected resource.
+func resourceCell(f model.Finding) string {
+	if len(f.Resources) <= 1 {
+		return html.EscapeString(f.ResourceID)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<details><summary>%s <span style="color:#8b949e">(%d)</span></summary><ul style="margin:4px 0 0 16px;font-size:.85em">`,
+		html.EscapeString(f.ResourceID), len(f.Resources))
+	for _, r := range f.Resources {
+		fmt.Fprintf(&sb, `<li><span style="color:#8b949e">%s</span> %s</li>`, html.EscapeString(r.Kind), html.EscapeString(r.String()))
+	}
+	sb.WriteString(`</ul></details>`)
+	return sb.String()
+}
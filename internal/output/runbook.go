@@ -35,10 +35,7 @@ func buildRunbook(buf *bytes.Buffer, b *model.Bundle) {
 	if platform == "" {
 		platform = "unknown"
 	}
-	backupTool := b.Inventory.Backup.PrimaryTool
-	if backupTool == "" {
-		backupTool = "none"
-	}
+	backupTool := b.Inventory.Backup.ToolList()
 
 	w(`<!DOCTYPE html><html lang="en"><head>
 <meta charset="utf-8"/>
@@ -160,9 +157,10 @@ pre{background:#f5f5f5;border:1px solid #ddd;border-radius:4px;padding:9px;font-
 <li><a href="#s1">Cluster Inventory</a></li>
 <li><a href="#s2">Backup &amp; Recovery Status</a></li>
 <li><a href="#s3">Restore Simulation</a></li>
-<li><a href="#s4">Findings</a></li>
-<li><a href="#s5">DR Remediation Playbook</a></li>
-<li><a href="#s6">Scan Metadata</a></li>
+<li><a href="#s4">Restore Order</a></li>
+<li><a href="#s5">Findings</a></li>
+<li><a href="#s6">DR Remediation Playbook</a></li>
+<li><a href="#s7">Scan Metadata</a></li>
 </ol></div>`)
 
 	// ── Section 1: Cluster Inventory ─────────────────────────────────────────
@@ -228,7 +226,7 @@ pre{background:#f5f5f5;border:1px solid #ddd;border-radius:4px;padding:9px;font-
 		offsiteStr = `<span class="ok">Yes</span>`
 	}
 	wf(`<table><tbody>
-<tr><th style="width:200px">Primary Backup Tool</th><td class="%s">%s</td></tr>
+<tr><th style="width:200px">Backup Tools</th><td class="%s">%s</td></tr>
 <tr><th>Offsite / Export Configured</th><td>%s</td></tr>
 <tr><th>Policies / Schedules Found</th><td>%d</td></tr>
 <tr><th>Covered Namespaces</th><td>%s</td></tr>
@@ -294,8 +292,15 @@ pre{background:#f5f5f5;border:1px solid #ddd;border-radius:4px;padding:9px;font-
 				}
 				return "ok"
 			}(), len(sim.UncoveredNS))
-		w(`<table><thead><tr><th>Namespace</th><th>Coverage</th><th>RPO (h)</th><th>PVC Data (GB)</th><th>Blockers</th><th>Warnings</th></tr></thead><tbody>`)
-		for _, ns := range sim.Namespaces {
+		m := sim.RTOModel
+		wf(`<p style="margin-bottom:8px">Estimated full recovery time: <strong>%.1f hours</strong></p>`, sim.EstimatedFullRecoveryHours)
+		wf(`<div class="note">Allow %.0f minutes to restore CRDs, operators and storage, then restore %d namespace(s) at a time in the order below. Estimates assume %g GB/h for PVC data, %g min per PVC and %g GB per image pulled at %g GB/h.</div>`,
+			m.ClusterMinutes, m.Parallel, m.GBPerHour, m.PVCMinutes, m.ImageGB, m.ImagePullGBPerHour)
+		ordered := make([]model.RestoreSimNamespace, len(sim.Namespaces))
+		copy(ordered, sim.Namespaces)
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].RestoreOrder < ordered[j].RestoreOrder })
+		w(`<table><thead><tr><th>#</th><th>Namespace</th><th>Coverage</th><th>RPO (h)</th><th>PVC Data (GB)</th><th>Est. RTO (h)</th><th>Ready After (h)</th><th>Blockers</th><th>Warnings</th></tr></thead><tbody>`)
+		for _, ns := range ordered {
 			covCell := `<span class="bad">none</span>`
 			if ns.HasCoverage {
 				covCell = `<span class="ok">covered</span>`
@@ -312,14 +317,55 @@ pre{background:#f5f5f5;border:1px solid #ddd;border-radius:4px;padding:9px;font-
 			if len(ns.Warnings) > 0 {
 				warningsCell = `<span class="c-MEDIUM">` + e(strings.Join(ns.Warnings, "; ")) + `</span>`
 			}
-			wf(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%.1f</td><td>%s</td><td>%s</td></tr>`,
-				e(ns.Namespace), covCell, rpoCell, ns.PVCSizeGB, blockersCell, warningsCell)
+			wf(`<tr><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%.1f</td><td>%.1f</td><td>%.1f</td><td>%s</td><td>%s</td></tr>`,
+				ns.RestoreOrder, e(ns.Namespace), covCell, rpoCell, ns.PVCSizeGB, ns.EstimatedRTOHours, ns.ReadyAfterHours, blockersCell, warningsCell)
+		}
+		w(`</tbody></table>`)
+	}
+
+	// ── Section 4: Restore Order ─────────────────────────────────────────────
+	w(`<h2 id="s4" class="section-break">4. Restore Order</h2>`)
+	if plan := b.Inventory.RestorePlan; plan == nil || len(plan.Steps) == 0 {
+		w(`<p style="color:#555">No objects to restore.</p>`)
+	} else {
+		w(`<p style="margin-bottom:8px">Restore in this order after a full loss. Each row waits for the rows its objects depend on (volumes and config before workloads, workloads before the Services in front of them); rows in the same wave can run together. The full dependency list is in <code>inventory.restorePlan</code> of the scan JSON.</p>`)
+		if len(plan.Cyclic) > 0 {
+			wf(`<div class="note">%d object(s) depend on each other in a cycle and are listed last: %s</div>`,
+				len(plan.Cyclic), e(strings.Join(plan.Cyclic, ", ")))
+		}
+		w(`<table><thead><tr><th>Wave</th><th>Kind</th><th>Namespace</th><th>Objects</th></tr></thead><tbody>`)
+		// One row per consecutive run of steps with the same wave, kind and
+		// namespace.
+		for i := 0; i < len(plan.Steps); {
+			s := plan.Steps[i]
+			j := i
+			var names []string
+			for ; j < len(plan.Steps); j++ {
+				t := plan.Steps[j]
+				if t.Wave != s.Wave || t.Kind != s.Kind || t.Namespace != s.Namespace || t.Operator != s.Operator {
+					break
+				}
+				names = append(names, t.Name)
+			}
+			kind := s.Kind
+			if s.Operator {
+				kind += " (operator)"
+			}
+			if len(names) > 8 {
+				names = append(names[:8], fmt.Sprintf("and %d more", len(names)-8))
+			}
+			ns := s.Namespace
+			if ns == "" {
+				ns = "—"
+			}
+			wf(`<tr><td>%d</td><td>%s</td><td>%s</td><td>%s</td></tr>`, s.Wave, e(kind), e(ns), e(strings.Join(names, ", ")))
+			i = j
 		}
 		w(`</tbody></table>`)
 	}
 
-	// ── Section 4: Findings ───────────────────────────────────────────────────
-	w(`<h2 id="s4" class="section-break">4. Findings</h2>`)
+	// ── Section 5: Findings ───────────────────────────────────────────────────
+	w(`<h2 id="s5" class="section-break">5. Findings</h2>`)
 	if len(b.Inventory.Findings) == 0 {
 		w(`<p class="ok">No findings recorded.</p>`)
 	} else {
@@ -362,8 +408,8 @@ pre{background:#f5f5f5;border:1px solid #ddd;border-radius:4px;padding:9px;font-
 		w(`</tbody></table>`)
 	}
 
-	// ── Section 5: Remediation Playbook ──────────────────────────────────────
-	w(`<h2 id="s5" class="section-break">5. DR Remediation Playbook</h2>`)
+	// ── Section 6: Remediation Playbook ──────────────────────────────────────
+	w(`<h2 id="s6" class="section-break">6. DR Remediation Playbook</h2>`)
 	if len(b.Inventory.RemediationSteps) == 0 {
 		w(`<p style="color:#555">No remediation steps generated. Run against a live cluster to produce findings.</p>`)
 	} else {
@@ -393,8 +439,8 @@ pre{background:#f5f5f5;border:1px solid #ddd;border-radius:4px;padding:9px;font-
 		}
 	}
 
-	// ── Section 6: Scan Metadata ─────────────────────────────────────────────
-	w(`<h2 id="s6">6. Scan Metadata</h2>`)
+	// ── Section 7: Scan Metadata ─────────────────────────────────────────────
+	w(`<h2 id="s7">7. Scan Metadata</h2>`)
 	wf(`<table><tbody>
 <tr><th style="width:200px">Scan ID</th><td>%s</td></tr>
 <tr><th>Tool Version</th><td>%s</td></tr>
@@ -0,0 +1,218 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// SARIF 2.1.0, the static analysis format code-scanning dashboards ingest.
+// Only the properties this tool fills in are modelled.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is a SARIF document.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is one tool's results.
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules,omitempty"`
+}
+
+// SARIFRule describes a result's ruleId once per run.
+type SARIFRule struct {
+	ID                   string              `json:"id"`
+	ShortDescription     *SARIFMessage       `json:"shortDescription,omitempty"`
+	Help                 *SARIFMessage       `json:"help,omitempty"`
+	DefaultConfiguration *SARIFConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]string   `json:"properties,omitempty"`
+}
+
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations,omitempty"`
+	// PartialFingerprints let dashboards track a result across runs.
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	// Suppressions marks waived findings, which dashboards show as dismissed.
+	Suppressions []SARIFSuppression `json:"suppressions,omitempty"`
+}
+
+type SARIFSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation places a result in the scan file it came from and, through
+// its logical location, on the Kubernetes object.
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// NewSARIFLog returns a single-run log for driver.
+func NewSARIFLog(driver SARIFDriver, results []SARIFResult) SARIFLog {
+	if results == nil {
+		results = []SARIFResult{}
+	}
+	return SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SARIFRun{{Tool: SARIFTool{Driver: driver}, Results: results}},
+	}
+}
+
+// SARIFLevel maps a finding severity to a SARIF level.
+func SARIFLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// FindingRule describes finding f's ID as a SARIF rule.
+func FindingRule(f model.Finding) SARIFRule {
+	r := SARIFRule{
+		ID:                   f.ID,
+		ShortDescription:     &SARIFMessage{Text: f.ID},
+		DefaultConfiguration: &SARIFConfiguration{Level: SARIFLevel(f.Severity)},
+		Properties:           map[string]string{"severity": f.Severity},
+	}
+	if f.Recommendation != "" {
+		r.Help = &SARIFMessage{Text: f.Recommendation}
+	}
+	if f.Domain != "" {
+		r.Properties["domain"] = f.Domain
+	}
+	return r
+}
+
+// FindingResult maps f to a SARIF result located in artifact (the scan
+// file) with one logical location per affected object, named
+// namespace/name.
+func FindingResult(f model.Finding, artifact string) SARIFResult {
+	res := SARIFResult{
+		RuleID:              f.ID,
+		Level:               SARIFLevel(f.Severity),
+		Message:             SARIFMessage{Text: f.Message},
+		PartialFingerprints: map[string]string{"findingKey/v1": f.ID + "|" + f.ResourceID},
+	}
+	physical := &SARIFPhysicalLocation{ArtifactLocation: SARIFArtifactLocation{URI: artifact}}
+	for _, r := range f.Resources {
+		res.Locations = append(res.Locations, SARIFLocation{
+			PhysicalLocation: physical,
+			LogicalLocations: []SARIFLogicalLocation{{Name: r.Name, FullyQualifiedName: r.String(), Kind: "resource"}},
+		})
+	}
+	if len(res.Locations) == 0 {
+		res.Locations = []SARIFLocation{{
+			PhysicalLocation: physical,
+			LogicalLocations: []SARIFLogicalLocation{{Name: f.ResourceID, FullyQualifiedName: f.ResourceID, Kind: "resource"}},
+		}}
+	}
+	return res
+}
+
+// FindingsSARIF maps every finding in b to a SARIF result, located in
+// artifact. Waived findings are included with an external suppression
+// naming the waiver's owner, justification and expiry.
+func FindingsSARIF(b *model.Bundle, artifact string) SARIFLog {
+	driver := SARIFDriver{Name: "k8s-recovery-visualizer", Version: b.Metadata.ToolVersion}
+	rules := map[string]SARIFRule{}
+	var results []SARIFResult
+	for _, f := range b.Inventory.Findings {
+		rules[f.ID] = FindingRule(f)
+		results = append(results, FindingResult(f, artifact))
+	}
+	for _, f := range b.Inventory.WaivedFindings {
+		if _, ok := rules[f.ID]; !ok {
+			rules[f.ID] = FindingRule(f)
+		}
+		res := FindingResult(f, artifact)
+		if w := f.Waiver; w != nil {
+			res.Suppressions = []SARIFSuppression{{
+				Kind:          "external",
+				Status:        "accepted",
+				Justification: fmt.Sprintf("%s (waived by %s until %s)", w.Justification, w.Owner, w.Expires),
+			}}
+		}
+		results = append(results, res)
+	}
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		driver.Rules = append(driver.Rules, rules[id])
+	}
+	return NewSARIFLog(driver, results)
+}
+
+// WriteFindingsSARIF writes the findings in b as SARIF to path; results
+// point at the recovery-scan.json next to it.
+func WriteFindingsSARIF(path string, b *model.Bundle) error {
+	return WriteSARIF(path, FindingsSARIF(b, "recovery-scan.json"))
+}
+
+// WriteSARIF writes log to path.
+func WriteSARIF(path string, log SARIFLog) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestSARIFLevel(t *testing.T) {
+	for sev, want := range map[string]string{
+		"CRITICAL": "error", "HIGH": "error", "MEDIUM": "warning", "LOW": "note", "INFO": "note", "": "note",
+	} {
+		if got := SARIFLevel(sev); got != want {
+			t.Errorf("SARIFLevel(%q) = %q, want %q", sev, got, want)
+		}
+	}
+}
+
+func TestFindingsSARIF(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Findings = []model.Finding{
+		{ID: "PVC_UNBOUND", Severity: "CRITICAL", Domain: "STORAGE", Message: "2 PVCs unbound", Resources: []model.ResourceRef{
+			{Kind: "PersistentVolumeClaim", Namespace: "db", Name: "data-0"},
+			{Kind: "PersistentVolumeClaim", Namespace: "db", Name: "data-1"},
+		}},
+		{ID: "SINGLE_AZ_CLUSTER", Severity: "MEDIUM", ResourceID: "cluster"},
+	}
+	b.Inventory.WaivedFindings = []model.Finding{{
+		ID: "POD_PRIVILEGED", Severity: "HIGH", ResourceID: "pod:kube-system/cni",
+		Waiver: &model.WaiverRef{Owner: "platform", Justification: "CNI needs host access", Expires: "2026-12-31"},
+	}}
+
+	log := FindingsSARIF(&b, "recovery-scan.json")
+	if len(log.Runs) != 1 {
+		t.Fatalf("runs = %d, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 || run.Tool.Driver.Rules[0].ID != "POD_PRIVILEGED" {
+		t.Errorf("rules = %+v, want POD_PRIVILEGED, PVC_UNBOUND, SINGLE_AZ_CLUSTER", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(run.Results))
+	}
+	pvc, az, waived := run.Results[0], run.Results[1], run.Results[2]
+
+	if pvc.Level != "error" || az.Level != "warning" || waived.Level != "error" {
+		t.Errorf("levels = %s, %s, %s; want error, warning, error", pvc.Level, az.Level, waived.Level)
+	}
+	if len(pvc.Locations) != 2 {
+		t.Fatalf("PVC_UNBOUND locations = %+v, want one per resource", pvc.Locations)
+	}
+	for i, want := range []string{"db/data-0", "db/data-1"} {
+		loc := pvc.Locations[i]
+		if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].FullyQualifiedName != want {
+			t.Errorf("location %d = %+v, want %s", i, loc.LogicalLocations, want)
+		}
+		if loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation.URI != "recovery-scan.json" {
+			t.Errorf("location %d artifact = %+v, want recovery-scan.json", i, loc.PhysicalLocation)
+		}
+	}
+	if len(az.Locations) != 1 || az.Locations[0].LogicalLocations[0].Name != "cluster" {
+		t.Errorf("SINGLE_AZ_CLUSTER locations = %+v, want the ResourceID", az.Locations)
+	}
+
+	if len(pvc.Suppressions) != 0 || len(az.Suppressions) != 0 {
+		t.Error("active findings carry suppressions")
+	}
+	if len(waived.Suppressions) != 1 {
+		t.Fatalf("waived suppressions = %+v, want one", waived.Suppressions)
+	}
+	s := waived.Suppressions[0]
+	if s.Kind != "external" || s.Status != "accepted" || !strings.Contains(s.Justification, "platform") || !strings.Contains(s.Justification, "2026-12-31") {
+		t.Errorf("suppression = %+v, want external, accepted, naming owner and expiry", s)
+	}
+}
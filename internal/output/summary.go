@@ -34,10 +34,7 @@ func buildSummary(buf *bytes.Buffer, b *model.Bundle) {
 	if platform == "" {
 		platform = "unknown"
 	}
-	backupTool := b.Inventory.Backup.PrimaryTool
-	if backupTool == "" {
-		backupTool = "none"
-	}
+	backupTool := b.Inventory.Backup.ToolList()
 
 	w(`<!DOCTYPE html><html lang="en"><head>
 <meta charset="utf-8"/>
@@ -138,7 +135,7 @@ td{padding:5px 8px;border-bottom:1px solid #e8e8e8;vertical-align:top}
 <table><tbody>
 <tr><td style="width:160px">Provider</td><td>%s</td><td style="width:160px">K8s Version</td><td>%s</td></tr>
 <tr><td>Nodes</td><td>%d</td><td>Namespaces</td><td>%d</td></tr>
-<tr><td>Backup Tool</td><td class="%s">%s</td><td>Recovery Target</td><td>%s</td></tr>
+<tr><td>Backup Tools</td><td class="%s">%s</td><td>Recovery Target</td><td>%s</td></tr>
 <tr><td>Helm Releases</td><td>%d</td><td>Certificates</td><td>%d</td></tr>
 </tbody></table>`,
 		e(platform), e(b.Cluster.Platform.K8sVersion),
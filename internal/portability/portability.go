@@ -0,0 +1,328 @@
+// Package portability checks whether what runs on the scanned cluster can be
+// restored onto a DR target cluster.
+package portability
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// Collectors are the collectors a target cluster scan needs.
+var Collectors = []string{"Nodes", "StorageClasses", "CRDs", "Platform", "VolumeSnapshotClasses", "CSIDrivers", "IngressClasses"}
+
+// Check compares source with target, a scan of the DR cluster named name,
+// and lists everything the source relies on that the target lacks:
+//
+//   - StorageClasses that source PVCs use;
+//   - the CSI drivers behind those StorageClasses and the source's
+//     VolumeSnapshotClasses;
+//   - a VolumeSnapshotClass for each snapshot driver;
+//   - custom API groups and versions the source serves;
+//   - IngressClasses source Ingresses name, or a default class when they
+//     rely on one;
+//   - node allocatable CPU and memory;
+//   - a Kubernetes version older than the source's, or more than one minor
+//     version newer.
+//
+// Checks whose target data was not collected are listed in Unchecked.
+func Check(source, target *model.Bundle, name string) model.PortabilityReport {
+	src, dst := source.Inventory, target.Inventory
+	r := model.PortabilityReport{
+		Target:        name,
+		ScannedAt:     target.Metadata.GeneratedAt,
+		SourceVersion: source.Cluster.Platform.K8sVersion,
+		TargetVersion: target.Cluster.Platform.K8sVersion,
+		Gaps:          []model.PortabilityGap{},
+	}
+	gap := func(kind, name, detail string, affected []model.ResourceRef) {
+		r.Gaps = append(r.Gaps, model.PortabilityGap{Kind: kind, Name: name, Detail: detail, Affected: affected})
+	}
+	checked := func(collector string) bool {
+		if collected(target, collector) {
+			return true
+		}
+		r.Unchecked = append(r.Unchecked, collector)
+		return false
+	}
+
+	// PVCs by StorageClass, and the classes' provisioners.
+	pvcsByClass := map[string][]model.ResourceRef{}
+	for _, pvc := range src.PVCs {
+		if pvc.StorageClass != "" {
+			pvcsByClass[pvc.StorageClass] = append(pvcsByClass[pvc.StorageClass],
+				model.ResourceRef{Kind: "PersistentVolumeClaim", Namespace: pvc.Namespace, Name: pvc.Name})
+		}
+	}
+	provisioner := map[string]string{}
+	for _, sc := range src.StorageClasses {
+		provisioner[sc.Name] = sc.Provisioner
+	}
+
+	if checked("StorageClasses") {
+		have := map[string]bool{}
+		for _, sc := range dst.StorageClasses {
+			have[sc.Name] = true
+		}
+		for _, class := range sortedKeys(pvcsByClass) {
+			if !have[class] {
+				gap("StorageClass", class, fmt.Sprintf("%d PVC(s) use StorageClass %s, which the target does not have", len(pvcsByClass[class]), class),
+					pvcsByClass[class])
+			}
+		}
+	}
+
+	// CSI drivers: the provisioners of the StorageClasses in use and the
+	// drivers of the source's VolumeSnapshotClasses.
+	if checked("CSIDrivers") {
+		isCSI := func(driver string) bool {
+			if len(src.CSIDrivers) == 0 {
+				return driver != "" && !strings.HasPrefix(driver, "kubernetes.io/")
+			}
+			for _, d := range src.CSIDrivers {
+				if d.Name == driver {
+					return true
+				}
+			}
+			return false
+		}
+		needed := map[string][]model.ResourceRef{}
+		for class, pvcs := range pvcsByClass {
+			if p := provisioner[class]; isCSI(p) {
+				needed[p] = append(needed[p], pvcs...)
+			}
+		}
+		for _, vsc := range src.VolumeSnapshotClasses {
+			if _, ok := needed[vsc.Driver]; !ok && vsc.Driver != "" {
+				needed[vsc.Driver] = nil
+			}
+		}
+		have := map[string]bool{}
+		for _, d := range dst.CSIDrivers {
+			have[d.Name] = true
+		}
+		for _, driver := range sortedKeys(needed) {
+			if !have[driver] {
+				affected := needed[driver]
+				sortRefs(affected)
+				gap("CSIDriver", driver, fmt.Sprintf("CSI driver %s is not installed on the target (%d PVC(s) depend on it)", driver, len(affected)), affected)
+			}
+		}
+	}
+
+	if checked("VolumeSnapshotClasses") {
+		have := map[string]bool{}
+		for _, vsc := range dst.VolumeSnapshotClasses {
+			have[vsc.Driver] = true
+		}
+		seen := map[string]bool{}
+		for _, vsc := range src.VolumeSnapshotClasses {
+			if vsc.Driver == "" || have[vsc.Driver] || seen[vsc.Driver] {
+				continue
+			}
+			seen[vsc.Driver] = true
+			var affected []model.ResourceRef
+			for class, pvcs := range pvcsByClass {
+				if provisioner[class] == vsc.Driver {
+					affected = append(affected, pvcs...)
+				}
+			}
+			sortRefs(affected)
+			gap("VolumeSnapshotClass", vsc.Name, fmt.Sprintf("No VolumeSnapshotClass for driver %s on the target; CSI snapshot restores of its volumes will fail", vsc.Driver), affected)
+		}
+	}
+
+	if checked("CRDs") {
+		// Versions the target serves, by API group and by CRD name.
+		served := map[string]map[string]bool{}
+		byName := map[string]map[string]bool{}
+		for _, c := range dst.CRDs {
+			if served[c.Group] == nil {
+				served[c.Group] = map[string]bool{}
+			}
+			if c.Name != "" && byName[c.Name] == nil {
+				byName[c.Name] = map[string]bool{}
+			}
+			for _, v := range c.Versions {
+				served[c.Group][v] = true
+				if c.Name != "" {
+					byName[c.Name][v] = true
+				}
+			}
+		}
+		// Source CRDs, or versions of them, the target lacks, by group.
+		missing := map[string][]string{}
+		for _, c := range src.CRDs {
+			versions, label := served[c.Group], c.Group
+			if c.Name != "" {
+				versions, label = byName[c.Name], c.Name
+			}
+			var absent []string
+			for _, v := range c.Versions {
+				if !versions[v] {
+					absent = append(absent, v)
+				}
+			}
+			switch {
+			case versions == nil:
+				missing[c.Group] = append(missing[c.Group], fmt.Sprintf("%s (%s)", label, strings.Join(c.Versions, ", ")))
+			case len(absent) > 0:
+				missing[c.Group] = append(missing[c.Group], fmt.Sprintf("%s %s", label, strings.Join(absent, ", ")))
+			}
+		}
+		owners := ownersByGroup(src)
+		for _, group := range sortedKeys(missing) {
+			list := strings.Join(missing[group], "; ")
+			if served[group] == nil {
+				gap("API", group, fmt.Sprintf("API group %s is not served by the target (%s); install its CRDs or operator first", group, list), owners[group])
+				continue
+			}
+			gap("API", group, fmt.Sprintf("API group %s: the target lacks %s (it serves %s)",
+				group, list, strings.Join(sortedKeys(served[group]), ", ")), owners[group])
+		}
+	}
+
+	if checked("IngressClasses") {
+		have := map[string]bool{}
+		targetDefault := false
+		for _, ic := range dst.IngressClasses {
+			have[ic.Name] = true
+			targetDefault = targetDefault || ic.Default
+		}
+		byClass := map[string][]model.ResourceRef{}
+		var unclassed []model.ResourceRef
+		for _, ing := range src.Ingresses {
+			ref := model.ResourceRef{Kind: "Ingress", Namespace: ing.Namespace, Name: ing.Name}
+			if ing.ClassName == "" {
+				unclassed = append(unclassed, ref)
+			} else if !have[ing.ClassName] {
+				byClass[ing.ClassName] = append(byClass[ing.ClassName], ref)
+			}
+		}
+		for _, class := range sortedKeys(byClass) {
+			gap("IngressClass", class, fmt.Sprintf("%d Ingress(es) use IngressClass %s, which the target does not have", len(byClass[class]), class), byClass[class])
+		}
+		if len(unclassed) > 0 && !targetDefault {
+			gap("IngressClass", "(default)", fmt.Sprintf("%d Ingress(es) set no ingressClassName and the target has no default IngressClass", len(unclassed)), unclassed)
+		}
+	}
+
+	if checked("Nodes") {
+		c := &r.Capacity
+		c.SourceNodes, c.SourceCPUMillis, c.SourceMemoryBytes = allocatable(src.Nodes)
+		c.TargetNodes, c.TargetCPUMillis, c.TargetMemoryBytes = allocatable(dst.Nodes)
+		if c.TargetCPUMillis < c.SourceCPUMillis {
+			gap("Capacity", "cpu", fmt.Sprintf("Target nodes have %s allocatable CPU, source %s", cores(c.TargetCPUMillis), cores(c.SourceCPUMillis)), nil)
+		}
+		if c.TargetMemoryBytes < c.SourceMemoryBytes {
+			gap("Capacity", "memory", fmt.Sprintf("Target nodes have %s allocatable memory, source %s", gib(c.TargetMemoryBytes), gib(c.SourceMemoryBytes)), nil)
+		}
+	}
+
+	sv, sok := minorVersion(r.SourceVersion)
+	tv, tok := minorVersion(r.TargetVersion)
+	if sok && tok {
+		r.VersionSkew = tv - sv
+		switch {
+		case r.VersionSkew < 0:
+			gap("Version", r.TargetVersion, fmt.Sprintf("Target runs %s, older than the source's %s; restored objects may use API versions or fields it does not know", r.TargetVersion, r.SourceVersion), nil)
+		case r.VersionSkew > 1:
+			gap("Version", r.TargetVersion, fmt.Sprintf("Target runs %s, %d minor versions ahead of the source's %s; check for API versions removed in between", r.TargetVersion, r.VersionSkew, r.SourceVersion), nil)
+		}
+	}
+	return r
+}
+
+// collected reports whether the named collector ran successfully on b. A
+// bundle with no recorded runs (hand-written JSON) is taken as complete.
+func collected(b *model.Bundle, name string) bool {
+	if len(b.CollectorRuns) == 0 {
+		return true
+	}
+	for _, run := range b.CollectorRuns {
+		if run.Name == name {
+			return run.Status == "ok"
+		}
+	}
+	return false
+}
+
+// ownersByGroup returns the custom resources owning source workloads, by
+// API group.
+func ownersByGroup(inv model.Inventory) map[string][]model.ResourceRef {
+	out := map[string][]model.ResourceRef{}
+	seen := map[model.ResourceRef]bool{}
+	add := func(ns string, refs model.WorkloadRefs) {
+		for _, o := range refs.Owners {
+			group, _, ok := strings.Cut(o.APIVersion, "/")
+			if !ok {
+				continue
+			}
+			ref := model.ResourceRef{Kind: o.Kind, Namespace: ns, Name: o.Name}
+			if !seen[ref] {
+				seen[ref] = true
+				out[group] = append(out[group], ref)
+			}
+		}
+	}
+	for _, d := range inv.Deployments {
+		add(d.Namespace, d.WorkloadRefs)
+	}
+	for _, s := range inv.StatefulSets {
+		add(s.Namespace, s.WorkloadRefs)
+	}
+	for _, d := range inv.DaemonSets {
+		add(d.Namespace, d.WorkloadRefs)
+	}
+	for _, refs := range out {
+		sortRefs(refs)
+	}
+	return out
+}
+
+// allocatable totals the allocatable CPU and memory of the Ready nodes.
+func allocatable(nodes []model.Node) (count int, cpuMillis, memBytes int64) {
+	for _, n := range nodes {
+		if !n.Ready {
+			continue
+		}
+		count++
+		cpuMillis += n.AllocatableCPUMillis
+		memBytes += n.AllocatableMemoryBytes
+	}
+	return count, cpuMillis, memBytes
+}
+
+// minorVersion parses the minor version from "v1.29.3+k3s1".
+func minorVersion(v string) (int, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) < 2 || parts[0] != "1" {
+		return 0, false
+	}
+	minor, err := strconv.Atoi(strings.TrimRight(parts[1], "+"))
+	return minor, err == nil
+}
+
+func cores(millis int64) string { return fmt.Sprintf("%.1f cores", float64(millis)/1000) }
+func gib(bytes int64) string    { return fmt.Sprintf("%.1f GiB", float64(bytes)/(1<<30)) }
+
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortRefs(refs []model.ResourceRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		return refs[i].Name < refs[j].Name
+	})
+}
@@ -0,0 +1,125 @@
+package portability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestCheck(t *testing.T) {
+	src := model.NewBundle("src", time.Now())
+	src.Cluster.Platform.K8sVersion = "v1.30.2"
+	src.Inventory.Nodes = []model.Node{
+		{Name: "a", Ready: true, AllocatableCPUMillis: 8000, AllocatableMemoryBytes: 32 << 30},
+		{Name: "b", Ready: true, AllocatableCPUMillis: 8000, AllocatableMemoryBytes: 32 << 30},
+	}
+	src.Inventory.StorageClasses = []model.StorageClass{
+		{Name: "fast", Provisioner: "ebs.csi.aws.com"},
+		{Name: "shared", Provisioner: "nfs.csi.k8s.io"},
+	}
+	src.Inventory.CSIDrivers = []model.CSIDriver{{Name: "ebs.csi.aws.com"}, {Name: "nfs.csi.k8s.io"}}
+	src.Inventory.VolumeSnapshotClasses = []model.VolumeSnapshotClass{{Name: "ebs-snap", Driver: "ebs.csi.aws.com"}}
+	src.Inventory.PVCs = []model.PersistentVolumeClaim{
+		{Namespace: "db", Name: "data", StorageClass: "fast"},
+		{Namespace: "files", Name: "share", StorageClass: "shared"},
+	}
+	src.Inventory.CRDs = []model.CRD{
+		{Group: "example.com", Versions: []string{"v1", "v1beta1"}},
+		{Group: "cache.example.com", Versions: []string{"v1"}},
+	}
+	src.Inventory.StatefulSets = []model.StatefulSet{{Namespace: "db", Name: "pg", WorkloadRefs: model.WorkloadRefs{
+		Owners: []model.OwnerRef{{APIVersion: "cache.example.com/v1", Kind: "Cache", Name: "pg"}},
+	}}}
+	src.Inventory.Ingresses = []model.Ingress{
+		{Namespace: "web", Name: "shop", ClassName: "nginx"},
+		{Namespace: "web", Name: "legacy"},
+	}
+
+	dst := model.NewBundle("dst", time.Now())
+	dst.Cluster.Platform.K8sVersion = "v1.29.5+k3s1"
+	dst.Inventory.Nodes = []model.Node{
+		{Name: "x", Ready: true, AllocatableCPUMillis: 16000, AllocatableMemoryBytes: 32 << 30},
+		{Name: "y", Ready: false, AllocatableCPUMillis: 16000, AllocatableMemoryBytes: 32 << 30},
+	}
+	dst.Inventory.StorageClasses = []model.StorageClass{{Name: "fast", Provisioner: "ebs.csi.aws.com"}}
+	dst.Inventory.CSIDrivers = []model.CSIDriver{{Name: "ebs.csi.aws.com"}}
+	dst.Inventory.CRDs = []model.CRD{{Group: "example.com", Versions: []string{"v1"}}}
+	dst.Inventory.IngressClasses = []model.IngressClass{{Name: "nginx"}}
+	// The VolumeSnapshotClasses collector did not run on the target.
+	for _, c := range Collectors {
+		if c != "VolumeSnapshotClasses" {
+			dst.CollectorRuns = append(dst.CollectorRuns, model.CollectorRun{Name: c, Status: "ok"})
+		}
+	}
+
+	r := Check(&src, &dst, "dr")
+	got := map[string]model.PortabilityGap{}
+	for _, g := range r.Gaps {
+		got[g.Kind+"/"+g.Name] = g
+	}
+	want := []string{
+		"StorageClass/shared",
+		"CSIDriver/nfs.csi.k8s.io",
+		"API/example.com",
+		"API/cache.example.com",
+		"IngressClass/(default)",
+		"Capacity/memory",
+		"Version/v1.29.5+k3s1",
+	}
+	for _, k := range want {
+		if _, ok := got[k]; !ok {
+			t.Errorf("missing gap %s", k)
+		}
+	}
+	if len(r.Gaps) != len(want) {
+		t.Errorf("got %d gaps %v; want %d", len(r.Gaps), got, len(want))
+	}
+	if a := got["API/cache.example.com"].Affected; len(a) != 1 || a[0].Kind != "Cache" {
+		t.Errorf("cache.example.com affects %v; want the Cache owning db/pg", a)
+	}
+	if a := got["StorageClass/shared"].Affected; len(a) != 1 || a[0].Name != "share" {
+		t.Errorf("shared affects %v; want files/share", a)
+	}
+	if r.VersionSkew != -1 || r.Capacity.TargetNodes != 1 || r.Capacity.TargetCPUMillis != 16000 {
+		t.Errorf("skew %d, capacity %+v; want -1 and one Ready 16-core target node", r.VersionSkew, r.Capacity)
+	}
+	if len(r.Unchecked) != 1 || r.Unchecked[0] != "VolumeSnapshotClasses" {
+		t.Errorf("unchecked = %v; want VolumeSnapshotClasses", r.Unchecked)
+	}
+}
+
+func TestCheckCRDsByGroup(t *testing.T) {
+	src := model.NewBundle("src", time.Now())
+	src.Inventory.CRDs = []model.CRD{
+		{Name: "certificates.cert-manager.io", Group: "cert-manager.io", Versions: []string{"v1"}},
+		{Name: "issuers.cert-manager.io", Group: "cert-manager.io", Versions: []string{"v1"}},
+		{Name: "widgets.example.org", Group: "example.org", Versions: []string{"v1"}},
+		{Name: "gadgets.example.org", Group: "example.org", Versions: []string{"v1beta1"}},
+		{Name: "gizmos.example.org", Group: "example.org", Versions: []string{"v1"}},
+	}
+	dst := model.NewBundle("dst", time.Now())
+	// Two CRDs of one group serving different versions: both must count.
+	dst.Inventory.CRDs = []model.CRD{
+		{Name: "widgets.example.org", Group: "example.org", Versions: []string{"v1"}},
+		{Name: "gadgets.example.org", Group: "example.org", Versions: []string{"v1beta1"}},
+	}
+
+	r := Check(&src, &dst, "dr")
+	got := map[string]string{}
+	for _, g := range r.Gaps {
+		if g.Kind == "API" {
+			got[g.Name] = g.Detail
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("API gaps = %v; want one for cert-manager.io and one for example.org", got)
+	}
+	if d := got["cert-manager.io"]; !strings.Contains(d, "certificates.cert-manager.io") || !strings.Contains(d, "issuers.cert-manager.io") {
+		t.Errorf("cert-manager.io gap %q; want both missing CRDs listed", d)
+	}
+	if d := got["example.org"]; !strings.Contains(d, "gizmos.example.org") || strings.Contains(d, "gadgets") || strings.Contains(d, "widgets") {
+		t.Errorf("example.org gap %q; want only gizmos.example.org", d)
+	}
+}
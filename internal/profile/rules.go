@@ -0,0 +1,193 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// RuleFile is a declarative rules profile (see profiles/default.json). It
+// tunes the built-in findings raised by analyze.Evaluate without code changes:
+// each rule can be switched off, given a different severity or penalty, or fed
+// parameters such as thresholds.
+type RuleFile struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Defaults    RuleDefaults `json:"defaults"`
+	Rules       []Rule       `json:"rules"`
+	Custom      []CustomRule `json:"custom,omitempty"`
+	Overrides   RuleOverride `json:"overrides"`
+
+	byID map[string]Rule
+}
+
+// RuleDefaults applies to every finding ID not listed in Rules.
+type RuleDefaults struct {
+	// Enabled defaults to true. Set it to false to run only the listed rules.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Weight, FailPenalty and WarnPenalty come from profiles written before
+	// --rules-file. They are accepted so those profiles still load, but have
+	// no effect.
+	Weight      *int `json:"weight,omitempty"`
+	FailPenalty *int `json:"failPenalty,omitempty"`
+	WarnPenalty *int `json:"warnPenalty,omitempty"`
+}
+
+// Rule configures a single finding ID.
+type Rule struct {
+	ID      string `json:"id"`
+	Enabled *bool  `json:"enabled,omitempty"`
+	// Domain is optional (STORAGE, WORKLOAD, CONFIG, BACKUP). When set it
+	// must match the finding's domain, which catches an ID pasted under the
+	// wrong heading; it never moves a finding to another domain.
+	Domain string `json:"domain,omitempty"`
+	// Severity replaces the built-in severity when set.
+	Severity string `json:"severity,omitempty"`
+	// Penalty replaces the points deducted from the domain score when set.
+	// It is absolute: profile multipliers are not applied on top.
+	Penalty *int                   `json:"penalty,omitempty"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	// Weight is the name older profiles used for Penalty.
+	//
+	// Deprecated: use Penalty.
+	Weight *int `json:"weight,omitempty"`
+}
+
+// CustomRule is a site-specific check written as a CEL expression over the
+// scan bundle. See analyze.CompileCustom for how Expr is evaluated.
+type CustomRule struct {
+	ID             string `json:"id"`
+	Domain         string `json:"domain"`
+	Severity       string `json:"severity"`
+	Penalty        int    `json:"penalty"`
+	Message        string `json:"message"`
+	Recommendation string `json:"recommendation,omitempty"`
+	Expr           string `json:"expr"`
+}
+
+var validDomains = map[string]bool{
+	"STORAGE": true, "WORKLOAD": true, "CONFIG": true, "BACKUP": true,
+}
+
+// RuleOverride holds scan-wide settings.
+type RuleOverride struct {
+	// ExcludeNamespaces are left out of collection entirely.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+}
+
+var validSeverities = map[string]bool{
+	"CRITICAL": true, "HIGH": true, "MEDIUM": true, "LOW": true, "INFO": true,
+}
+
+// LoadRules reads and validates a rules profile from path. Unknown fields
+// are rejected so a setting this version does not understand fails loudly
+// instead of being ignored. The "weight" of older profiles is still read as
+// "penalty", with a warning.
+func LoadRules(path string) (*RuleFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("rules file: %w", err)
+	}
+	var rf RuleFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rf); err != nil {
+		return nil, fmt.Errorf("rules file %s: %w", path, err)
+	}
+	if d := rf.Defaults; d.Weight != nil || d.FailPenalty != nil || d.WarnPenalty != nil {
+		log.Printf("rules file %s: defaults.weight, failPenalty and warnPenalty are no longer used (ignored); set penalty per rule instead", path)
+	}
+	rf.byID = map[string]Rule{}
+	for i, r := range rf.Rules {
+		id := strings.ToUpper(strings.TrimSpace(r.ID))
+		if id == "" {
+			return nil, fmt.Errorf("rules file %s: rule %d has no id", path, i)
+		}
+		if _, dup := rf.byID[id]; dup {
+			return nil, fmt.Errorf("rules file %s: duplicate rule %s", path, id)
+		}
+		if r.Severity != "" {
+			r.Severity = strings.ToUpper(r.Severity)
+			if !validSeverities[r.Severity] {
+				return nil, fmt.Errorf("rules file %s: rule %s: unknown severity %q", path, id, r.Severity)
+			}
+		}
+		if r.Weight != nil {
+			if r.Penalty != nil {
+				return nil, fmt.Errorf("rules file %s: rule %s: weight is the old name for penalty; set only penalty", path, id)
+			}
+			log.Printf("rules file %s: rule %s: weight is deprecated, rename it to penalty", path, id)
+			r.Penalty, r.Weight = r.Weight, nil
+		}
+		if r.Penalty != nil && *r.Penalty < 0 {
+			return nil, fmt.Errorf("rules file %s: rule %s: penalty must be >= 0", path, id)
+		}
+		r.ID = id
+		r.Domain = strings.ToUpper(r.Domain)
+		rf.Rules[i] = r
+		rf.byID[id] = r
+	}
+	seen := map[string]bool{}
+	for i, c := range rf.Custom {
+		c.ID = strings.ToUpper(strings.TrimSpace(c.ID))
+		c.Domain = strings.ToUpper(c.Domain)
+		c.Severity = strings.ToUpper(c.Severity)
+		switch {
+		case c.ID == "":
+			return nil, fmt.Errorf("rules file %s: custom rule %d has no id", path, i)
+		case seen[c.ID]:
+			return nil, fmt.Errorf("rules file %s: duplicate custom rule %s", path, c.ID)
+		case !validDomains[c.Domain]:
+			return nil, fmt.Errorf("rules file %s: custom rule %s: domain must be STORAGE, WORKLOAD, CONFIG or BACKUP", path, c.ID)
+		case !validSeverities[c.Severity]:
+			return nil, fmt.Errorf("rules file %s: custom rule %s: unknown severity %q", path, c.ID, c.Severity)
+		case c.Penalty < 0:
+			return nil, fmt.Errorf("rules file %s: custom rule %s: penalty must be >= 0", path, c.ID)
+		case strings.TrimSpace(c.Expr) == "":
+			return nil, fmt.Errorf("rules file %s: custom rule %s has no expr", path, c.ID)
+		}
+		seen[c.ID] = true
+		rf.Custom[i] = c
+	}
+	return &rf, nil
+}
+
+// Rule returns the configuration for id, if the file lists it.
+func (rf *RuleFile) Rule(id string) (Rule, bool) {
+	if rf == nil {
+		return Rule{}, false
+	}
+	r, ok := rf.byID[id]
+	return r, ok
+}
+
+// Enabled reports whether findings with this ID should be raised.
+// A nil RuleFile enables everything.
+func (rf *RuleFile) Enabled(id string) bool {
+	if rf == nil {
+		return true
+	}
+	if r, ok := rf.byID[id]; ok && r.Enabled != nil {
+		return *r.Enabled
+	}
+	return rf.Defaults.Enabled == nil || *rf.Defaults.Enabled
+}
+
+// IntParam returns params[key] for rule id as an int, or def when unset.
+func (rf *RuleFile) IntParam(id, key string, def int) int {
+	r, ok := rf.Rule(id)
+	if !ok {
+		return def
+	}
+	switch v := r.Params[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return def
+}
@@ -61,12 +61,151 @@ func stepForFinding(f model.Finding, tool, target, platform string) *model.Remed
 		return &s
 
 	case "BACKUP_PARTIAL_COVERAGE":
+		nss := strings.Join(refNames(f), ",")
 		return &model.RemediationStep{
 			Priority:  1,
 			Category:  "Backup",
 			Title:     "Extend backup coverage to all stateful namespaces",
-			Detail:    fmt.Sprintf("StatefulSets in uncovered namespaces: %s", f.ResourceID),
-			Commands:  backupPolicyCmds(tool, f.ResourceID),
+			Detail:    fmt.Sprintf("StatefulSets in uncovered namespaces: %s", nss),
+			Commands:  backupPolicyCmds(tool, nss),
+			FindingID: f.ID,
+		}
+
+	case "POD_NO_REQUESTS", "POD_NO_LIMITS":
+		what := "requests"
+		if f.ID == "POD_NO_LIMITS" {
+			what = "limits"
+		}
+		cmds := []string{fmt.Sprintf("# Set %s on the owning workloads; a namespace LimitRange supplies defaults for new pods.", what)}
+		for _, g := range byNamespace(f) {
+			cmds = append(cmds, fmt.Sprintf("kubectl get pods -n %s %s -o custom-columns=POD:.metadata.name,OWNER_KIND:.metadata.ownerReferences[0].kind,OWNER:.metadata.ownerReferences[0].name",
+				g.ns, strings.Join(g.names, " ")))
+		}
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Workload",
+			Title:     fmt.Sprintf("Set CPU/memory %s on %d pod(s)", what, len(f.Resources)),
+			Detail:    "Pods without " + what + " make recovery-site capacity planning and scheduling unpredictable.",
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "POD_PRIVILEGED":
+		cmds := []string{"# Review each privileged pod and replace privileged:true with specific capabilities:"}
+		for _, g := range byNamespace(f) {
+			cmds = append(cmds, fmt.Sprintf("kubectl get pods -n %s %s -o custom-columns=POD:.metadata.name,PRIVILEGED:.spec.containers[*].securityContext.privileged",
+				g.ns, strings.Join(g.names, " ")))
+		}
+		return &model.RemediationStep{
+			Priority:  1,
+			Category:  "Config",
+			Title:     fmt.Sprintf("Remove privileged mode from %d pod(s)", len(f.Resources)),
+			Detail:    "Privileged containers get full host access and are often blocked by Pod Security Admission on a rebuilt DR cluster.",
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "LR_MISSING_NAMESPACE":
+		cmds := []string{"# Apply a LimitRange with default requests/limits to each namespace (limitrange.yaml):"}
+		for _, ns := range refNames(f) {
+			cmds = append(cmds, "kubectl apply -n "+ns+" -f limitrange.yaml")
+		}
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Config",
+			Title:     fmt.Sprintf("Add a LimitRange to %d namespace(s)", len(refNames(f))),
+			Detail:    "Without a LimitRange, pods in these namespaces can run with unbounded resources.",
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "NETPOL_MISSING_NAMESPACE":
+		cmds := []string{"# Apply a default-deny NetworkPolicy (default-deny.yaml), then allow required traffic:"}
+		for _, ns := range refNames(f) {
+			cmds = append(cmds, "kubectl apply -n "+ns+" -f default-deny.yaml")
+		}
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Network",
+			Title:     fmt.Sprintf("Add NetworkPolicies to %d namespace(s)", len(refNames(f))),
+			Detail:    "Namespaces without NetworkPolicies allow unrestricted east-west traffic after recovery.",
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "BACKUP_STALE":
+		cmds := []string{"# Find the most recent backups and their phase:"}
+		if tool == "velero" {
+			cmds = append(cmds, "velero backup get --sort-by=.status.startTimestamp",
+				"velero schedule get")
+		}
+		return &model.RemediationStep{
+			Priority:  1,
+			Category:  "Backup",
+			Title:     "Get backups succeeding again for stale namespaces",
+			Detail:    fmt.Sprintf("No recent successful backup for: %s", strings.Join(refNames(f), ", ")),
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "BACKUP_FAILED":
+		var cmds []string
+		for _, r := range f.Resources {
+			switch r.Kind {
+			case "Backup":
+				cmds = append(cmds, fmt.Sprintf("velero backup describe %s -n %s --details", r.Name, r.Namespace),
+					fmt.Sprintf("velero backup logs %s -n %s | grep -i error", r.Name, r.Namespace))
+			case "BackupAction":
+				cmds = append(cmds, fmt.Sprintf("kubectl -n %s describe backupactions.actions.kio.kasten.io %s", r.Namespace, r.Name))
+			case "ApplicationBackup":
+				cmds = append(cmds, fmt.Sprintf("kubectl -n %s describe applicationbackups.stork.libopenstorage.org %s", r.Namespace, r.Name))
+			}
+		}
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Backup",
+			Title:     fmt.Sprintf("Investigate %d failed backup(s)", len(f.Resources)),
+			Detail:    f.Message,
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "RESTORE_NOT_TESTED":
+		cmds := []string{"# Restore the latest backup into a scratch namespace and verify the application:"}
+		if tool == "velero" {
+			cmds = append(cmds, "velero restore create restore-test --from-schedule <schedule> --namespace-mappings <ns>:<ns>-restore-test")
+		}
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Backup",
+			Title:     "Run and schedule a test restore",
+			Detail:    "No restore has ever completed, so backups are unverified.",
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
+	case "DR_RPO_TARGET_MISSED":
+		return &model.RemediationStep{
+			Priority:  1,
+			Category:  "Backup",
+			Title:     fmt.Sprintf("Meet the RPO target of %d namespace(s)", len(refNames(f))),
+			Detail:    f.Message,
+			Commands:  backupPolicyCmds(tool, strings.Join(refNames(f), ",")),
+			FindingID: f.ID,
+		}
+
+	case "DR_RTO_TARGET_MISSED":
+		return &model.RemediationStep{
+			Priority: 2,
+			Category: "Backup",
+			Title:    fmt.Sprintf("Shorten restore time for %d namespace(s)", len(refNames(f))),
+			Detail:   f.Message,
+			Commands: []string{
+				"# Time a test restore of each namespace to replace the estimate with a measurement",
+				"# Record the measured throughput in --rto-model, and raise its parallel setting if restores can overlap",
+				"# Split large volumes, use faster storage for restores, or pre-pull images on standby nodes",
+				"# If the target is unrealistic, agree a new one and update the dr.example/rto annotation",
+			},
 			FindingID: f.ID,
 		}
 
@@ -144,6 +283,83 @@ func stepForFinding(f model.Finding, tool, target, platform string) *model.Remed
 			FindingID: f.ID,
 		}
 
+	case "PORT_STORAGECLASS_MISSING", "PORT_SNAPSHOTCLASS_MISSING", "PORT_INGRESSCLASS_MISSING":
+		kind := map[string]string{
+			"PORT_STORAGECLASS_MISSING":  "storageclass",
+			"PORT_SNAPSHOTCLASS_MISSING": "volumesnapshotclass",
+			"PORT_INGRESSCLASS_MISSING":  "ingressclass",
+		}[f.ID]
+		var names []string
+		for _, n := range refNames(f) {
+			if !strings.HasPrefix(n, "(") { // "(default)": no class to copy
+				names = append(names, n)
+			}
+		}
+		return &model.RemediationStep{
+			Priority: 1,
+			Category: "Portability",
+			Title:    fmt.Sprintf("Create the missing %s(es) on the DR cluster", kind),
+			Detail:   f.Message,
+			Commands: []string{
+				fmt.Sprintf("kubectl get %s %s -o yaml > dr-%s.yaml", kind, strings.Join(names, " "), kind),
+				"# Adjust provisioner/driver/controller parameters for the DR cluster, then:",
+				fmt.Sprintf("kubectl --kubeconfig <dr-kubeconfig> apply -f dr-%s.yaml", kind),
+			},
+			FindingID: f.ID,
+		}
+
+	case "PORT_CSI_DRIVER_MISSING", "PORT_API_NOT_SERVED":
+		return &model.RemediationStep{
+			Priority: 1,
+			Category: "Portability",
+			Title:    fmt.Sprintf("Install %s on the DR cluster", strings.Join(refNames(f), ", ")),
+			Detail:   f.Message,
+			Commands: []string{
+				"# Install the same CSI drivers, CRDs and operators (same chart versions) on the DR cluster",
+				"kubectl --kubeconfig <dr-kubeconfig> get csidrivers,crds",
+			},
+			FindingID: f.ID,
+		}
+
+	case "PORT_CAPACITY_SHORT", "PORT_VERSION_SKEW":
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Portability",
+			Title:     "Size and version the DR cluster to match the source",
+			Detail:    f.Message,
+			Commands:  []string{"kubectl --kubeconfig <dr-kubeconfig> get nodes -o custom-columns=NAME:.metadata.name,VERSION:.status.nodeInfo.kubeletVersion,CPU:.status.allocatable.cpu,MEM:.status.allocatable.memory"},
+			FindingID: f.ID,
+		}
+
+	case "CAPACITY_DR_SHORT":
+		return &model.RemediationStep{
+			Priority: 1,
+			Category: "Capacity",
+			Title:    "Grow the DR site to fit the protected workloads",
+			Detail:   f.Message,
+			Commands: []string{
+				"# Requests per protected namespace on the source:",
+				"kubectl get pods -A --field-selector=status.phase=Running -o custom-columns=NS:.metadata.namespace,NAME:.metadata.name,CPU:.spec.containers[*].resources.requests.cpu,MEM:.spec.containers[*].resources.requests.memory",
+				"# Allocatable on the DR site:",
+				"kubectl --kubeconfig <dr-kubeconfig> get nodes -o custom-columns=NAME:.metadata.name,CPU:.status.allocatable.cpu,MEM:.status.allocatable.memory,PODS:.status.allocatable.pods",
+			},
+			FindingID: f.ID,
+		}
+
+	case "CAPACITY_POD_TOO_LARGE":
+		var cmds []string
+		for _, r := range f.Resources {
+			cmds = append(cmds, fmt.Sprintf("kubectl -n %s get pod %s -o jsonpath='{.spec.containers[*].resources.requests}'", r.Namespace, r.Name))
+		}
+		return &model.RemediationStep{
+			Priority:  2,
+			Category:  "Capacity",
+			Title:     "Add a DR node pool large enough for the biggest pods",
+			Detail:    f.Message,
+			Commands:  cmds,
+			FindingID: f.ID,
+		}
+
 	case "CRD_NO_BACKUP":
 		return &model.RemediationStep{
 			Priority:  2,
@@ -270,6 +486,58 @@ func targetImageNote(target string) string {
 	return "VM target: use a registry in the same cloud region or VPN-accessible private registry to reduce pull latency."
 }
 
+// refNames returns the name of every resource a finding lists, falling back
+// to the comma-separated ResourceID for findings without Resources.
+func refNames(f model.Finding) []string {
+	if len(f.Resources) > 0 {
+		out := make([]string, len(f.Resources))
+		for i, r := range f.Resources {
+			out[i] = r.Name
+		}
+		return out
+	}
+	id := f.ResourceID
+	if i := strings.Index(id, ":"); i >= 0 {
+		id = id[i+1:]
+	}
+	return strings.Split(strings.TrimSuffix(id, "..."), ",")
+}
+
+type nsGroup struct {
+	ns    string
+	names []string
+}
+
+// maxNamesPerCmd caps how many object names go on one kubectl command line.
+const maxNamesPerCmd = 50
+
+// byNamespace groups a finding's namespaced resources by namespace, sorted,
+// so one command can address the objects in a namespace. Namespaces with more
+// than maxNamesPerCmd objects are split into several groups.
+func byNamespace(f model.Finding) []nsGroup {
+	idx := map[string]int{}
+	var out []nsGroup
+	for _, r := range f.Resources {
+		i, ok := idx[r.Namespace]
+		if !ok {
+			i = len(out)
+			idx[r.Namespace] = i
+			out = append(out, nsGroup{ns: r.Namespace})
+		}
+		out[i].names = append(out[i].names, r.Name)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ns < out[j].ns })
+	var chunked []nsGroup
+	for _, g := range out {
+		for len(g.names) > maxNamesPerCmd {
+			chunked = append(chunked, nsGroup{ns: g.ns, names: g.names[:maxNamesPerCmd]})
+			g.names = g.names[maxNamesPerCmd:]
+		}
+		chunked = append(chunked, g)
+	}
+	return chunked
+}
+
 func nsFromRef(ref string) string {
 	parts := strings.SplitN(ref, "/", 2)
 	if len(parts) == 2 {
@@ -0,0 +1,33 @@
+package remediation
+
+import (
+	"strings"
+	"testing"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestBackupFailedCommandsPerTool(t *testing.T) {
+	f := model.Finding{ID: "BACKUP_FAILED", Resources: []model.ResourceRef{
+		{Kind: "Backup", Namespace: "velero", Name: "daily-1"},
+		{Kind: "BackupAction", Namespace: "shop", Name: "backup-abc12"},
+		{Kind: "ApplicationBackup", Namespace: "db", Name: "pg-nightly"},
+	}}
+	s := stepForFinding(f, "kasten", "vm", "")
+	if s == nil {
+		t.Fatal("no step for BACKUP_FAILED")
+	}
+	cmds := strings.Join(s.Commands, "\n")
+	if !strings.Contains(cmds, "velero backup describe daily-1 -n velero") {
+		t.Errorf("commands %q; want velero describe for the Backup", cmds)
+	}
+	if !strings.Contains(cmds, "kubectl -n shop describe backupactions.actions.kio.kasten.io backup-abc12") {
+		t.Errorf("commands %q; want kubectl describe for the Kasten BackupAction", cmds)
+	}
+	if !strings.Contains(cmds, "kubectl -n db describe applicationbackups.stork.libopenstorage.org pg-nightly") {
+		t.Errorf("commands %q; want kubectl describe for the Stork ApplicationBackup", cmds)
+	}
+	if n := strings.Count(cmds, "velero backup"); n != 2 {
+		t.Errorf("commands %q; want velero commands only for the velero Backup", cmds)
+	}
+}
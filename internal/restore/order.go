@@ -0,0 +1,362 @@
+package restore
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+
+	"k8s-recovery-visualizer/internal/kutil"
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// Restore tiers, in the order objects come back when nothing else forces
+// an order: CRDs, operators, namespaces, config, volumes, workloads, then
+// the Services and Ingresses in front of them.
+const (
+	tierCRD = iota
+	tierOperator
+	tierNamespace
+	tierConfig
+	tierVolume
+	tierStatefulSet
+	tierWorkload
+	tierService
+	tierIngress
+)
+
+// planNode is one object in the restore graph.
+type planNode struct {
+	step model.RestoreStep
+	tier int
+	deps map[string]struct{}
+	next []string // IDs that depend on this node
+	wait int      // dependencies not yet emitted
+}
+
+// planGraph is the restore dependency graph keyed by step ID.
+type planGraph map[string]*planNode
+
+func (g planGraph) add(kind, ns, name, release string, tier int) string {
+	id := stepID(kind, ns, name)
+	if _, ok := g[id]; !ok {
+		g[id] = &planNode{
+			step: model.RestoreStep{ID: id, Kind: kind, Namespace: ns, Name: name, HelmRelease: release},
+			tier: tier,
+			deps: map[string]struct{}{},
+		}
+	}
+	return id
+}
+
+// edge records that to must be restored after from. Unknown IDs are ignored.
+func (g planGraph) edge(from, to string) {
+	if from == to {
+		return
+	}
+	if _, ok := g[from]; !ok {
+		return
+	}
+	if n, ok := g[to]; ok {
+		n.deps[from] = struct{}{}
+	}
+}
+
+// Plan builds the restore dependency graph for everything outside the
+// system namespaces and returns it topologically sorted. Dependencies come
+// from:
+//
+//   - CRDs before operators, and operators before every other namespace;
+//   - a namespace before the objects in it;
+//   - ConfigMaps, Secrets and PVCs before the workloads that use them,
+//     including the volumeClaimTemplate PVCs of a StatefulSet;
+//   - the CRD of a custom resource that owns a workload, and an owning
+//     workload before the workloads it owns;
+//   - a Helm release's ConfigMaps, Secrets and PVCs before its workloads;
+//   - workloads before the Services whose selector matches them, and
+//     Services and TLS Secrets before the Ingresses that use them.
+//
+// Among objects whose dependencies are met, lower tiers go first.
+func Plan(b *model.Bundle) model.RestorePlan {
+	inv := b.Inventory
+	g := planGraph{}
+
+	var crds []string
+	crdByGroup := map[string][]string{}
+	for _, c := range inv.CRDs {
+		id := g.add("CustomResourceDefinition", "", c.Name, "", tierCRD)
+		crds = append(crds, id)
+		crdByGroup[c.Group] = append(crdByGroup[c.Group], id)
+	}
+	for _, ns := range inv.Namespaces {
+		if !kutil.SystemNamespace(ns.Name) {
+			g.add("Namespace", "", ns.Name, "", tierNamespace)
+		}
+	}
+	inNS := func(ns, id string) {
+		g.edge(g.add("Namespace", "", ns, "", tierNamespace), id)
+	}
+
+	// Config and volumes, indexed by Helm release for the release edges.
+	type releaseKey struct{ ns, release string }
+	releaseDeps := map[releaseKey][]string{}
+	addDep := func(kind, ns, name, release string, tier int) {
+		if kutil.SystemNamespace(ns) {
+			return
+		}
+		id := g.add(kind, ns, name, release, tier)
+		inNS(ns, id)
+		if release != "" {
+			k := releaseKey{ns, release}
+			releaseDeps[k] = append(releaseDeps[k], id)
+		}
+	}
+	for _, s := range inv.Secrets {
+		addDep("Secret", s.Namespace, s.Name, s.HelmRelease, tierConfig)
+	}
+	for _, cm := range inv.ConfigMaps {
+		addDep("ConfigMap", cm.Namespace, cm.Name, cm.HelmRelease, tierConfig)
+	}
+	for _, pvc := range inv.PVCs {
+		addDep("PersistentVolumeClaim", pvc.Namespace, pvc.Name, pvc.HelmRelease, tierVolume)
+	}
+
+	// Workloads.
+	type workload struct {
+		id   string
+		ns   string
+		refs model.WorkloadRefs
+	}
+	var workloads []workload
+	addWorkload := func(kind, ns, name string, refs model.WorkloadRefs, tier int) string {
+		if kutil.SystemNamespace(ns) {
+			return ""
+		}
+		op := isOperator(name, refs.PodLabels)
+		if op {
+			tier = tierOperator
+		}
+		id := g.add(kind, ns, name, refs.HelmRelease, tier)
+		g[id].step.Operator = op
+		inNS(ns, id)
+		for _, cm := range refs.ConfigMaps {
+			g.edge(stepID("ConfigMap", ns, cm), id)
+		}
+		for _, s := range refs.Secrets {
+			g.edge(stepID("Secret", ns, s), id)
+		}
+		for _, p := range refs.PVCs {
+			g.edge(stepID("PersistentVolumeClaim", ns, p), id)
+		}
+		if refs.HelmRelease != "" {
+			for _, dep := range releaseDeps[releaseKey{ns, refs.HelmRelease}] {
+				g.edge(dep, id)
+			}
+		}
+		workloads = append(workloads, workload{id: id, ns: ns, refs: refs})
+		return id
+	}
+	for _, sts := range inv.StatefulSets {
+		id := addWorkload("StatefulSet", sts.Namespace, sts.Name, sts.WorkloadRefs, tierStatefulSet)
+		if id == "" {
+			continue
+		}
+		for _, pvc := range inv.PVCs {
+			if pvc.Namespace == sts.Namespace && sts.OwnsClaim(pvc.Name) {
+				g.edge(stepID("PersistentVolumeClaim", pvc.Namespace, pvc.Name), id)
+			}
+		}
+	}
+	for _, d := range inv.Deployments {
+		addWorkload("Deployment", d.Namespace, d.Name, d.WorkloadRefs, tierWorkload)
+	}
+	for _, ds := range inv.DaemonSets {
+		addWorkload("DaemonSet", ds.Namespace, ds.Name, ds.WorkloadRefs, tierWorkload)
+	}
+
+	// CRDs before operators, operators before the namespaces they do not
+	// run in.
+	operatorNS := map[string]bool{}
+	var operators []string
+	for _, w := range workloads {
+		if g[w.id].step.Operator {
+			operators = append(operators, w.id)
+			operatorNS[w.ns] = true
+			for _, c := range crds {
+				g.edge(c, w.id)
+			}
+		}
+	}
+	for id, n := range g {
+		if n.step.Kind == "Namespace" && !operatorNS[n.step.Name] {
+			for _, op := range operators {
+				g.edge(op, id)
+			}
+		}
+	}
+
+	// Owners: a custom resource needs its CRD; an owning workload comes
+	// first.
+	for _, w := range workloads {
+		for _, o := range w.refs.Owners {
+			group := o.APIVersion
+			if i := strings.LastIndex(group, "/"); i >= 0 {
+				group = group[:i]
+			} else {
+				group = ""
+			}
+			for _, c := range crdByGroup[group] {
+				g.edge(c, w.id)
+			}
+			for _, kind := range []string{"StatefulSet", "Deployment", "DaemonSet"} {
+				if o.Kind == kind {
+					g.edge(stepID(kind, w.ns, o.Name), w.id)
+				}
+			}
+		}
+	}
+
+	// Services after the workloads they select, Ingresses after their
+	// Services and TLS Secrets.
+	for _, svc := range inv.Services {
+		if kutil.SystemNamespace(svc.Namespace) {
+			continue
+		}
+		id := g.add("Service", svc.Namespace, svc.Name, svc.HelmRelease, tierService)
+		inNS(svc.Namespace, id)
+		if len(svc.Selector) == 0 {
+			continue
+		}
+		for _, w := range workloads {
+			if w.ns == svc.Namespace && kutil.Selects(svc.Selector, w.refs.PodLabels) {
+				g.edge(w.id, id)
+			}
+		}
+	}
+	for _, ing := range inv.Ingresses {
+		if kutil.SystemNamespace(ing.Namespace) {
+			continue
+		}
+		id := g.add("Ingress", ing.Namespace, ing.Name, ing.HelmRelease, tierIngress)
+		inNS(ing.Namespace, id)
+		for _, r := range ing.Rules {
+			if svc, _, _ := strings.Cut(r.Backend, ":"); svc != "" {
+				g.edge(stepID("Service", ing.Namespace, svc), id)
+			}
+		}
+		for _, s := range ing.TLSSecrets {
+			g.edge(stepID("Secret", ing.Namespace, s), id)
+		}
+	}
+
+	return g.ordered()
+}
+
+// ordered emits the graph in dependency order (Kahn's algorithm), choosing the
+// lowest tier, then namespace, kind and name among ready nodes. Nodes left
+// in a cycle are appended in the same order.
+func (g planGraph) ordered() model.RestorePlan {
+	ready := &planQueue{}
+	for id, n := range g {
+		for dep := range n.deps {
+			g[dep].next = append(g[dep].next, id)
+		}
+		n.wait = len(n.deps)
+		if n.wait == 0 {
+			heap.Push(ready, n)
+		}
+	}
+
+	plan := model.RestorePlan{Steps: []model.RestoreStep{}}
+	emitted := map[string]bool{}
+	emit := func(n *planNode) {
+		n.step.Order = len(plan.Steps) + 1
+		n.step.Wave = 1
+		for dep := range n.deps {
+			n.step.DependsOn = append(n.step.DependsOn, dep)
+			if d := g[dep]; emitted[dep] && d.step.Wave >= n.step.Wave {
+				n.step.Wave = d.step.Wave + 1
+			}
+		}
+		sort.Strings(n.step.DependsOn)
+		emitted[n.step.ID] = true
+		plan.Steps = append(plan.Steps, n.step)
+	}
+	for ready.Len() > 0 {
+		n := heap.Pop(ready).(*planNode)
+		emit(n)
+		for _, id := range n.next {
+			next := g[id]
+			next.wait--
+			if next.wait == 0 {
+				heap.Push(ready, next)
+			}
+		}
+	}
+
+	if len(plan.Steps) < len(g) {
+		var rest planQueue
+		for id, n := range g {
+			if !emitted[id] {
+				rest = append(rest, n)
+			}
+		}
+		sort.Sort(rest)
+		for _, n := range rest {
+			plan.Cyclic = append(plan.Cyclic, n.step.ID)
+			emit(n)
+		}
+	}
+	return plan
+}
+
+// planQueue orders ready nodes by tier, namespace, kind and name. It
+// implements heap.Interface.
+type planQueue []*planNode
+
+func (q planQueue) Len() int { return len(q) }
+func (q planQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.tier != b.tier {
+		return a.tier < b.tier
+	}
+	if a.step.Namespace != b.step.Namespace {
+		return a.step.Namespace < b.step.Namespace
+	}
+	if a.step.Kind != b.step.Kind {
+		return a.step.Kind < b.step.Kind
+	}
+	return a.step.Name < b.step.Name
+}
+func (q planQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *planQueue) Push(x any)   { *q = append(*q, x.(*planNode)) }
+func (q *planQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// stepID is Kind/namespace/name, or Kind/name for cluster-scoped objects.
+func stepID(kind, ns, name string) string {
+	if ns == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + ns + "/" + name
+}
+
+// isOperator reports whether a workload looks like an operator or
+// controller: its name says so, or it carries the labels kubebuilder and
+// common charts put on controller pods.
+func isOperator(name string, podLabels map[string]string) bool {
+	if strings.Contains(strings.ToLower(name), "operator") {
+		return true
+	}
+	if podLabels["control-plane"] == "controller-manager" {
+		return true
+	}
+	switch podLabels["app.kubernetes.io/component"] {
+	case "operator", "controller":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package restore
+
+import (
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestPlan(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	inv := &b.Inventory
+	inv.CRDs = []model.CRD{{Name: "databases.example.com", Group: "example.com"}}
+	inv.Namespaces = []model.Namespace{{Name: "kube-system"}, {Name: "ops"}, {Name: "shop"}}
+	inv.Secrets = []model.Secret{
+		{Namespace: "shop", Name: "db-creds"},
+		{Namespace: "shop", Name: "web-tls"},
+		{Namespace: "shop", Name: "chart-values", HelmRelease: "web"},
+		{Namespace: "kube-system", Name: "bootstrap"},
+	}
+	inv.ConfigMaps = []model.ConfigMap{{Namespace: "shop", Name: "web-config"}}
+	inv.PVCs = []model.PersistentVolumeClaim{{Namespace: "shop", Name: "data-db-0"}}
+	inv.Deployments = []model.Deployment{
+		{Namespace: "ops", Name: "db-operator"},
+		{Namespace: "shop", Name: "web", WorkloadRefs: model.WorkloadRefs{
+			PodLabels:   map[string]string{"app": "web"},
+			ConfigMaps:  []string{"web-config"},
+			HelmRelease: "web",
+		}},
+	}
+	inv.StatefulSets = []model.StatefulSet{{
+		Namespace:      "shop",
+		Name:           "db",
+		ClaimTemplates: []string{"data"},
+		WorkloadRefs: model.WorkloadRefs{
+			Secrets: []string{"db-creds"},
+			Owners:  []model.OwnerRef{{APIVersion: "example.com/v1", Kind: "Database", Name: "db"}},
+		},
+	}}
+	inv.Services = []model.Service{{Namespace: "shop", Name: "web", Selector: map[string]string{"app": "web"}}}
+	inv.Ingresses = []model.Ingress{{
+		Namespace:  "shop",
+		Name:       "web",
+		Rules:      []model.IngressRule{{Host: "shop.example.com", Backend: "web:80"}},
+		TLSSecrets: []string{"web-tls"},
+	}}
+
+	plan := Plan(&b)
+	steps := map[string]model.RestoreStep{}
+	for _, s := range plan.Steps {
+		steps[s.ID] = s
+	}
+	if len(plan.Steps) != len(steps) || len(plan.Cyclic) != 0 {
+		t.Fatalf("plan has duplicate or cyclic steps: %+v", plan)
+	}
+	if _, ok := steps["Secret/kube-system/bootstrap"]; ok {
+		t.Error("kube-system objects are in the plan")
+	}
+	if !steps["Deployment/ops/db-operator"].Operator {
+		t.Error("db-operator not recognised as an operator")
+	}
+
+	before := [][2]string{
+		{"CustomResourceDefinition/databases.example.com", "Deployment/ops/db-operator"},
+		{"Namespace/ops", "Deployment/ops/db-operator"},
+		{"Deployment/ops/db-operator", "Namespace/shop"},
+		{"Namespace/shop", "Secret/shop/db-creds"},
+		{"Secret/shop/db-creds", "StatefulSet/shop/db"},
+		{"PersistentVolumeClaim/shop/data-db-0", "StatefulSet/shop/db"},
+		{"ConfigMap/shop/web-config", "Deployment/shop/web"},
+		{"Secret/shop/chart-values", "Deployment/shop/web"},
+		{"Deployment/shop/web", "Service/shop/web"},
+		{"Service/shop/web", "Ingress/shop/web"},
+		{"Secret/shop/web-tls", "Ingress/shop/web"},
+	}
+	for _, p := range before {
+		a, ok1 := steps[p[0]]
+		z, ok2 := steps[p[1]]
+		if !ok1 || !ok2 {
+			t.Errorf("missing step %s or %s", p[0], p[1])
+			continue
+		}
+		if a.Order >= z.Order || a.Wave >= z.Wave {
+			t.Errorf("%s (order %d, wave %d) should come before %s (order %d, wave %d)",
+				p[0], a.Order, a.Wave, p[1], z.Order, z.Wave)
+		}
+	}
+
+	db := steps["StatefulSet/shop/db"]
+	want := []string{
+		"CustomResourceDefinition/databases.example.com",
+		"Namespace/shop",
+		"PersistentVolumeClaim/shop/data-db-0",
+		"Secret/shop/db-creds",
+	}
+	if len(db.DependsOn) != len(want) {
+		t.Fatalf("db depends on %v, want %v", db.DependsOn, want)
+	}
+	for i := range want {
+		if db.DependsOn[i] != want[i] {
+			t.Errorf("db depends on %v, want %v", db.DependsOn, want)
+			break
+		}
+	}
+}
+
+func TestPlanCycle(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Deployments = []model.Deployment{
+		{Namespace: "app", Name: "a", WorkloadRefs: model.WorkloadRefs{Owners: []model.OwnerRef{{APIVersion: "apps/v1", Kind: "Deployment", Name: "b"}}}},
+		{Namespace: "app", Name: "b", WorkloadRefs: model.WorkloadRefs{Owners: []model.OwnerRef{{APIVersion: "apps/v1", Kind: "Deployment", Name: "a"}}}},
+	}
+	plan := Plan(&b)
+	if len(plan.Steps) != 3 || len(plan.Cyclic) != 2 || plan.Steps[0].ID != "Namespace/app" {
+		t.Errorf("plan = %+v; want the namespace, then both deployments as cyclic", plan)
+	}
+}
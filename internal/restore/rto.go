@@ -0,0 +1,192 @@
+package restore
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// Defaults for RTOModel fields left at zero.
+const (
+	defaultGBPerHour          = 360 // ~100 MB/s
+	defaultNamespaceMinutes   = 15
+	defaultPVCMinutes         = 2
+	defaultObjectSeconds      = 0.5
+	defaultImageGB            = 0.3
+	defaultImagePullGBPerHour = 180 // ~50 MB/s from the registry
+	defaultParallel           = 1
+	defaultClusterMinutes     = 30
+)
+
+// DefaultRTOModel returns the built-in restore time assumptions.
+func DefaultRTOModel() model.RTOModel {
+	return withDefaults(model.RTOModel{})
+}
+
+func withDefaults(m model.RTOModel) model.RTOModel {
+	set := func(v *float64, def float64) {
+		if *v == 0 {
+			*v = def
+		}
+	}
+	set(&m.GBPerHour, defaultGBPerHour)
+	set(&m.NamespaceMinutes, defaultNamespaceMinutes)
+	set(&m.PVCMinutes, defaultPVCMinutes)
+	set(&m.ObjectSeconds, defaultObjectSeconds)
+	set(&m.ImageGB, defaultImageGB)
+	set(&m.ImagePullGBPerHour, defaultImagePullGBPerHour)
+	set(&m.ClusterMinutes, defaultClusterMinutes)
+	if m.Parallel == 0 {
+		m.Parallel = defaultParallel
+	}
+	return m
+}
+
+// LoadRTOModel reads an --rto-model YAML (or JSON) file:
+//
+//	gbPerHour: 500
+//	throughput:
+//	  ebs.csi.aws.com: 250
+//	  kasten: 700
+//	parallel: 3
+//
+// Fields left out take the defaults.
+func LoadRTOModel(file string) (model.RTOModel, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return model.RTOModel{}, fmt.Errorf("rto model: %w", err)
+	}
+	var m model.RTOModel
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return model.RTOModel{}, fmt.Errorf("rto model %s: %w", file, err)
+	}
+	for name, v := range map[string]float64{
+		"gbPerHour":          m.GBPerHour,
+		"namespaceMinutes":   m.NamespaceMinutes,
+		"pvcMinutes":         m.PVCMinutes,
+		"objectSeconds":      m.ObjectSeconds,
+		"imageGb":            m.ImageGB,
+		"imagePullGbPerHour": m.ImagePullGBPerHour,
+		"parallel":           float64(m.Parallel),
+		"clusterMinutes":     m.ClusterMinutes,
+	} {
+		if v < 0 {
+			return model.RTOModel{}, fmt.Errorf("rto model %s: %s must not be negative", file, name)
+		}
+	}
+	for key, v := range m.Throughput {
+		if v <= 0 {
+			return model.RTOModel{}, fmt.Errorf("rto model %s: throughput %q must be positive", file, key)
+		}
+	}
+	return withDefaults(m), nil
+}
+
+// restoreWork is what restoring one namespace involves.
+type restoreWork struct {
+	objects   int
+	pvcs      int
+	images    int
+	sizeGB    float64
+	dataHours float64 // sizeGB spread over each PVC's throughput
+}
+
+// estimateRTO turns a namespace's restore work into hours, rounded to a
+// tenth, and the per-phase breakdown. PVCs and images are handled one after
+// another, which overestimates for tools that restore volumes in parallel.
+func estimateRTO(m model.RTOModel, w restoreWork) (float64, model.RTOBreakdown) {
+	bd := model.RTOBreakdown{
+		BaseHours:    m.NamespaceMinutes / 60,
+		ObjectsHours: float64(w.objects) * m.ObjectSeconds / 3600,
+		VolumesHours: float64(w.pvcs) * m.PVCMinutes / 60,
+		DataHours:    w.dataHours,
+		ImagesHours:  float64(w.images) * m.ImageGB / m.ImagePullGBPerHour,
+	}
+	if w.dataHours > 0 {
+		bd.GBPerHour = math.Round(w.sizeGB / w.dataHours)
+	}
+	total := round1(bd.BaseHours + bd.ObjectsHours + bd.VolumesHours + bd.DataHours + bd.ImagesHours)
+	for _, h := range []*float64{&bd.BaseHours, &bd.ObjectsHours, &bd.VolumesHours, &bd.DataHours, &bd.ImagesHours} {
+		*h = math.Round(*h*100) / 100
+	}
+	return total, bd
+}
+
+// pvcThroughput is the GB/h a PVC's data is restored at: the entry for its
+// provisioner, else the fastest entry among the tools protecting it, else
+// m.GBPerHour.
+func pvcThroughput(m model.RTOModel, provisioner string, tools []string) float64 {
+	if v := m.Throughput[provisioner]; provisioner != "" && v > 0 {
+		return v
+	}
+	best := 0.0
+	for _, t := range tools {
+		if v := m.Throughput[t]; v > best {
+			best = v
+		}
+	}
+	if best > 0 {
+		return best
+	}
+	return m.GBPerHour
+}
+
+// sequence orders a full-cluster recovery and sets RestoreOrder and
+// ReadyAfterHours on each namespace. Cluster-wide setup comes first, then
+// namespaces fill m.Parallel restore slots: tightest RTO target first, then
+// the longest restores (which keeps the total short). Returns when the last
+// namespace is ready, or 0 when there is nothing to restore.
+func sequence(nss []model.RestoreSimNamespace, m model.RTOModel) float64 {
+	if len(nss) == 0 {
+		return 0
+	}
+	target := func(ns *model.RestoreSimNamespace) float64 {
+		if ns.Target != nil && ns.Target.RTOHours > 0 {
+			return ns.Target.RTOHours
+		}
+		return math.Inf(1)
+	}
+	order := make([]int, len(nss))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := &nss[order[a]], &nss[order[b]]
+		if tx, ty := target(x), target(y); tx != ty {
+			return tx < ty
+		}
+		if x.EstimatedRTOHours != y.EstimatedRTOHours {
+			return x.EstimatedRTOHours > y.EstimatedRTOHours
+		}
+		return x.Namespace < y.Namespace
+	})
+
+	start := m.ClusterMinutes / 60
+	slots := make([]float64, max(m.Parallel, 1))
+	for i := range slots {
+		slots[i] = start
+	}
+	full := start
+	for n, i := range order {
+		k := 0
+		for j := range slots {
+			if slots[j] < slots[k] {
+				k = j
+			}
+		}
+		slots[k] += nss[i].EstimatedRTOHours
+		nss[i].RestoreOrder = n + 1
+		nss[i].ReadyAfterHours = round1(slots[k])
+		full = max(full, slots[k])
+	}
+	return round1(full)
+}
+
+func round1(h float64) float64 {
+	return math.Round(h*10) / 10
+}
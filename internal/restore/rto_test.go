@@ -0,0 +1,98 @@
+package restore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestSimulateRTO(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.StorageClasses = []model.StorageClass{
+		{Name: "fast", Provisioner: "ebs.csi.aws.com"},
+		{Name: "slow", Provisioner: "nfs.csi.k8s.io"},
+	}
+	b.Inventory.PVCs = []model.PersistentVolumeClaim{
+		{Namespace: "db", Name: "data", StorageClass: "fast", RequestedSize: "720Gi"},
+		{Namespace: "files", Name: "share", StorageClass: "slow", RequestedSize: "360Gi"},
+	}
+	b.Inventory.StatefulSets = []model.StatefulSet{{Namespace: "db", Name: "pg", Images: []string{"postgres:16"}}}
+	b.Inventory.Deployments = []model.Deployment{
+		{Namespace: "web", Name: "api", Images: []string{"api:1", "sidecar:1"}},
+		{Namespace: "web", Name: "ui", Images: []string{"ui:1", "sidecar:1"}},
+		{Namespace: "kube-system", Name: "coredns", Images: []string{"coredns:1"}},
+	}
+	b.Inventory.Namespaces = []model.Namespace{
+		{Name: "web", Target: &model.DRTarget{RTOHours: 1}},
+	}
+	b.Inventory.Backup = model.BackupInventory{
+		Protection: []model.NamespaceProtection{
+			{Namespace: "files", PVCs: []model.PVCProtection{{Name: "share", Tools: []string{"velero", "kasten"}}}},
+		},
+	}
+
+	sim := SimulateWith(&b, model.RTOModel{
+		Throughput:     map[string]float64{"ebs.csi.aws.com": 720, "kasten": 120, "velero": 180},
+		Parallel:       2,
+		ClusterMinutes: 60,
+	})
+
+	got := map[string]model.RestoreSimNamespace{}
+	for _, ns := range sim.Namespaces {
+		got[ns.Namespace] = ns
+	}
+	if _, ok := got["kube-system"]; ok {
+		t.Error("kube-system simulated; system namespaces are not restored")
+	}
+	// db: 0.25 base + 2 min PVC + 720 GB at the provisioner's 720 GB/h + 1 image.
+	if db := got["db"]; db.RTO == nil || db.RTO.GBPerHour != 720 || db.EstimatedRTOHours != 1.3 {
+		t.Errorf("db = %.1fh %+v; want 1.3h at 720 GB/h", db.EstimatedRTOHours, db.RTO)
+	}
+	// files: no provisioner entry, so the faster of its tools (velero, 180 GB/h).
+	if files := got["files"]; files.RTO == nil || files.RTO.GBPerHour != 180 || files.EstimatedRTOHours != 2.3 {
+		t.Errorf("files = %.1fh %+v; want 2.3h at 180 GB/h", files.EstimatedRTOHours, files.RTO)
+	}
+	web := got["web"]
+	if web.Stateful || web.ImageCount != 3 || web.ObjectCount != 2 {
+		t.Errorf("web = stateful %v, %d images, %d objects; want stateless, 3 distinct images, 2 objects",
+			web.Stateful, web.ImageCount, web.ObjectCount)
+	}
+
+	// Two slots after 1h of cluster setup: web first (RTO target), then the
+	// longest restore (files) in the other slot, db after web.
+	if web.RestoreOrder != 1 || got["files"].RestoreOrder != 2 || got["db"].RestoreOrder != 3 {
+		t.Errorf("order = web %d, files %d, db %d; want 1, 2, 3", web.RestoreOrder, got["files"].RestoreOrder, got["db"].RestoreOrder)
+	}
+	if web.ReadyAfterHours != 1.3 || web.RTOStatus != "missed" {
+		t.Errorf("web ready after %.1fh (%s); want 1.3h, missing its 1h target", web.ReadyAfterHours, web.RTOStatus)
+	}
+	if got["db"].ReadyAfterHours != 2.6 || sim.EstimatedFullRecoveryHours != 3.3 {
+		t.Errorf("db ready after %.1fh, full recovery %.1fh; want 2.6h and 3.3h",
+			got["db"].ReadyAfterHours, sim.EstimatedFullRecoveryHours)
+	}
+}
+
+func TestLoadRTOModel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rto.yaml")
+	os.WriteFile(path, []byte("gbPerHour: 500\nthroughput:\n  kasten: 700\nparallel: 3\n"), 0o644)
+	m, err := LoadRTOModel(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GBPerHour != 500 || m.Throughput["kasten"] != 700 || m.Parallel != 3 || m.PVCMinutes != defaultPVCMinutes {
+		t.Errorf("model = %+v; want file values with defaults for the rest", m)
+	}
+
+	os.WriteFile(path, []byte("throughput:\n  kasten: 0\n"), 0o644)
+	if _, err := LoadRTOModel(path); err == nil {
+		t.Error("LoadRTOModel accepted a zero throughput")
+	}
+	os.WriteFile(path, []byte("gbPerHr: 500\n"), 0o644)
+	if _, err := LoadRTOModel(path); err == nil {
+		t.Error("LoadRTOModel accepted an unknown field")
+	}
+}
@@ -1,12 +1,13 @@
 // Package restore provides restore simulation logic that assesses per-namespace
 // recovery feasibility based on backup policy coverage, PVC sizing, and known
-// blockers (hostPath volumes, unbound PVCs, missing StorageClasses).
+// blockers (hostPath volumes, unbound PVCs, missing StorageClasses), and
+// estimates how long a namespace and a full-cluster recovery would take.
 package restore
 
 import (
-	"strconv"
-	"strings"
+	"sort"
 
+	"k8s-recovery-visualizer/internal/kutil"
 	"k8s-recovery-visualizer/internal/model"
 )
 
@@ -14,12 +15,29 @@ import (
 // the aggregated result. It is called after backup.Detect() so that policy
 // data is already present on the bundle.
 func Simulate(b *model.Bundle) model.RestoreSimResult {
+	return SimulateWith(b, DefaultRTOModel())
+}
+
+// SimulateWith is Simulate with restore time assumptions from m (see
+// LoadRTOModel); zero fields take the defaults.
+func SimulateWith(b *model.Bundle, m model.RTOModel) model.RestoreSimResult {
 	inv := b.Inventory.Backup
+	m = withDefaults(m)
 
 	// Build set of StorageClasses present in the cluster for blocker detection.
 	scSet := map[string]struct{}{}
+	provisioner := map[string]string{}
 	for _, sc := range b.Inventory.StorageClasses {
 		scSet[sc.Name] = struct{}{}
+		provisioner[sc.Name] = sc.Provisioner
+	}
+
+	// Tools protecting each PVC's data, for per-tool restore throughput.
+	pvcTools := map[string][]string{}
+	for _, np := range inv.Protection {
+		for _, pp := range np.PVCs {
+			pvcTools[np.Namespace+"/"+pp.Name] = pp.Tools
+		}
 	}
 
 	// Build per-namespace PVC size totals and per-PVC metadata for blocker checks.
@@ -28,6 +46,7 @@ func Simulate(b *model.Bundle) model.RestoreSimResult {
 		sizeGB       float64
 		bound        bool   // true when a matching PV exists
 		backend      string // "hostPath" triggers a blocker
+		gbPerHour    float64
 	}
 	nsPVCs := map[string][]pvcMeta{}
 	pvMap := map[string]model.PersistentVolume{}
@@ -42,36 +61,90 @@ func Simulate(b *model.Bundle) model.RestoreSimResult {
 		}
 		nsPVCs[pvc.Namespace] = append(nsPVCs[pvc.Namespace], pvcMeta{
 			storageClass: pvc.StorageClass,
-			sizeGB:       parseGiB(pvc.RequestedSize),
+			sizeGB:       kutil.SizeGiB(pvc.RequestedSize),
 			bound:        bound,
 			backend:      backend,
+			gbPerHour:    pvcThroughput(m, provisioner[pvc.StorageClass], pvcTools[pvc.Namespace+"/"+pvc.Name]),
 		})
 	}
 
 	// Collect the namespaces that are relevant for restore simulation:
-	// any namespace that has StatefulSets or PVCs.
+	// any namespace that has StatefulSets or PVCs, runs other workloads
+	// outside the system namespaces, or declares a recovery target.
 	relevantNS := map[string]struct{}{}
+	stateful := map[string]bool{}
+	images := map[string]map[string]struct{}{}
+	addImages := func(ns string, imgs []string) {
+		if images[ns] == nil {
+			images[ns] = map[string]struct{}{}
+		}
+		for _, img := range imgs {
+			images[ns][img] = struct{}{}
+		}
+	}
 	for _, sts := range b.Inventory.StatefulSets {
 		relevantNS[sts.Namespace] = struct{}{}
+		stateful[sts.Namespace] = true
+		addImages(sts.Namespace, sts.Images)
+	}
+	for _, d := range b.Inventory.Deployments {
+		addImages(d.Namespace, d.Images)
+		if !kutil.SystemNamespace(d.Namespace) {
+			relevantNS[d.Namespace] = struct{}{}
+		}
+	}
+	for _, ds := range b.Inventory.DaemonSets {
+		addImages(ds.Namespace, ds.Images)
+		if !kutil.SystemNamespace(ds.Namespace) {
+			relevantNS[ds.Namespace] = struct{}{}
+		}
 	}
 	for ns := range nsPVCs {
 		relevantNS[ns] = struct{}{}
+		stateful[ns] = true
 	}
+	targets := map[string]*model.DRTarget{}
+	for _, ns := range b.Inventory.Namespaces {
+		if ns.Target != nil {
+			relevantNS[ns.Name] = struct{}{}
+			targets[ns.Name] = ns.Target
+		}
+	}
+
+	// Last successful backup per namespace, when backup objects were readable.
+	lastBackup := map[string]model.NamespaceBackup{}
+	if ev := inv.Evidence; ev != nil {
+		for _, nb := range ev.Namespaces {
+			lastBackup[nb.Namespace] = nb
+		}
+	}
+
+	objects := namespacedObjects(b)
 
-	var result model.RestoreSimResult
+	result := model.RestoreSimResult{RTOModel: m}
 	var uncoveredNS []string
 
 	for ns := range relevantNS {
+		np, covered := inv.NamespaceCoverage(ns)
 		sim := model.RestoreSimNamespace{
 			Namespace:   ns,
-			HasCoverage: policyCoversNamespace(inv, ns),
-			RPOHours:    bestRPOForNamespace(inv, ns),
+			HasCoverage: covered,
+			Stateful:    stateful[ns],
+			RPOHours:    np.RPOHours,
+
+			MeasuredRPOHours: -1,
+		}
+		if nb, ok := lastBackup[ns]; ok {
+			t := nb.LastSuccess
+			sim.LastBackup = &t
+			sim.MeasuredRPOHours = nb.AgeHours
 		}
 
 		// Sum PVC sizes and collect blockers/warnings.
-		var nsSizeGB float64
+		var nsSizeGB, dataHours float64
 		for _, pvc := range nsPVCs[ns] {
 			nsSizeGB += pvc.sizeGB
+			dataHours += pvc.sizeGB / pvc.gbPerHour
 			if !pvc.bound {
 				sim.Blockers = append(sim.Blockers, "unbound PVC")
 			}
@@ -86,8 +159,22 @@ func Simulate(b *model.Bundle) model.RestoreSimResult {
 			}
 		}
 		sim.PVCSizeGB = nsSizeGB
+		sim.AchievedRPOHours = achievedRPO(sim)
+		sim.PVCCount = len(nsPVCs[ns])
+		sim.ObjectCount = objects[ns]
+		sim.ImageCount = len(images[ns])
+		rto, bd := estimateRTO(m, restoreWork{
+			objects:   sim.ObjectCount,
+			pvcs:      sim.PVCCount,
+			images:    sim.ImageCount,
+			sizeGB:    nsSizeGB,
+			dataHours: dataHours,
+		})
+		sim.EstimatedRTOHours = rto
+		sim.RTO = &bd
+		sim.Target = targets[ns]
 
-		if !sim.HasCoverage {
+		if !sim.HasCoverage && sim.Stateful {
 			uncoveredNS = append(uncoveredNS, ns)
 		}
 
@@ -98,113 +185,115 @@ func Simulate(b *model.Bundle) model.RestoreSimResult {
 		}
 	}
 
+	sort.Slice(result.Namespaces, func(i, j int) bool { return result.Namespaces[i].Namespace < result.Namespaces[j].Namespace })
+	result.EstimatedFullRecoveryHours = sequence(result.Namespaces, m)
+	for i := range result.Namespaces {
+		if ns := &result.Namespaces[i]; ns.Target != nil {
+			ns.RPOStatus, ns.RTOStatus = targetStatus(*ns, ns.Target)
+		}
+	}
+	sort.Strings(uncoveredNS)
 	result.UncoveredNS = uncoveredNS
+	if ev := inv.Evidence; ev != nil {
+		result.RestoreTested = ev.RestoresCompleted > 0
+		result.LastRestore = ev.LastRestore
+	}
 	return result
 }
 
-// policyCoversNamespace returns true when at least one policy covers the given
-// namespace, or when the tool has wildcard coverage (CoveredNamespaces == ["*"]).
-func policyCoversNamespace(inv model.BackupInventory, ns string) bool {
-	if inv.PrimaryTool == "none" || inv.PrimaryTool == "" {
-		return false
-	}
-	// Policy-level check (Velero, Kasten, Longhorn).
-	if len(inv.Policies) > 0 {
-		for _, p := range inv.Policies {
-			if matchesPolicy(p, ns) {
-				return true
-			}
-		}
-		return false
+// namespacedObjects counts the API objects a restore re-creates, per
+// namespace.
+func namespacedObjects(b *model.Bundle) map[string]int {
+	inv := b.Inventory
+	n := map[string]int{}
+	for _, x := range inv.Deployments {
+		n[x.Namespace]++
 	}
-	// Fallback: use the legacy CoveredNamespaces list.
-	if len(inv.CoveredNamespaces) == 1 && inv.CoveredNamespaces[0] == "*" {
-		return true
+	for _, x := range inv.StatefulSets {
+		n[x.Namespace]++
 	}
-	for _, covered := range inv.CoveredNamespaces {
-		if covered == ns {
-			return true
-		}
+	for _, x := range inv.DaemonSets {
+		n[x.Namespace]++
 	}
-	return false
-}
-
-// matchesPolicy returns true when a BackupPolicy covers the given namespace.
-func matchesPolicy(p model.BackupPolicy, ns string) bool {
-	// Excluded always wins.
-	for _, ex := range p.ExcludedNS {
-		if ex == ns {
-			return false
-		}
+	for _, x := range inv.Jobs {
+		n[x.Namespace]++
 	}
-	// Empty IncludedNS = all namespaces.
-	if len(p.IncludedNS) == 0 {
-		return true
+	for _, x := range inv.CronJobs {
+		n[x.Namespace]++
 	}
-	for _, incl := range p.IncludedNS {
-		if incl == ns || incl == "*" {
-			return true
-		}
+	for _, x := range inv.Services {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.Ingresses {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.NetworkPolicies {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.ConfigMaps {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.Secrets {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.ServiceAccounts {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.PVCs {
+		n[x.Namespace]++
 	}
-	return false
+	for _, x := range inv.HPAs {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.PodDisruptionBudgets {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.ResourceQuotas {
+		n[x.Namespace]++
+	}
+	for _, x := range inv.LimitRanges {
+		n[x.Namespace]++
+	}
+	return n
 }
 
-// bestRPOForNamespace returns the lowest (best) RPO hours across all policies
-// that cover the namespace. Returns -1 when unknown or no coverage.
-func bestRPOForNamespace(inv model.BackupInventory, ns string) int {
-	best := -1
-	for _, p := range inv.Policies {
-		if !matchesPolicy(p, ns) {
-			continue
-		}
-		if p.RPOHours < 0 {
-			continue
-		}
-		if best < 0 || p.RPOHours < best {
-			best = p.RPOHours
-		}
+// achievedRPO is the worst-case data loss for a namespace: the worse of the
+// scheduled RPO and the age of the last successful backup. -1 when the
+// namespace is uncovered or neither is known.
+func achievedRPO(sim model.RestoreSimNamespace) float64 {
+	if !sim.HasCoverage {
+		return -1
+	}
+	rpo := float64(sim.RPOHours)
+	if sim.MeasuredRPOHours > rpo {
+		rpo = sim.MeasuredRPOHours
 	}
-	return best
+	return rpo
 }
 
-// parseGiB converts a Kubernetes quantity string (e.g. "10Gi", "500Mi", "2Ti")
-// to a float64 number of GiB. Returns 0 for unparseable values.
-func parseGiB(s string) float64 {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return 0
-	}
-	suffixes := []struct {
-		suffix string
-		factor float64
-	}{
-		{"Ti", 1024},
-		{"Gi", 1},
-		{"Mi", 1.0 / 1024},
-		{"Ki", 1.0 / (1024 * 1024)},
-		{"T", 1000},
-		{"G", 1000.0 / 1024},
-		{"M", 1000.0 / (1024 * 1024)},
-	}
-	for _, sf := range suffixes {
-		if strings.HasSuffix(s, sf.suffix) {
-			numStr := strings.TrimSuffix(s, sf.suffix)
-			var n float64
-			if err := parseFloat(numStr, &n); err == nil {
-				return n * sf.factor
-			}
+// targetStatus checks a namespace against its target: the achieved RPO
+// against the RPO target, and when the namespace is ready in a full-cluster
+// recovery against the RTO target. Each is "met" or "missed", or empty when
+// that target is unset. An uncovered namespace misses any RPO target; a
+// covered one whose RPO cannot be determined is "unknown".
+func targetStatus(sim model.RestoreSimNamespace, t *model.DRTarget) (rpo, rto string) {
+	if t.RPOHours > 0 {
+		switch {
+		case !sim.HasCoverage:
+			rpo = "missed"
+		case sim.AchievedRPOHours < 0:
+			rpo = "unknown"
+		case sim.AchievedRPOHours <= t.RPOHours:
+			rpo = "met"
+		default:
+			rpo = "missed"
 		}
 	}
-	// Plain bytes
-	var n float64
-	if err := parseFloat(s, &n); err == nil {
-		return n / (1024 * 1024 * 1024)
+	if t.RTOHours > 0 {
+		rto = "met"
+		if sim.ReadyAfterHours > t.RTOHours {
+			rto = "missed"
+		}
 	}
-	return 0
-}
-
-func parseFloat(s string, out *float64) error {
-	n, err := strconv.ParseFloat(s, 64)
-	*out = n
-	return err
+	return rpo, rto
 }
@@ -0,0 +1,182 @@
+// Package targets resolves per-namespace recovery objectives (RPO and RTO)
+// from namespace annotations and an optional --targets file.
+package targets
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// Namespace annotations declaring recovery objectives, e.g.
+// dr.example/rpo: 4h. They take precedence over the targets file.
+const (
+	RPOAnnotation = model.AnnotationPrefix + "rpo"
+	RTOAnnotation = model.AnnotationPrefix + "rto"
+)
+
+// File is the top-level targets document. The first namespaces entry whose
+// glob matches wins; default applies to every other namespace.
+//
+//	default:
+//	  rpo: 24h
+//	  rto: 8h
+//	namespaces:
+//	  - namespace: "prod-*"
+//	    rpo: 4h
+//	    rto: 2h
+type File struct {
+	Default    *Target  `json:"default,omitempty"`
+	Namespaces []Target `json:"namespaces,omitempty"`
+}
+
+// Target is an RPO/RTO pair. Either may be empty.
+type Target struct {
+	// Namespace is a glob ("prod-*"). Ignored on the default target.
+	Namespace string `json:"namespace,omitempty"`
+	RPO       string `json:"rpo,omitempty"`
+	RTO       string `json:"rto,omitempty"`
+
+	rpo, rto float64
+}
+
+// Load reads and validates a targets YAML (or JSON) file.
+func Load(file string) (*File, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("targets: %w", err)
+	}
+	var f File
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("targets %s: %w", file, err)
+	}
+	check := func(t *Target, what string) error {
+		var err error
+		if t.rpo, err = parseObjective(t.RPO); err != nil {
+			return fmt.Errorf("targets %s: %s rpo: %w", file, what, err)
+		}
+		if t.rto, err = parseObjective(t.RTO); err != nil {
+			return fmt.Errorf("targets %s: %s rto: %w", file, what, err)
+		}
+		if t.rpo == 0 && t.rto == 0 {
+			return fmt.Errorf("targets %s: %s sets neither rpo nor rto", file, what)
+		}
+		return nil
+	}
+	if f.Default != nil {
+		if err := check(f.Default, "default"); err != nil {
+			return nil, err
+		}
+	}
+	for i := range f.Namespaces {
+		t := &f.Namespaces[i]
+		if strings.TrimSpace(t.Namespace) == "" {
+			return nil, fmt.Errorf("targets %s: entry %d has no namespace", file, i)
+		}
+		if _, err := path.Match(t.Namespace, ""); err != nil {
+			return nil, fmt.Errorf("targets %s: namespace %q: %w", file, t.Namespace, err)
+		}
+		if err := check(t, "namespace "+t.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	return &f, nil
+}
+
+// parseObjective converts "4h", "90m", "1h30m", "2d" or a bare number of
+// hours to hours. Empty means no objective (0).
+func parseObjective(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("%q is not a duration", s)
+		}
+		return n * 24, nil
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("%q must be positive", s)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%q is not a duration", s)
+	}
+	return d.Hours(), nil
+}
+
+// Apply sets Namespace.Target on every scanned namespace that has an
+// objective, from its annotations first, then f (which may be nil). It
+// returns one warning per annotation that could not be parsed; that
+// annotation is ignored.
+func Apply(b *model.Bundle, f *File) []string {
+	var warnings []string
+	for i := range b.Inventory.Namespaces {
+		ns := &b.Inventory.Namespaces[i]
+		t := model.DRTarget{}
+		var sources []string
+		for _, a := range []struct {
+			key string
+			dst *float64
+		}{{RPOAnnotation, &t.RPOHours}, {RTOAnnotation, &t.RTOHours}} {
+			v, ok := ns.Annotations[a.key]
+			if !ok {
+				continue
+			}
+			h, err := parseObjective(v)
+			if err != nil || h == 0 {
+				warnings = append(warnings, fmt.Sprintf("namespace %s: annotation %s=%q ignored: want a duration such as 4h", ns.Name, a.key, v))
+				continue
+			}
+			*a.dst = h
+			sources = appendSource(sources, "annotation")
+		}
+		if f != nil {
+			fill := func(from *Target, source string) {
+				if t.RPOHours == 0 && from.rpo > 0 {
+					t.RPOHours = from.rpo
+					sources = appendSource(sources, source)
+				}
+				if t.RTOHours == 0 && from.rto > 0 {
+					t.RTOHours = from.rto
+					sources = appendSource(sources, source)
+				}
+			}
+			for j := range f.Namespaces {
+				if ok, _ := path.Match(f.Namespaces[j].Namespace, ns.Name); ok {
+					fill(&f.Namespaces[j], "file")
+					break
+				}
+			}
+			if f.Default != nil {
+				fill(f.Default, "default")
+			}
+		}
+		if len(sources) > 0 {
+			t.Source = strings.Join(sources, ", ")
+			ns.Target = &t
+		}
+	}
+	return warnings
+}
+
+func appendSource(sources []string, s string) []string {
+	for _, x := range sources {
+		if x == s {
+			return sources
+		}
+	}
+	return append(sources, s)
+}
@@ -0,0 +1,95 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+	"k8s-recovery-visualizer/internal/restore"
+)
+
+func TestLoadAndApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.yaml")
+	err := os.WriteFile(path, []byte(`default:
+  rpo: 1d
+  rto: 8h
+namespaces:
+  - namespace: "prod-*"
+    rpo: 4h
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Namespaces = []model.Namespace{
+		{Name: "prod-db", Annotations: map[string]string{RTOAnnotation: "30m"}},
+		{Name: "prod-web", Annotations: map[string]string{RPOAnnotation: "soon"}},
+		{Name: "dev"},
+	}
+	warnings := Apply(&b, f)
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one for prod-web's bad rpo annotation", warnings)
+	}
+
+	want := map[string]model.DRTarget{
+		"prod-db":  {RPOHours: 4, RTOHours: 0.5, Source: "annotation, file"},
+		"prod-web": {RPOHours: 4, RTOHours: 8, Source: "file, default"},
+		"dev":      {RPOHours: 24, RTOHours: 8, Source: "default"},
+	}
+	for _, ns := range b.Inventory.Namespaces {
+		if ns.Target == nil || *ns.Target != want[ns.Name] {
+			t.Errorf("%s target = %+v, want %+v", ns.Name, ns.Target, want[ns.Name])
+		}
+	}
+}
+
+func TestLoadRejectsBadObjective(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.yaml")
+	os.WriteFile(path, []byte("namespaces:\n  - namespace: db\n    rpo: -4h\n"), 0o644)
+	if _, err := Load(path); err == nil {
+		t.Error("Load accepted a negative RPO")
+	}
+}
+
+func TestTargetCompliance(t *testing.T) {
+	b := model.NewBundle("test", time.Now())
+	b.Inventory.Namespaces = []model.Namespace{
+		{Name: "db", Annotations: map[string]string{RPOAnnotation: "4h", RTOAnnotation: "1h"}},
+		{Name: "web", Annotations: map[string]string{RPOAnnotation: "48h"}},
+		{Name: "batch", Annotations: map[string]string{RPOAnnotation: "24h"}},
+	}
+	b.Inventory.PVCs = []model.PersistentVolumeClaim{{Namespace: "db", Name: "data", RequestedSize: "720Gi"}}
+	b.Inventory.Backup = model.BackupInventory{
+		PrimaryTool: "velero",
+		Protection: []model.NamespaceProtection{
+			{Namespace: "batch", RPOHours: -1},
+			{Namespace: "db", Tools: []string{"velero"}, Policies: []string{"velero/daily"}, RPOHours: 24},
+			{Namespace: "web", Tools: []string{"velero"}, Policies: []string{"velero/daily"}, RPOHours: 24},
+		},
+	}
+	Apply(&b, nil)
+	sim := restore.Simulate(&b)
+
+	got := map[string]model.RestoreSimNamespace{}
+	for _, ns := range sim.Namespaces {
+		got[ns.Namespace] = ns
+	}
+	if db := got["db"]; db.RPOStatus != "missed" || db.RTOStatus != "missed" || db.EstimatedRTOHours != 2.3 {
+		t.Errorf("db = rpo %s, rto %s (%.1fh); want both missed, 720GB at 360GB/h + 0.25h = 2.3h",
+			db.RPOStatus, db.RTOStatus, db.EstimatedRTOHours)
+	}
+	if web := got["web"]; web.RPOStatus != "met" || web.Stateful {
+		t.Errorf("web = %+v, want a met RPO on a stateless namespace", web)
+	}
+	if batch := got["batch"]; batch.RPOStatus != "missed" || len(sim.UncoveredNS) != 0 {
+		t.Errorf("batch = %s, uncovered %v; want missed for no coverage, but not counted as an uncovered stateful namespace",
+			batch.RPOStatus, sim.UncoveredNS)
+	}
+}
@@ -0,0 +1,213 @@
+// Package waiver loads accepted-risk waivers (--waivers) and matches them
+// against findings.
+package waiver
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+// dateLayout is the format of Waiver.Expires.
+const dateLayout = "2006-01-02"
+
+// File is the top-level waivers document.
+//
+//	waivers:
+//	  - id: POD_HOSTPATH
+//	    namespace: kube-flannel
+//	    resource: "kube-flannel/kube-flannel-ds-*"
+//	    owner: platform-team
+//	    justification: CNI daemonset needs hostPath for /run/flannel
+//	    expires: "2026-12-31"
+type File struct {
+	Waivers []Waiver `json:"waivers"`
+}
+
+// Waiver accepts the risk of one finding ID, optionally narrowed to matching
+// resources and namespaces, until Expires.
+type Waiver struct {
+	// ID is the finding ID (glob).
+	ID string `json:"id"`
+	// Resource is a glob matched against the finding's ResourceID. Empty = any.
+	Resource string `json:"resource,omitempty"`
+	// Namespace is a glob every resource in the finding must fall under. Empty = any.
+	Namespace     string `json:"namespace,omitempty"`
+	Owner         string `json:"owner"`
+	Justification string `json:"justification"`
+	// Expires is the last day (YYYY-MM-DD, UTC) the waiver applies.
+	Expires string `json:"expires"`
+
+	expires time.Time
+}
+
+// Load reads and validates a waivers YAML (or JSON) file.
+func Load(path string) ([]Waiver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("waivers: %w", err)
+	}
+	var f File
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("waivers %s: %w", path, err)
+	}
+	for i := range f.Waivers {
+		w := &f.Waivers[i]
+		w.ID = strings.ToUpper(strings.TrimSpace(w.ID))
+		switch {
+		case w.ID == "":
+			return nil, fmt.Errorf("waivers %s: waiver %d has no id", path, i)
+		case strings.TrimSpace(w.Owner) == "":
+			return nil, fmt.Errorf("waivers %s: waiver %s has no owner", path, w.ID)
+		case strings.TrimSpace(w.Justification) == "":
+			return nil, fmt.Errorf("waivers %s: waiver %s has no justification", path, w.ID)
+		}
+		t, err := time.Parse(dateLayout, strings.TrimSpace(w.Expires))
+		if err != nil {
+			return nil, fmt.Errorf("waivers %s: waiver %s: expires must be YYYY-MM-DD: %w", path, w.ID, err)
+		}
+		w.expires = t
+	}
+	return f.Waivers, nil
+}
+
+// Expired reports whether now is past the waiver's last valid day.
+func (w Waiver) Expired(now time.Time) bool {
+	exp := w.expires
+	if exp.IsZero() {
+		exp, _ = time.Parse(dateLayout, w.Expires)
+	}
+	return !now.UTC().Before(exp.AddDate(0, 0, 1))
+}
+
+// Matches reports whether the waiver covers f.
+func (w Waiver) Matches(f model.Finding) bool {
+	if !glob(w.ID, f.ID) {
+		return false
+	}
+	if w.Resource != "" && !glob(w.Resource, f.ResourceID) {
+		return false
+	}
+	if w.Namespace != "" {
+		nss := Namespaces(f.ResourceID)
+		if len(nss) == 0 {
+			return false
+		}
+		for _, ns := range nss {
+			if !glob(w.Namespace, ns) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MatchesRef reports whether the waiver covers one affected resource of a
+// finding with the given ID. Namespace objects match on their own name.
+func (w Waiver) MatchesRef(id string, r model.ResourceRef) bool {
+	if !glob(w.ID, id) {
+		return false
+	}
+	if w.Resource != "" && !glob(w.Resource, r.String()) {
+		return false
+	}
+	if w.Namespace != "" {
+		ns := r.Namespace
+		if r.Kind == "Namespace" {
+			ns = r.Name
+		}
+		if ns == "" || !glob(w.Namespace, ns) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ref is the record stored on a finding covered by w.
+func (w Waiver) Ref(now time.Time) *model.WaiverRef {
+	return &model.WaiverRef{
+		Owner:         w.Owner,
+		Justification: w.Justification,
+		Expires:       w.Expires,
+		Expired:       w.Expired(now),
+	}
+}
+
+// Find returns the first waiver matching f, preferring one still in force.
+func Find(waivers []Waiver, f model.Finding, now time.Time) (Waiver, bool) {
+	return find(waivers, now, func(w Waiver) bool { return w.Matches(f) })
+}
+
+// FindRef is Find for a single affected resource of finding id.
+func FindRef(waivers []Waiver, id string, r model.ResourceRef, now time.Time) (Waiver, bool) {
+	return find(waivers, now, func(w Waiver) bool { return w.MatchesRef(id, r) })
+}
+
+func find(waivers []Waiver, now time.Time, match func(Waiver) bool) (Waiver, bool) {
+	var expired *Waiver
+	for i, w := range waivers {
+		if !match(w) {
+			continue
+		}
+		if !w.Expired(now) {
+			return w, true
+		}
+		if expired == nil {
+			expired = &waivers[i]
+		}
+	}
+	if expired != nil {
+		return *expired, true
+	}
+	return Waiver{}, false
+}
+
+// Namespaces extracts the namespace of every resource in a finding's
+// ResourceID ("ns/name", "kind:ns/a,ns/b" or "namespaces:a,b").
+// Cluster-scoped names yield none.
+func Namespaces(resourceID string) []string {
+	kind := ""
+	if i := strings.Index(resourceID, ":"); i >= 0 {
+		kind, resourceID = resourceID[:i], resourceID[i+1:]
+	}
+	var out []string
+	for _, r := range strings.Split(strings.TrimSuffix(resourceID, "..."), ",") {
+		if kind == "namespaces" && r != "" {
+			out = append(out, r)
+		} else if i := strings.Index(r, "/"); i > 0 {
+			out = append(out, r[:i])
+		} else {
+			return nil
+		}
+	}
+	return out
+}
+
+// glob matches s against a shell-style pattern where * spans any run of
+// characters (including "/") and ? matches one.
+func glob(pattern, s string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	var re strings.Builder
+	re.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			re.WriteString(".*")
+		case '?':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString("$")
+	ok, _ := regexp.MatchString(re.String(), s)
+	return ok
+}
@@ -0,0 +1,82 @@
+package waiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-recovery-visualizer/internal/model"
+)
+
+func TestLoadAndMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waivers.yaml")
+	err := os.WriteFile(path, []byte(`waivers:
+  - id: pod_hostpath
+    namespace: kube-flannel
+    resource: "kube-flannel/kube-flannel-ds-*"
+    owner: platform-team
+    justification: CNI needs /run/flannel
+    expires: 2026-12-31
+  - id: SINGLE_AZ_CLUSTER
+    owner: infra
+    justification: second AZ lands in Q3
+    expires: "2026-06-30"
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(ws) != 2 || ws[0].Expires != "2026-12-31" {
+		t.Fatalf("waivers = %+v", ws)
+	}
+
+	now := time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)
+	flannel := model.Finding{ID: "POD_HOSTPATH", ResourceID: "kube-flannel/kube-flannel-ds-x7k2p"}
+	if w, ok := Find(ws, flannel, now); !ok || w.Expired(now) {
+		t.Errorf("flannel pod should be waived on the expiry day")
+	}
+	if w, ok := Find(ws, flannel, now.Add(2*time.Hour)); !ok || !w.Expired(now.Add(2*time.Hour)) {
+		t.Errorf("flannel waiver should have expired the day after")
+	}
+	if _, ok := Find(ws, model.Finding{ID: "POD_HOSTPATH", ResourceID: "shop/db-0"}, now); ok {
+		t.Errorf("waiver must not match other namespaces")
+	}
+	if _, ok := Find(ws, model.Finding{ID: "SINGLE_AZ_CLUSTER", ResourceID: "cluster"}, now); !ok {
+		t.Errorf("ID-only waiver should match")
+	}
+}
+
+func TestLoadRejectsIncompleteWaiver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waivers.yaml")
+	if err := os.WriteFile(path, []byte("waivers:\n  - id: PV_ORPHAN\n    owner: me\n    expires: 2026-01-01\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a waiver without justification")
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	cases := map[string][]string{
+		"shop/db":              {"shop"},
+		"pods:a/x,b/y...":      {"a", "b"},
+		"cluster":              nil,
+		"namespaces:shop,prod": {"shop", "prod"},
+	}
+	for in, want := range cases {
+		got := Namespaces(in)
+		if len(got) != len(want) {
+			t.Errorf("Namespaces(%q) = %v, want %v", in, got, want)
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("Namespaces(%q) = %v, want %v", in, got, want)
+			}
+		}
+	}
+}